@@ -0,0 +1,252 @@
+package algorithms
+
+import (
+	"context"
+	"families-linkedin/internal/kinship"
+	"families-linkedin/internal/models"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// PathConstraints restricts which families and connections a path search may traverse.
+// A nil or zero value leaves the search unrestricted.
+type PathConstraints struct {
+	AllowedRelationTypes []string `json:"allowed_relation_types,omitempty"` // Matched against relation_type or specific_relation
+	MinStrength          float64  `json:"min_strength,omitempty"`
+	VerifiedOnly         bool     `json:"verified_only,omitempty"`
+	ExcludedFamilyIDs    []string `json:"excluded_family_ids,omitempty"`
+	ViaFamilyID          string   `json:"via_family_id,omitempty"` // Family every path must pass through
+}
+
+// IsEmpty reports whether the constraints leave the search unrestricted
+func (c *PathConstraints) IsEmpty() bool {
+	return c == nil || (!c.filtersEdges() && len(c.ExcludedFamilyIDs) == 0 && c.ViaFamilyID == "")
+}
+
+// Allows reports whether a connection satisfies the edge constraints
+func (c *PathConstraints) Allows(connection *models.FamilyConnection) bool {
+	if c == nil {
+		return true
+	}
+	if connection == nil {
+		return false
+	}
+	if c.VerifiedOnly && !connection.Verified {
+		return false
+	}
+	if connection.Strength < c.MinStrength {
+		return false
+	}
+	if len(c.AllowedRelationTypes) == 0 {
+		return true
+	}
+	for _, relationType := range c.AllowedRelationTypes {
+		if relationType == connection.RelationType || relationType == connection.SpecificRelation {
+			return true
+		}
+	}
+	return false
+}
+
+// filtersEdges reports whether the constraints restrict individual connections
+func (c *PathConstraints) filtersEdges() bool {
+	return len(c.AllowedRelationTypes) > 0 || c.MinStrength > 0 || c.VerifiedOnly
+}
+
+// excludes reports whether a family is on the avoid-list
+func (c *PathConstraints) excludes(familyID string) bool {
+	for _, excludedID := range c.ExcludedFamilyIDs {
+		if excludedID == familyID {
+			return true
+		}
+	}
+	return false
+}
+
+// pathSearch runs an unconstrained search for up to maxPaths ranked paths over repo
+type pathSearch func(ctx context.Context, repo GraphRepository, fromID, toID string, maxDepth, maxPaths int) ([]*models.ConnectionPath, error)
+
+// maxViaFirstLegs caps how many routes to a via-family are tried for continuations to the target
+const maxViaFirstLegs = 64
+
+// findConstrainedPaths runs search over a view of repo that hides the families and connections
+// the constraints rule out. A via-family splits the search into two legs joined at that family.
+func findConstrainedPaths(ctx context.Context, repo GraphRepository, fromID, toID string, maxDepth, maxPaths int,
+	constraints *PathConstraints, rankBy string, decay *models.StrengthDecay, search pathSearch) ([]*models.ConnectionPath, error) {
+	if constraints.IsEmpty() || fromID == toID {
+		return search(ctx, repo, fromID, toID, maxDepth, maxPaths)
+	}
+
+	if constraints.excludes(fromID) || constraints.excludes(toID) {
+		return nil, nil // An endpoint is on the avoid-list
+	}
+
+	viaID := constraints.ViaFamilyID
+	if viaID == "" || viaID == fromID || viaID == toID {
+		return search(ctx, newConstrainedRepository(repo, constraints, nil), fromID, toID, maxDepth, maxPaths)
+	}
+
+	if constraints.excludes(viaID) {
+		return nil, nil
+	}
+
+	// The best route to the via family may leave no way on to the target without revisiting a
+	// family, so alternative first legs are ranked by Yen's algorithm and tried in order, fetching
+	// more until enough continue or none are left. The first leg must not pass through the target.
+	firstLegFinder := NewKShortestPaths(newConstrainedRepository(repo, constraints, []string{toID}), rankBy, decay)
+	tried := make(map[string]bool)
+	var paths []*models.ConnectionPath
+
+	for want := min(max(maxPaths, 4), maxViaFirstLegs); ; want = min(want*2, maxViaFirstLegs) {
+		firstLegs, err := firstLegFinder.findPaths(ctx, fromID, viaID, maxDepth-1, want)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find path to via family %s: %w", viaID, err)
+		}
+
+		for _, first := range firstLegs {
+			if tried[pathKey(first.Path)] {
+				continue
+			}
+			tried[pathKey(first.Path)] = true
+
+			// The second leg must not revisit families from the first leg
+			visited := first.Path[:len(first.Path)-1]
+			secondLegs, err := search(ctx, newConstrainedRepository(repo, constraints, visited), viaID, toID, maxDepth-first.Degree, maxPaths)
+			if err != nil {
+				return nil, fmt.Errorf("failed to find path from via family %s: %w", viaID, err)
+			}
+
+			for _, second := range secondLegs {
+				paths = append(paths, joinPaths(first, second))
+			}
+		}
+
+		if len(paths) >= maxPaths || len(firstLegs) < want || want == maxViaFirstLegs {
+			break
+		}
+	}
+
+	rankPaths(paths, rankBy)
+	if len(paths) > maxPaths {
+		paths = paths[:maxPaths]
+	}
+
+	return paths, nil
+}
+
+// joinPaths concatenates two paths that meet at a shared family
+func joinPaths(first, second *models.ConnectionPath) *models.ConnectionPath {
+	path := append(append([]string{}, first.Path...), second.Path[1:]...)
+	relationTypes := append(append([]string{}, first.RelationTypes...), second.RelationTypes...)
+	edges := append(append([]models.PathEdge{}, first.Edges...), second.Edges...)
+	specificRelation, _ := kinship.PathRelation(edges)
+
+	return &models.ConnectionPath{
+		SourceFamilyID:   first.SourceFamilyID,
+		TargetFamilyID:   second.TargetFamilyID,
+		Path:             path,
+		Degree:           first.Degree + second.Degree,
+		PathStrength:     first.PathStrength * second.PathStrength,
+		RelationTypes:    relationTypes,
+		SpecificRelation: specificRelation,
+		Edges:            edges,
+		Verified:         first.Verified && second.Verified,
+		CalculatedAt:     time.Now(),
+	}
+}
+
+// rankPaths orders paths by the given ranking
+func rankPaths(paths []*models.ConnectionPath, rankBy string) {
+	sort.SliceStable(paths, func(i, j int) bool {
+		if rankBy == RankByStrength && paths[i].PathStrength != paths[j].PathStrength {
+			return paths[i].PathStrength > paths[j].PathStrength
+		}
+		if paths[i].Degree != paths[j].Degree {
+			return paths[i].Degree < paths[j].Degree
+		}
+		return paths[i].PathStrength > paths[j].PathStrength
+	})
+}
+
+// singlePath adapts a single-path search result to a pathSearch result
+func singlePath(path *models.ConnectionPath, err error) ([]*models.ConnectionPath, error) {
+	if err != nil || path == nil {
+		return nil, err
+	}
+	return []*models.ConnectionPath{path}, nil
+}
+
+// firstPath returns the best ranked path of a pathSearch result
+func firstPath(paths []*models.ConnectionPath, err error) (*models.ConnectionPath, error) {
+	if err != nil || len(paths) == 0 {
+		return nil, err
+	}
+	return paths[0], nil
+}
+
+// constrainedRepository hides the families and connections that violate a set of constraints
+type constrainedRepository struct {
+	repo        GraphRepository
+	constraints *PathConstraints
+	excluded    map[string]bool
+}
+
+func newConstrainedRepository(repo GraphRepository, constraints *PathConstraints, extraExclusions []string) *constrainedRepository {
+	excluded := make(map[string]bool, len(constraints.ExcludedFamilyIDs)+len(extraExclusions))
+	for _, familyID := range constraints.ExcludedFamilyIDs {
+		excluded[familyID] = true
+	}
+	for _, familyID := range extraExclusions {
+		excluded[familyID] = true
+	}
+
+	return &constrainedRepository{
+		repo:        repo,
+		constraints: constraints,
+		excluded:    excluded,
+	}
+}
+
+func (c *constrainedRepository) GetNeighbors(ctx context.Context, familyID string) ([]*models.FamilyConnection, error) {
+	edges, err := c.repo.GetNeighbors(ctx, familyID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.allowed(edges), nil
+}
+
+func (c *constrainedRepository) GetNeighborsBulk(ctx context.Context, familyIDs []string) (map[string][]*models.FamilyConnection, error) {
+	neighbors, err := c.repo.GetNeighborsBulk(ctx, familyIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string][]*models.FamilyConnection, len(neighbors))
+	for familyID, edges := range neighbors {
+		result[familyID] = c.allowed(edges)
+	}
+
+	return result, nil
+}
+
+// allowed drops the edges leading to excluded families or violating the edge constraints
+func (c *constrainedRepository) allowed(edges []*models.FamilyConnection) []*models.FamilyConnection {
+	allowed := make([]*models.FamilyConnection, 0, len(edges))
+	for _, edge := range edges {
+		if c.excluded[edge.ToFamilyID] || !c.constraints.Allows(edge) {
+			continue
+		}
+		allowed = append(allowed, edge)
+	}
+	return allowed
+}
+
+func (c *constrainedRepository) GetConnection(ctx context.Context, from, to string) (*models.FamilyConnection, error) {
+	return c.repo.GetConnection(ctx, from, to)
+}
+
+func (c *constrainedRepository) GetFamilyTrustScore(ctx context.Context, familyID string) (float64, error) {
+	return c.repo.GetFamilyTrustScore(ctx, familyID)
+}
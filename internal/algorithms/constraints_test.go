@@ -0,0 +1,72 @@
+package algorithms_test
+
+import (
+	"context"
+	"families-linkedin/internal/algorithms"
+	"reflect"
+	"testing"
+)
+
+func TestViaFamilyTriesAlternativeFirstLegs(t *testing.T) {
+	// The shortest way from A to V runs through B, but the only way from V on to T also
+	// needs B, so the path must reach V the long way round through C and D
+	graph := newMemoryGraph(t,
+		edge{"A", "B", 0.9}, edge{"B", "V", 0.9}, edge{"B", "T", 0.9},
+		edge{"A", "C", 0.8}, edge{"C", "D", 0.8}, edge{"D", "V", 0.8},
+	)
+	constraints := &algorithms.PathConstraints{ViaFamilyID: "V"}
+	want := "A-C-D-V-B-T"
+
+	finders := map[string]algorithms.PathFinder{
+		"bidirectional BFS": algorithms.NewBidirectionalBFS(graph, nil),
+		"strongest":         algorithms.NewStrongestPathFinder(graph, nil),
+		"k-shortest":        algorithms.NewKShortestPaths(graph, algorithms.RankByHops, nil),
+	}
+	for name, finder := range finders {
+		t.Run(name, func(t *testing.T) {
+			path, err := finder.FindPath(context.Background(), "A", "T", 6, constraints)
+			if err != nil {
+				t.Fatalf("FindPath: %v", err)
+			}
+			if got := route(path); got != want {
+				t.Errorf("FindPath via V = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestPathConstraints(t *testing.T) {
+	graph := newMemoryGraph(t,
+		edge{"A", "B", 0.9}, edge{"B", "D", 0.9},
+		edge{"A", "C", 0.4}, edge{"C", "D", 0.4},
+		edge{"A", "E", 0.6}, edge{"E", "F", 0.6}, edge{"F", "D", 0.6},
+	)
+
+	tests := []struct {
+		name        string
+		constraints *algorithms.PathConstraints
+		maxDepth    int
+		want        []string
+	}{
+		{"unconstrained", nil, 4, []string{"A-B-D", "A-C-D", "A-E-F-D"}},
+		{"excluded family", &algorithms.PathConstraints{ExcludedFamilyIDs: []string{"B"}}, 4, []string{"A-C-D", "A-E-F-D"}},
+		{"minimum strength", &algorithms.PathConstraints{MinStrength: 0.5}, 4, []string{"A-B-D", "A-E-F-D"}},
+		{"via family", &algorithms.PathConstraints{ViaFamilyID: "E"}, 4, []string{"A-E-F-D"}},
+		{"via family beyond max depth", &algorithms.PathConstraints{ViaFamilyID: "E"}, 2, []string{}},
+		{"excluded endpoint", &algorithms.PathConstraints{ExcludedFamilyIDs: []string{"D"}}, 4, []string{}},
+		{"excluded via family", &algorithms.PathConstraints{ViaFamilyID: "E", ExcludedFamilyIDs: []string{"E"}}, 4, []string{}},
+	}
+
+	finder := algorithms.NewKShortestPaths(graph, algorithms.RankByHops, nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paths, err := finder.FindMultiplePaths(context.Background(), "A", "D", tt.maxDepth, 5, tt.constraints)
+			if err != nil {
+				t.Fatalf("FindMultiplePaths: %v", err)
+			}
+			if got := routes(paths); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FindMultiplePaths = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
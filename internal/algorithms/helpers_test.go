@@ -0,0 +1,92 @@
+package algorithms_test
+
+import (
+	"context"
+	"families-linkedin/internal/models"
+	"families-linkedin/internal/repository/memory"
+	"strings"
+	"testing"
+)
+
+// edge is a verified connection between two test families
+type edge struct {
+	from, to string
+	strength float64
+}
+
+// memoryGraph adapts the memory backend to GraphRepository, as the connection service does
+type memoryGraph struct {
+	connections *memory.ConnectionRepository
+	families    *memory.FamilyRepository
+}
+
+// newMemoryGraph stores a family for every ID named by the edges and connects them
+func newMemoryGraph(t *testing.T, edges ...edge) *memoryGraph {
+	t.Helper()
+
+	ctx := context.Background()
+	store := memory.NewStore()
+	graph := &memoryGraph{
+		connections: memory.NewConnectionRepository(store),
+		families:    memory.NewFamilyRepository(store),
+	}
+
+	created := make(map[string]bool)
+	for _, e := range edges {
+		for _, familyID := range []string{e.from, e.to} {
+			if created[familyID] {
+				continue
+			}
+			family := models.NewFamily(familyID, familyID)
+			family.ID = familyID
+			if err := graph.families.CreateFamily(ctx, family); err != nil {
+				t.Fatalf("CreateFamily(%s): %v", familyID, err)
+			}
+			created[familyID] = true
+		}
+
+		connection := models.NewFamilyConnection(e.from, e.to, "RELATIVE", "COUSIN", e.strength, true)
+		if err := graph.connections.CreateConnection(ctx, connection); err != nil {
+			t.Fatalf("CreateConnection(%s, %s): %v", e.from, e.to, err)
+		}
+	}
+
+	return graph
+}
+
+func (g *memoryGraph) GetNeighbors(ctx context.Context, familyID string) ([]*models.FamilyConnection, error) {
+	return g.connections.GetFamilyEdges(ctx, familyID)
+}
+
+func (g *memoryGraph) GetNeighborsBulk(ctx context.Context, familyIDs []string) (map[string][]*models.FamilyConnection, error) {
+	return g.connections.GetFamilyEdgesBulk(ctx, familyIDs)
+}
+
+func (g *memoryGraph) GetConnection(ctx context.Context, from, to string) (*models.FamilyConnection, error) {
+	return g.connections.GetConnection(ctx, from, to)
+}
+
+func (g *memoryGraph) GetFamilyTrustScore(ctx context.Context, familyID string) (float64, error) {
+	family, err := g.families.GetFamilyByID(ctx, familyID)
+	if err != nil || family == nil {
+		return 0, err
+	}
+	return family.TrustScore, nil
+}
+
+// route renders a path as "A-B-C", or "" for no path
+func route(path *models.ConnectionPath) string {
+	if path == nil {
+		return ""
+	}
+	return strings.Join(path.Path, "-")
+}
+
+// routes renders several paths with route
+func routes(paths []*models.ConnectionPath) []string {
+	rendered := make([]string, 0, len(paths))
+	for _, path := range paths {
+		rendered = append(rendered, route(path))
+	}
+	return rendered
+}
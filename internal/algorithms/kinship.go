@@ -0,0 +1,222 @@
+package algorithms
+
+import (
+	"context"
+	"families-linkedin/internal/models"
+	"fmt"
+	"time"
+)
+
+// KinshipRepository reads the PARENT_OF and SPOUSE_OF relationships between persons.
+// Relationships are returned as stored, under every person they touch.
+type KinshipRepository interface {
+	GetPersonRelationsBulk(ctx context.Context, personIDs []string) (map[string][]*models.PersonRelation, error)
+}
+
+// KinshipPathFinder finds how two persons are related through parent, child and spouse links
+type KinshipPathFinder struct {
+	repo KinshipRepository
+}
+
+// NewKinshipPathFinder creates a new person-level path finder
+func NewKinshipPathFinder(repo KinshipRepository) *KinshipPathFinder {
+	return &KinshipPathFinder{repo: repo}
+}
+
+// kinshipLink records how a person was reached during the search
+type kinshipLink struct {
+	previous string
+	relation *models.PersonRelation
+}
+
+// FindPath finds the shortest chain of relationships between two persons with a bidirectional
+// BFS, one bulk relationship query per level. Among chains of equal length, the first found
+// wins. It returns nil when the persons are not related within maxDepth steps.
+func (kpf *KinshipPathFinder) FindPath(ctx context.Context, fromID, toID string, maxDepth int) (*models.KinshipPath, error) {
+	if fromID == toID {
+		return &models.KinshipPath{
+			FromPersonID: fromID,
+			ToPersonID:   toID,
+			PersonIDs:    []string{fromID},
+			Steps:        []models.KinshipStep{},
+			CalculatedAt: time.Now(),
+		}, nil
+	}
+
+	// Links double as the visited sets of each direction
+	forwardLinks := map[string]kinshipLink{fromID: {}}
+	backwardLinks := map[string]kinshipLink{toID: {}}
+
+	forwardFrontier := []string{fromID}
+	backwardFrontier := []string{toID}
+
+	for steps := 1; steps <= maxDepth; steps++ {
+		if len(forwardFrontier) == 0 || len(backwardFrontier) == 0 {
+			break
+		}
+
+		var meeting string
+		var err error
+
+		// Expand the smaller frontier to keep both searches balanced
+		if len(forwardFrontier) <= len(backwardFrontier) {
+			forwardFrontier, meeting, err = kpf.expandLevel(ctx, forwardFrontier, forwardLinks, backwardLinks)
+		} else {
+			backwardFrontier, meeting, err = kpf.expandLevel(ctx, backwardFrontier, backwardLinks, forwardLinks)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if meeting != "" {
+			return joinKinship(fromID, toID, meeting, forwardLinks, backwardLinks), nil
+		}
+	}
+
+	return nil, nil // Not related within maxDepth
+}
+
+// expandLevel expands a whole frontier with one bulk relationship query. It returns the next
+// frontier and, if the level reached a person already visited by the opposite search, that person.
+func (kpf *KinshipPathFinder) expandLevel(ctx context.Context, frontier []string, links, opposite map[string]kinshipLink) ([]string, string, error) {
+	relations, err := kpf.repo.GetPersonRelationsBulk(ctx, frontier)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get relations for %d persons: %w", len(frontier), err)
+	}
+
+	var next []string
+	for _, personID := range frontier {
+		for _, relation := range relations[personID] {
+			relative := relation.Other(personID)
+			if _, visited := links[relative]; visited {
+				continue // Already reached at this depth or earlier
+			}
+
+			links[relative] = kinshipLink{previous: personID, relation: relation}
+			if _, met := opposite[relative]; met {
+				return next, relative, nil
+			}
+			next = append(next, relative)
+		}
+	}
+
+	return next, "", nil
+}
+
+// joinKinship rebuilds the chain through the person where both searches met
+func joinKinship(fromID, toID, meeting string, forwardLinks, backwardLinks map[string]kinshipLink) *models.KinshipPath {
+	path := &models.KinshipPath{
+		FromPersonID: fromID,
+		ToPersonID:   toID,
+		CalculatedAt: time.Now(),
+	}
+
+	// Forward links point back towards fromID, so walk them and reverse
+	var forward []string
+	for personID := meeting; personID != fromID; personID = forwardLinks[personID].previous {
+		forward = append(forward, personID)
+	}
+	path.PersonIDs = append([]string{fromID}, reverse(forward)...)
+	for i := 1; i < len(path.PersonIDs); i++ {
+		path.Steps = append(path.Steps, kinshipStep(path.PersonIDs[i-1], forwardLinks[path.PersonIDs[i]].relation))
+	}
+
+	// Backward links point on towards toID
+	for personID := meeting; personID != toID; {
+		link := backwardLinks[personID]
+		path.Steps = append(path.Steps, kinshipStep(personID, link.relation))
+		personID = link.previous
+		path.PersonIDs = append(path.PersonIDs, personID)
+	}
+
+	return path
+}
+
+// kinshipStep describes crossing a relationship from one person
+func kinshipStep(fromID string, relation *models.PersonRelation) models.KinshipStep {
+	return models.KinshipStep{
+		FromPersonID: fromID,
+		ToPersonID:   relation.Other(fromID),
+		Kin:          relation.KinFrom(fromID),
+	}
+}
+
+// Ancestor is an ancestor of a person, reached through one of the person's parents
+type Ancestor struct {
+	PersonID    string
+	ParentID    string // The person's parent the line of ascent passes through
+	Generations int    // 1 for a parent, 2 for a grandparent and so on
+}
+
+// FindAncestors lists a person's ancestors up to the given number of generations, one bulk
+// relationship query per generation. An ancestor reached through both parents is listed once
+// per parent, at the fewest generations along each line.
+func (kpf *KinshipPathFinder) FindAncestors(ctx context.Context, personID string, generations int) ([]Ancestor, error) {
+	ancestors, err := kpf.FindAncestorsBulk(ctx, []string{personID}, generations)
+	if err != nil {
+		return nil, err
+	}
+	return ancestors[personID], nil
+}
+
+// FindAncestorsBulk lists the ancestors of several persons as FindAncestors does, keyed by
+// person, with one bulk relationship query per generation for all of them
+func (kpf *KinshipPathFinder) FindAncestorsBulk(ctx context.Context, personIDs []string, generations int) (map[string][]Ancestor, error) {
+	type lineKey struct{ descendantID, personID, parentID string }
+	type ascent struct {
+		descendantID string
+		Ancestor
+	}
+
+	ancestors := make(map[string][]Ancestor, len(personIDs))
+	seen := make(map[lineKey]bool)
+	frontier := make([]ascent, 0, len(personIDs))
+	for _, personID := range personIDs {
+		if _, listed := ancestors[personID]; !listed {
+			ancestors[personID] = nil
+			frontier = append(frontier, ascent{descendantID: personID, Ancestor: Ancestor{PersonID: personID}})
+		}
+	}
+
+	for generation := 1; generation <= generations && len(frontier) > 0; generation++ {
+		queried := make(map[string]bool, len(frontier))
+		var levelIDs []string
+		for _, child := range frontier {
+			if !queried[child.PersonID] {
+				queried[child.PersonID] = true
+				levelIDs = append(levelIDs, child.PersonID)
+			}
+		}
+
+		relations, err := kpf.repo.GetPersonRelationsBulk(ctx, levelIDs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get relations for %d persons: %w", len(levelIDs), err)
+		}
+
+		var next []ascent
+		for _, child := range frontier {
+			for _, relation := range relations[child.PersonID] {
+				if relation.Type != models.RelationParentOf || relation.ToPersonID != child.PersonID {
+					continue // Not one of the child's parents
+				}
+
+				parentID := child.ParentID
+				if generation == 1 {
+					parentID = relation.FromPersonID
+				}
+				key := lineKey{child.descendantID, relation.FromPersonID, parentID}
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+
+				ancestor := Ancestor{PersonID: relation.FromPersonID, ParentID: parentID, Generations: generation}
+				ancestors[child.descendantID] = append(ancestors[child.descendantID], ancestor)
+				next = append(next, ascent{descendantID: child.descendantID, Ancestor: ancestor})
+			}
+		}
+		frontier = next
+	}
+
+	return ancestors, nil
+}
@@ -0,0 +1,83 @@
+package algorithms_test
+
+import (
+	"context"
+	"families-linkedin/internal/algorithms"
+	"families-linkedin/internal/models"
+	"families-linkedin/internal/repository/memory"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestKinshipPathFinderFindPath(t *testing.T) {
+	// G is the father of F and U, F and M are married and the parents of S, U is the
+	// father of C, and X is related to nobody
+	ctx := context.Background()
+	store := memory.NewStore()
+	family := models.NewFamily("FAM", "FAM")
+	family.ID = "FAM"
+	if err := memory.NewFamilyRepository(store).CreateFamily(ctx, family); err != nil {
+		t.Fatalf("CreateFamily: %v", err)
+	}
+
+	persons := memory.NewPersonRepository(store)
+	for _, personID := range []string{"G", "F", "U", "M", "S", "C", "X"} {
+		person := models.NewPerson("FAM", personID, "FAM", "MALE", time.Now().AddDate(-30, 0, 0))
+		person.ID = personID
+		if err := persons.CreatePerson(ctx, person); err != nil {
+			t.Fatalf("CreatePerson(%s): %v", personID, err)
+		}
+	}
+	for _, relation := range []*models.PersonRelation{
+		models.NewPersonRelation("G", "F", models.RelationParentOf),
+		models.NewPersonRelation("G", "U", models.RelationParentOf),
+		models.NewPersonRelation("F", "M", models.RelationSpouseOf),
+		models.NewPersonRelation("F", "S", models.RelationParentOf),
+		models.NewPersonRelation("M", "S", models.RelationParentOf),
+		models.NewPersonRelation("U", "C", models.RelationParentOf),
+	} {
+		if err := persons.CreatePersonRelation(ctx, relation); err != nil {
+			t.Fatalf("CreatePersonRelation(%s, %s): %v", relation.FromPersonID, relation.ToPersonID, err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		from, to string
+		maxDepth int
+		want     string // Persons on the path, then what each is to the one before
+	}{
+		{"parent", "S", "M", 4, "S-M PARENT"},
+		{"cousin through the grandparent", "S", "C", 4, "S-F-G-U-C PARENT-PARENT-CHILD-CHILD"},
+		{"through a marriage", "M", "U", 4, "M-F-G-U SPOUSE-PARENT-CHILD"},
+		{"same person", "S", "S", 4, "S "},
+		{"beyond max depth", "S", "C", 3, ""},
+		{"unrelated", "S", "X", 12, ""},
+	}
+
+	finder := algorithms.NewKinshipPathFinder(persons)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, err := finder.FindPath(ctx, tt.from, tt.to, tt.maxDepth)
+			if err != nil {
+				t.Fatalf("FindPath: %v", err)
+			}
+
+			got := ""
+			if path != nil {
+				kin := make([]string, len(path.Steps))
+				for i, step := range path.Steps {
+					kin[i] = step.Kin
+					if step.FromPersonID != path.PersonIDs[i] || step.ToPersonID != path.PersonIDs[i+1] {
+						t.Errorf("step %d goes %s-%s, not along the path", i, step.FromPersonID, step.ToPersonID)
+					}
+				}
+				got = strings.Join(path.PersonIDs, "-") + " " + strings.Join(kin, "-")
+			}
+			if got != tt.want {
+				t.Errorf("FindPath = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
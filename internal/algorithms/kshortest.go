@@ -0,0 +1,315 @@
+package algorithms
+
+import (
+	"container/heap"
+	"context"
+	"families-linkedin/internal/models"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Ranking orders for multiple path searches
+const (
+	RankByHops     = "hops"     // Fewest hops first, stronger paths first among equal lengths
+	RankByStrength = "strength" // Highest product of connection strengths first
+)
+
+// IsValidRanking reports whether rankBy names a supported path ranking
+func IsValidRanking(rankBy string) bool {
+	return rankBy == RankByHops || rankBy == RankByStrength
+}
+
+// KShortestPaths implements Yen's algorithm for the K shortest loopless paths.
+// Each spur search is a hop-bounded Dijkstra through the GraphRepository, so
+// the returned paths are distinct, cycle-free and ranked by the chosen order.
+type KShortestPaths struct {
+	repo   GraphRepository
+	rankBy string
+	decay  *models.StrengthDecay // Applied to connection strengths before weighting
+}
+
+// NewKShortestPaths creates a new Yen's K-shortest-paths finder
+func NewKShortestPaths(repo GraphRepository, rankBy string, decay *models.StrengthDecay) *KShortestPaths {
+	if !IsValidRanking(rankBy) {
+		rankBy = RankByHops
+	}
+	return &KShortestPaths{repo: repo, rankBy: rankBy, decay: decay}
+}
+
+// FindPath finds the best ranked path between two families
+func (ksp *KShortestPaths) FindPath(ctx context.Context, fromID, toID string, maxDepth int, constraints *PathConstraints) (*models.ConnectionPath, error) {
+	return firstPath(ksp.FindMultiplePaths(ctx, fromID, toID, maxDepth, 1, constraints))
+}
+
+// FindMultiplePaths finds up to maxPaths ranked, distinct loopless paths between two families
+func (ksp *KShortestPaths) FindMultiplePaths(ctx context.Context, fromID, toID string, maxDepth, maxPaths int, constraints *PathConstraints) ([]*models.ConnectionPath, error) {
+	return findConstrainedPaths(ctx, ksp.repo, fromID, toID, maxDepth, maxPaths, constraints, ksp.rankBy, ksp.decay,
+		func(ctx context.Context, repo GraphRepository, fromID, toID string, maxDepth, maxPaths int) ([]*models.ConnectionPath, error) {
+			return NewKShortestPaths(repo, ksp.rankBy, ksp.decay).findPaths(ctx, fromID, toID, maxDepth, maxPaths)
+		})
+}
+
+// findPaths runs Yen's algorithm without constraints
+func (ksp *KShortestPaths) findPaths(ctx context.Context, fromID, toID string, maxDepth, maxPaths int) ([]*models.ConnectionPath, error) {
+	if fromID == toID || maxPaths <= 0 {
+		return nil, nil
+	}
+
+	// Spur searches revisit the same families many times, so memoize lookups for this query
+	repo := newMemoizedRepository(ksp.repo)
+	weight := ksp.weight()
+
+	first, err := weightedSearch(ctx, repo, fromID, toID, maxDepth, weight, ksp.decay, nil)
+	if err != nil {
+		return nil, err
+	}
+	if first == nil {
+		return nil, nil // No path found
+	}
+
+	accepted := [][]string{first}
+	seen := map[string]bool{pathKey(first): true}
+	candidates := &candidateQueue{}
+
+	for len(accepted) < maxPaths {
+		previous := accepted[len(accepted)-1]
+
+		for i := 0; i < len(previous)-1; i++ {
+			spurNode := previous[i]
+			rootPath := previous[:i+1]
+
+			exclusions := &searchExclusions{
+				families: make(map[string]bool),
+				edges:    make(map[string]bool),
+			}
+
+			// Remove the next edge of every accepted path sharing this root
+			for _, path := range accepted {
+				if len(path) > i+1 && samePrefix(path, rootPath) {
+					exclusions.edges[edgeKey(path[i], path[i+1])] = true
+				}
+			}
+
+			// Remove root path families so the spur cannot loop back through them
+			for _, familyID := range rootPath[:i] {
+				exclusions.families[familyID] = true
+			}
+
+			spurPath, err := weightedSearch(ctx, repo, spurNode, toID, maxDepth-i, weight, ksp.decay, exclusions)
+			if err != nil {
+				return nil, err
+			}
+			if spurPath == nil {
+				continue
+			}
+
+			candidate := append(append([]string{}, rootPath[:i]...), spurPath...)
+			key := pathKey(candidate)
+			if seen[key] {
+				continue
+			}
+
+			cost, err := ksp.pathCost(ctx, repo, candidate)
+			if err != nil {
+				return nil, err
+			}
+
+			seen[key] = true
+			heap.Push(candidates, &candidatePath{path: candidate, cost: cost})
+		}
+
+		if candidates.Len() == 0 {
+			break
+		}
+
+		accepted = append(accepted, heap.Pop(candidates).(*candidatePath).path)
+	}
+
+	paths := make([]*models.ConnectionPath, 0, len(accepted))
+	for _, path := range accepted {
+		connectionPath := &models.ConnectionPath{
+			SourceFamilyID: fromID,
+			TargetFamilyID: toID,
+			Path:           path,
+			Degree:         len(path) - 1,
+			CalculatedAt:   time.Now(),
+		}
+
+		if err := calculatePathStrength(ctx, repo, connectionPath, ksp.decay); err != nil {
+			return nil, err
+		}
+
+		paths = append(paths, connectionPath)
+	}
+
+	return paths, nil
+}
+
+// weight returns the edge cost function for the configured ranking
+func (ksp *KShortestPaths) weight() edgeWeight {
+	if ksp.rankBy == RankByStrength {
+		return strengthWeight
+	}
+	return hopWeight
+}
+
+// pathCost sums the edge costs along a path
+func (ksp *KShortestPaths) pathCost(ctx context.Context, repo GraphRepository, path []string) (float64, error) {
+	weight := ksp.weight()
+	now := time.Now()
+	cost := 0.0
+
+	for i := 0; i < len(path)-1; i++ {
+		connection, err := repo.GetConnection(ctx, path[i], path[i+1])
+		if err != nil {
+			return 0, fmt.Errorf("failed to get connection between %s and %s: %w",
+				path[i], path[i+1], err)
+		}
+		if connection == nil {
+			return 0, fmt.Errorf("no connection between %s and %s", path[i], path[i+1])
+		}
+		cost += weight(connection.DecayedStrength(ksp.decay, now))
+	}
+
+	return cost, nil
+}
+
+// samePrefix reports whether path starts with prefix
+func samePrefix(path, prefix []string) bool {
+	if len(path) < len(prefix) {
+		return false
+	}
+	for i := range prefix {
+		if path[i] != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// candidatePath is a potential next path in Yen's algorithm
+type candidatePath struct {
+	path []string
+	cost float64
+}
+
+type candidateQueue []*candidatePath
+
+func (q candidateQueue) Len() int { return len(q) }
+func (q candidateQueue) Less(i, j int) bool {
+	if q[i].cost != q[j].cost {
+		return q[i].cost < q[j].cost
+	}
+	return pathKey(q[i].path) < pathKey(q[j].path)
+}
+func (q candidateQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+func (q *candidateQueue) Push(x interface{}) {
+	*q = append(*q, x.(*candidatePath))
+}
+func (q *candidateQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	candidate := old[n-1]
+	*q = old[:n-1]
+	return candidate
+}
+
+// memoizedRepository caches neighbor and connection lookups for the lifetime of a single query
+type memoizedRepository struct {
+	repo        GraphRepository
+	neighbors   map[string][]*models.FamilyConnection
+	connections map[string]*models.FamilyConnection
+	mutex       sync.Mutex
+}
+
+func newMemoizedRepository(repo GraphRepository) *memoizedRepository {
+	return &memoizedRepository{
+		repo:        repo,
+		neighbors:   make(map[string][]*models.FamilyConnection),
+		connections: make(map[string]*models.FamilyConnection),
+	}
+}
+
+func (m *memoizedRepository) GetNeighbors(ctx context.Context, familyID string) ([]*models.FamilyConnection, error) {
+	m.mutex.Lock()
+	neighbors, ok := m.neighbors[familyID]
+	m.mutex.Unlock()
+	if ok {
+		return neighbors, nil
+	}
+
+	neighbors, err := m.repo.GetNeighbors(ctx, familyID)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mutex.Lock()
+	m.neighbors[familyID] = neighbors
+	for _, connection := range neighbors {
+		m.connections[familyID+"->"+connection.ToFamilyID] = connection
+	}
+	m.mutex.Unlock()
+	return neighbors, nil
+}
+
+func (m *memoizedRepository) GetNeighborsBulk(ctx context.Context, familyIDs []string) (map[string][]*models.FamilyConnection, error) {
+	result := make(map[string][]*models.FamilyConnection, len(familyIDs))
+	var missing []string
+
+	m.mutex.Lock()
+	for _, familyID := range familyIDs {
+		if neighbors, ok := m.neighbors[familyID]; ok {
+			result[familyID] = neighbors
+		} else {
+			missing = append(missing, familyID)
+		}
+	}
+	m.mutex.Unlock()
+
+	if len(missing) == 0 {
+		return result, nil
+	}
+
+	fetched, err := m.repo.GetNeighborsBulk(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mutex.Lock()
+	for _, familyID := range missing {
+		neighbors := fetched[familyID]
+		m.neighbors[familyID] = neighbors
+		for _, connection := range neighbors {
+			m.connections[familyID+"->"+connection.ToFamilyID] = connection
+		}
+		result[familyID] = neighbors
+	}
+	m.mutex.Unlock()
+	return result, nil
+}
+
+func (m *memoizedRepository) GetConnection(ctx context.Context, from, to string) (*models.FamilyConnection, error) {
+	key := from + "->" + to
+
+	m.mutex.Lock()
+	connection, ok := m.connections[key]
+	m.mutex.Unlock()
+	if ok {
+		return connection, nil
+	}
+
+	connection, err := m.repo.GetConnection(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mutex.Lock()
+	m.connections[key] = connection
+	m.mutex.Unlock()
+	return connection, nil
+}
+
+func (m *memoizedRepository) GetFamilyTrustScore(ctx context.Context, familyID string) (float64, error) {
+	return m.repo.GetFamilyTrustScore(ctx, familyID)
+}
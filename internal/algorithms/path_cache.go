@@ -0,0 +1,275 @@
+package algorithms
+
+import (
+	"context"
+	"families-linkedin/internal/cache"
+	"families-linkedin/internal/models"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// PathInvalidationChannel carries connection changes between replicas sharing a cache backend
+const PathInvalidationChannel = "path-invalidations"
+
+// negativePathTag marks cached searches that found no path, which any new connection may change
+const negativePathTag = "path-negative"
+
+// PathCacheKey identifies a cached path search
+type PathCacheKey struct {
+	FromID   string
+	ToID     string
+	MaxDepth int
+	MaxPaths int // 0 for single path searches
+	Mode     string
+}
+
+func (k PathCacheKey) String() string {
+	return fmt.Sprintf("path:%s|%d|%d|%s->%s", k.Mode, k.MaxDepth, k.MaxPaths, k.FromID, k.ToID)
+}
+
+// PathCache caches path search results in a cache.Backend. Each result is tagged with the
+// families its paths pass through, so a connection change only invalidates the searches it
+// can affect. Concurrent misses for the same key within a process share a single search.
+type PathCache struct {
+	backend cache.Backend
+	ttl     time.Duration
+	flights map[string]*pathFlight
+	// generation changes on every invalidation, local or published by another replica,
+	// so in-flight searches that started before it do not store results from the old graph.
+	// Stores hold generationMutex for reading from the check to the write, and invalidations
+	// hold it for writing, so a stale result never lands after its tags are dropped.
+	generation      uint64
+	generationMutex sync.RWMutex
+	metrics         cache.MetricsRecorder
+	mutex           sync.Mutex // Guards flights
+}
+
+// pathFlight is a search in progress that other callers can wait on
+type pathFlight struct {
+	done  chan struct{}
+	paths []*models.ConnectionPath
+	err   error
+}
+
+// NewPathCache creates a path cache storing results in backend for ttl. metrics may be nil.
+func NewPathCache(backend cache.Backend, ttl time.Duration, metrics cache.MetricsRecorder) *PathCache {
+	return &PathCache{
+		backend: backend,
+		ttl:     ttl,
+		flights: make(map[string]*pathFlight),
+		metrics: metrics,
+	}
+}
+
+// GetOrLoad returns the cached result of a search, or runs load to produce it.
+// Concurrent calls for the same key share a single load. Backend failures are
+// counted and treated as misses so the cache never fails a search.
+func (pc *PathCache) GetOrLoad(ctx context.Context, key PathCacheKey, load func() ([]*models.ConnectionPath, error)) ([]*models.ConnectionPath, error) {
+	cacheKey := key.String()
+
+	if paths, found := pc.get(ctx, cacheKey); found {
+		pc.increment("path_cache_hits")
+		return paths, nil
+	}
+
+	pc.mutex.Lock()
+	if flight, inFlight := pc.flights[cacheKey]; inFlight {
+		pc.mutex.Unlock()
+		pc.increment("path_cache_coalesced")
+		select {
+		case <-flight.done:
+			return flight.paths, flight.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	flight := &pathFlight{done: make(chan struct{})}
+	pc.flights[cacheKey] = flight
+	pc.mutex.Unlock()
+
+	pc.generationMutex.RLock()
+	generation := pc.generation
+	pc.generationMutex.RUnlock()
+
+	pc.increment("path_cache_misses")
+	flight.paths, flight.err = load()
+
+	pc.mutex.Lock()
+	delete(pc.flights, cacheKey)
+	pc.mutex.Unlock()
+	close(flight.done)
+
+	if flight.err == nil {
+		pc.store(context.WithoutCancel(ctx), key, flight.paths, generation)
+	}
+
+	return flight.paths, flight.err
+}
+
+// InvalidateConnection drops the cached searches a change to the connection between
+// two families can affect: every search whose paths pass through either family, and
+// every search that found no path. The change is published to other replicas.
+func (pc *PathCache) InvalidateConnection(ctx context.Context, family1ID, family2ID string) error {
+	pc.bumpGeneration()
+
+	dropped, err := pc.backend.InvalidateTags(ctx, familyPathTag(family1ID), familyPathTag(family2ID), negativePathTag)
+	if err != nil {
+		pc.increment("path_cache_errors")
+		return fmt.Errorf("failed to invalidate cached paths: %w", err)
+	}
+	for i := 0; i < dropped; i++ {
+		pc.increment("path_cache_invalidations")
+	}
+
+	if err := pc.backend.Publish(ctx, PathInvalidationChannel, []byte(family1ID+","+family2ID)); err != nil {
+		pc.increment("path_cache_errors")
+		return fmt.Errorf("failed to publish path invalidation: %w", err)
+	}
+
+	return nil
+}
+
+// Listen applies connection changes published by other replicas until ctx is cancelled.
+// The cached entries themselves are shared, so only searches in flight here need stopping.
+func (pc *PathCache) Listen(ctx context.Context) error {
+	return pc.backend.Subscribe(ctx, PathInvalidationChannel, func(payload []byte) {
+		pc.bumpGeneration()
+		pc.increment("path_cache_remote_invalidations")
+	})
+}
+
+func (pc *PathCache) bumpGeneration() {
+	pc.generationMutex.Lock()
+	defer pc.generationMutex.Unlock()
+
+	pc.generation++
+}
+
+// store writes a search result unless the cache has been invalidated since the search began
+func (pc *PathCache) store(ctx context.Context, key PathCacheKey, paths []*models.ConnectionPath, generation uint64) {
+	pc.generationMutex.RLock()
+	defer pc.generationMutex.RUnlock()
+
+	if generation == pc.generation {
+		pc.set(ctx, key, paths)
+	}
+}
+
+// get reads and decodes a cached search result
+func (pc *PathCache) get(ctx context.Context, cacheKey string) ([]*models.ConnectionPath, bool) {
+	data, found, err := pc.backend.Get(ctx, cacheKey)
+	if err != nil {
+		pc.increment("path_cache_errors")
+		return nil, false
+	}
+	if !found {
+		return nil, false
+	}
+
+	paths, err := cache.DecodePaths(data)
+	if err != nil {
+		pc.increment("path_cache_errors")
+		return nil, false
+	}
+	return paths, true
+}
+
+// set encodes and stores a search result, tagged with the families it touches
+func (pc *PathCache) set(ctx context.Context, key PathCacheKey, paths []*models.ConnectionPath) {
+	data, err := cache.EncodePaths(paths)
+	if err != nil {
+		pc.increment("path_cache_errors")
+		return
+	}
+
+	var tags []string
+	for _, familyID := range pathFamilies(key, paths) {
+		tags = append(tags, familyPathTag(familyID))
+	}
+	if len(paths) == 0 {
+		tags = append(tags, negativePathTag)
+	}
+
+	if err := pc.backend.Set(ctx, key.String(), data, pc.ttl, tags...); err != nil {
+		pc.increment("path_cache_errors")
+	}
+}
+
+func (pc *PathCache) increment(name string) {
+	if pc.metrics != nil {
+		pc.metrics.IncrementCounter(name)
+	}
+}
+
+// familyPathTag tags the cached searches whose paths pass through a family
+func familyPathTag(familyID string) string {
+	return "path-family:" + familyID
+}
+
+// pathFamilies lists the distinct families touched by a search result, including its endpoints
+func pathFamilies(key PathCacheKey, paths []*models.ConnectionPath) []string {
+	seen := map[string]bool{key.FromID: true, key.ToID: true}
+	families := []string{key.FromID}
+	if key.ToID != key.FromID {
+		families = append(families, key.ToID)
+	}
+
+	for _, path := range paths {
+		for _, familyID := range path.Path {
+			if !seen[familyID] {
+				seen[familyID] = true
+				families = append(families, familyID)
+			}
+		}
+	}
+
+	return families
+}
+
+// CachedPathFinder wraps a PathFinder with caching capabilities. Finders for different
+// modes can share one PathCache since the mode is part of the key.
+type CachedPathFinder struct {
+	pathFinder PathFinder
+	cache      *PathCache
+	mode       string
+}
+
+// NewCachedPathFinder creates a new cached path finder
+func NewCachedPathFinder(pathFinder PathFinder, cache *PathCache, mode string) *CachedPathFinder {
+	return &CachedPathFinder{
+		pathFinder: pathFinder,
+		cache:      cache,
+		mode:       mode,
+	}
+}
+
+// FindPath finds a path with caching. Constrained searches bypass the cache.
+func (cpf *CachedPathFinder) FindPath(ctx context.Context, fromID, toID string, maxDepth int, constraints *PathConstraints) (*models.ConnectionPath, error) {
+	if !constraints.IsEmpty() {
+		return cpf.pathFinder.FindPath(ctx, fromID, toID, maxDepth, constraints)
+	}
+
+	key := PathCacheKey{FromID: fromID, ToID: toID, MaxDepth: maxDepth, Mode: cpf.mode}
+
+	// A coalesced search is shared by several callers, so one caller going away must not cancel it
+	searchCtx := context.WithoutCancel(ctx)
+	return firstPath(cpf.cache.GetOrLoad(ctx, key, func() ([]*models.ConnectionPath, error) {
+		return singlePath(cpf.pathFinder.FindPath(searchCtx, fromID, toID, maxDepth, nil))
+	}))
+}
+
+// FindMultiplePaths finds multiple paths with caching. Constrained searches bypass the cache.
+func (cpf *CachedPathFinder) FindMultiplePaths(ctx context.Context, fromID, toID string, maxDepth, maxPaths int, constraints *PathConstraints) ([]*models.ConnectionPath, error) {
+	if !constraints.IsEmpty() {
+		return cpf.pathFinder.FindMultiplePaths(ctx, fromID, toID, maxDepth, maxPaths, constraints)
+	}
+
+	key := PathCacheKey{FromID: fromID, ToID: toID, MaxDepth: maxDepth, MaxPaths: maxPaths, Mode: cpf.mode}
+
+	searchCtx := context.WithoutCancel(ctx)
+	return cpf.cache.GetOrLoad(ctx, key, func() ([]*models.ConnectionPath, error) {
+		return cpf.pathFinder.FindMultiplePaths(searchCtx, fromID, toID, maxDepth, maxPaths, nil)
+	})
+}
@@ -0,0 +1,124 @@
+package algorithms_test
+
+import (
+	"context"
+	"errors"
+	"families-linkedin/internal/algorithms"
+	"families-linkedin/internal/cache"
+	"families-linkedin/internal/models"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestPathCache() *algorithms.PathCache {
+	return algorithms.NewPathCache(cache.NewMemoryBackend(100, nil), time.Minute, nil)
+}
+
+func testPath(families ...string) []*models.ConnectionPath {
+	return []*models.ConnectionPath{{SourceFamilyID: families[0], TargetFamilyID: families[len(families)-1], Path: families, Degree: len(families) - 1}}
+}
+
+func TestPathCacheHitsAndCoalesces(t *testing.T) {
+	ctx := context.Background()
+	pc := newTestPathCache()
+	key := algorithms.PathCacheKey{FromID: "A", ToID: "C", MaxDepth: 4, Mode: algorithms.PathModeShortest}
+
+	var loads int32
+	release := make(chan struct{})
+	load := func() ([]*models.ConnectionPath, error) {
+		atomic.AddInt32(&loads, 1)
+		<-release
+		return testPath("A", "B", "C"), nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if paths, err := pc.GetOrLoad(ctx, key, load); err != nil || len(paths) != 1 {
+				t.Errorf("GetOrLoad = %v, %v", paths, err)
+			}
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if _, err := pc.GetOrLoad(ctx, key, load); err != nil {
+		t.Fatalf("GetOrLoad: %v", err)
+	}
+	if got := atomic.LoadInt32(&loads); got != 1 {
+		t.Errorf("loads = %d, want 1 shared by concurrent callers and then cached", got)
+	}
+}
+
+func TestPathCacheWaiterHonoursContext(t *testing.T) {
+	pc := newTestPathCache()
+	key := algorithms.PathCacheKey{FromID: "A", ToID: "C", MaxDepth: 4, Mode: algorithms.PathModeShortest}
+
+	release := make(chan struct{})
+	defer close(release)
+	started := make(chan struct{})
+	go pc.GetOrLoad(context.Background(), key, func() ([]*models.ConnectionPath, error) {
+		close(started)
+		<-release
+		return nil, nil
+	})
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		_, err := pc.GetOrLoad(ctx, key, func() ([]*models.ConnectionPath, error) { return nil, nil })
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("waiter error = %v, want %v", err, context.DeadlineExceeded)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("waiter still blocked on the shared search after its context expired")
+	}
+}
+
+func TestPathCacheDropsResultsFromBeforeInvalidation(t *testing.T) {
+	ctx := context.Background()
+	pc := newTestPathCache()
+	key := algorithms.PathCacheKey{FromID: "A", ToID: "C", MaxDepth: 4, Mode: algorithms.PathModeShortest}
+
+	loads := 0
+	stale := func() ([]*models.ConnectionPath, error) {
+		loads++
+		// The graph changes while the search runs
+		if err := pc.InvalidateConnection(ctx, "X", "Y"); err != nil {
+			t.Fatalf("InvalidateConnection: %v", err)
+		}
+		return nil, nil
+	}
+	fresh := func() ([]*models.ConnectionPath, error) {
+		loads++
+		return testPath("A", "X", "Y", "C"), nil
+	}
+
+	pc.GetOrLoad(ctx, key, stale)
+	paths, err := pc.GetOrLoad(ctx, key, fresh)
+	if err != nil {
+		t.Fatalf("GetOrLoad: %v", err)
+	}
+	if loads != 2 || len(paths) != 1 {
+		t.Errorf("loads = %d, paths = %d; want the stale empty result discarded and searched again", loads, len(paths))
+	}
+
+	// A connection change on the cached path drops it
+	pc.InvalidateConnection(ctx, "X", "Z")
+	pc.GetOrLoad(ctx, key, fresh)
+	if loads != 3 {
+		t.Errorf("loads = %d after invalidating a family on the path, want 3", loads)
+	}
+}
@@ -2,6 +2,7 @@ package algorithms
 
 import (
 	"context"
+	"families-linkedin/internal/kinship"
 	"families-linkedin/internal/models"
 	"fmt"
 	"sync"
@@ -10,29 +11,53 @@ import (
 
 // PathFinder interface defines methods for finding paths between families
 type PathFinder interface {
-	FindPath(ctx context.Context, fromID, toID string, maxDepth int) (*models.ConnectionPath, error)
-	FindMultiplePaths(ctx context.Context, fromID, toID string, maxDepth, maxPaths int) ([]*models.ConnectionPath, error)
+	FindPath(ctx context.Context, fromID, toID string, maxDepth int, constraints *PathConstraints) (*models.ConnectionPath, error)
+	FindMultiplePaths(ctx context.Context, fromID, toID string, maxDepth, maxPaths int, constraints *PathConstraints) ([]*models.ConnectionPath, error)
 }
 
-// GraphRepository interface for graph operations
+// Path search modes accepted by the connection service
+const (
+	PathModeShortest  = "shortest"  // Fewest hops
+	PathModeStrongest = "strongest" // Highest product of connection strengths
+)
+
+// IsValidPathMode reports whether mode names a supported path search mode
+func IsValidPathMode(mode string) bool {
+	return mode == PathModeShortest || mode == PathModeStrongest
+}
+
+// GraphRepository interface for graph operations. Connections are returned as full
+// edge records oriented away from the queried family, so ToFamilyID is the neighbor.
 type GraphRepository interface {
-	GetNeighbors(ctx context.Context, familyID string) ([]string, error)
-	GetConnectionStrength(ctx context.Context, from, to string) (float64, error)
+	GetNeighbors(ctx context.Context, familyID string) ([]*models.FamilyConnection, error)
+	GetNeighborsBulk(ctx context.Context, familyIDs []string) (map[string][]*models.FamilyConnection, error)
+	GetConnection(ctx context.Context, from, to string) (*models.FamilyConnection, error)
 	GetFamilyTrustScore(ctx context.Context, familyID string) (float64, error)
 }
 
 // BidirectionalBFS implements bidirectional breadth-first search with cycle detection
 type BidirectionalBFS struct {
-	repo GraphRepository
+	repo  GraphRepository
+	decay *models.StrengthDecay // Applied to the strength of found paths
 }
 
 // NewBidirectionalBFS creates a new bidirectional BFS path finder
-func NewBidirectionalBFS(repo GraphRepository) *BidirectionalBFS {
-	return &BidirectionalBFS{repo: repo}
+func NewBidirectionalBFS(repo GraphRepository, decay *models.StrengthDecay) *BidirectionalBFS {
+	return &BidirectionalBFS{repo: repo, decay: decay}
 }
 
 // FindPath finds the shortest path between two families using bidirectional BFS
-func (bfs *BidirectionalBFS) FindPath(ctx context.Context, fromID, toID string, maxDepth int) (*models.ConnectionPath, error) {
+func (bfs *BidirectionalBFS) FindPath(ctx context.Context, fromID, toID string, maxDepth int, constraints *PathConstraints) (*models.ConnectionPath, error) {
+	return firstPath(findConstrainedPaths(ctx, bfs.repo, fromID, toID, maxDepth, 1, constraints, RankByHops, bfs.decay,
+		func(ctx context.Context, repo GraphRepository, fromID, toID string, maxDepth, _ int) ([]*models.ConnectionPath, error) {
+			return singlePath(NewBidirectionalBFS(repo, bfs.decay).findPath(ctx, fromID, toID, maxDepth))
+		}))
+}
+
+// findPath runs the unconstrained bidirectional BFS. Each step expands one whole level of
+// the smaller frontier with a single bulk neighbor query, so a search costs one round trip
+// per level instead of one per visited family.
+func (bfs *BidirectionalBFS) findPath(ctx context.Context, fromID, toID string, maxDepth int) (*models.ConnectionPath, error) {
 	if fromID == toID {
 		return &models.ConnectionPath{
 			SourceFamilyID: fromID,
@@ -45,299 +70,149 @@ func (bfs *BidirectionalBFS) FindPath(ctx context.Context, fromID, toID string,
 		}, nil
 	}
 
-	// Forward and backward queues
-	forwardQueue := []PathState{{FamilyID: fromID, Path: []string{fromID}, Visited: map[string]bool{fromID: true}}}
-	backwardQueue := []PathState{{FamilyID: toID, Path: []string{toID}, Visited: map[string]bool{toID: true}}}
-	
-	forwardVisited := map[string][]string{fromID: {fromID}}
-	backwardVisited := map[string][]string{toID: {toID}}
-	
-	depth := 0
-	
-	for len(forwardQueue) > 0 || len(backwardQueue) > 0 {
-		if depth > maxDepth {
+	// Parent pointers double as the visited sets of each direction
+	forwardParents := map[string]string{fromID: ""}
+	backwardParents := map[string]string{toID: ""}
+
+	forwardFrontier := []string{fromID}
+	backwardFrontier := []string{toID}
+
+	for hops := 1; hops <= maxDepth; hops++ {
+		if len(forwardFrontier) == 0 || len(backwardFrontier) == 0 {
 			break
 		}
-		
-		// Expand forward frontier
-		if len(forwardQueue) > 0 {
-			newForwardQueue := []PathState{}
-			
-			for _, state := range forwardQueue {
-				neighbors, err := bfs.repo.GetNeighbors(ctx, state.FamilyID)
-				if err != nil {
-					return nil, fmt.Errorf("failed to get neighbors for %s: %w", state.FamilyID, err)
-				}
-				
-				for _, neighbor := range neighbors {
-					// Check if we found connection to backward search
-					if backwardPath, exists := backwardVisited[neighbor]; exists {
-						// Connection found! Reconstruct path
-						fullPath := append(state.Path, reverse(backwardPath[1:])...)
-						
-						// Validate no cycles
-						if hasCycle(fullPath) {
-							continue // Skip this path if it has cycles
-						}
-						
-						connectionPath := &models.ConnectionPath{
-							SourceFamilyID: fromID,
-							TargetFamilyID: toID,
-							Path:           fullPath,
-							Degree:         len(fullPath) - 1,
-							CalculatedAt:   time.Now(),
-						}
-						
-						// Calculate path strength
-						if err := bfs.calculatePathStrength(ctx, connectionPath); err != nil {
-							return nil, err
-						}
-						
-						return connectionPath, nil
-					}
-					
-					// Check for cycles in current path
-					if state.Visited[neighbor] {
-						continue // Skip to avoid cycles
-					}
-					
-					// Add to forward visited if not already explored
-					if _, visited := forwardVisited[neighbor]; !visited {
-						newPath := append(state.Path, neighbor)
-						newVisited := make(map[string]bool)
-						for k, v := range state.Visited {
-							newVisited[k] = v
-						}
-						newVisited[neighbor] = true
-						
-						forwardVisited[neighbor] = newPath
-						newForwardQueue = append(newForwardQueue, PathState{
-							FamilyID: neighbor,
-							Path:     newPath,
-							Visited:  newVisited,
-						})
-					}
-				}
-			}
-			
-			forwardQueue = newForwardQueue
-		}
-		
-		// Expand backward frontier
-		if len(backwardQueue) > 0 {
-			newBackwardQueue := []PathState{}
-			
-			for _, state := range backwardQueue {
-				neighbors, err := bfs.repo.GetNeighbors(ctx, state.FamilyID)
-				if err != nil {
-					return nil, fmt.Errorf("failed to get neighbors for %s: %w", state.FamilyID, err)
-				}
-				
-				for _, neighbor := range neighbors {
-					// Check if we found connection to forward search
-					if forwardPath, exists := forwardVisited[neighbor]; exists {
-						// Connection found! Reconstruct path
-						fullPath := append(forwardPath, reverse(state.Path[1:])...)
-						
-						// Validate no cycles
-						if hasCycle(fullPath) {
-							continue // Skip this path if it has cycles
-						}
-						
-						connectionPath := &models.ConnectionPath{
-							SourceFamilyID: fromID,
-							TargetFamilyID: toID,
-							Path:           fullPath,
-							Degree:         len(fullPath) - 1,
-							CalculatedAt:   time.Now(),
-						}
-						
-						// Calculate path strength
-						if err := bfs.calculatePathStrength(ctx, connectionPath); err != nil {
-							return nil, err
-						}
-						
-						return connectionPath, nil
-					}
-					
-					// Check for cycles in current path
-					if state.Visited[neighbor] {
-						continue // Skip to avoid cycles
-					}
-					
-					// Add to backward visited if not already explored
-					if _, visited := backwardVisited[neighbor]; !visited {
-						newPath := append(state.Path, neighbor)
-						newVisited := make(map[string]bool)
-						for k, v := range state.Visited {
-							newVisited[k] = v
-						}
-						newVisited[neighbor] = true
-						
-						backwardVisited[neighbor] = newPath
-						newBackwardQueue = append(newBackwardQueue, PathState{
-							FamilyID: neighbor,
-							Path:     newPath,
-							Visited:  newVisited,
-						})
-					}
-				}
-			}
-			
-			backwardQueue = newBackwardQueue
-		}
-		
-		depth++
-	}
-	
-	return nil, nil // No path found
-}
 
-// FindMultiplePaths finds multiple paths using iterative BFS with cycle detection
-func (bfs *BidirectionalBFS) FindMultiplePaths(ctx context.Context, fromID, toID string, maxDepth, maxPaths int) ([]*models.ConnectionPath, error) {
-	var paths []*models.ConnectionPath
-	pathsFound := make(map[string]bool) // Track found paths to avoid duplicates
-	
-	// Use multiple starting strategies to find diverse paths
-	for attempt := 0; attempt < maxPaths && len(paths) < maxPaths; attempt++ {
-		path, err := bfs.findPathWithExclusions(ctx, fromID, toID, maxDepth, pathsFound)
+		var meeting string
+		var err error
+
+		// Expand the smaller frontier to keep both searches balanced
+		if len(forwardFrontier) <= len(backwardFrontier) {
+			forwardFrontier, meeting, err = bfs.expandLevel(ctx, forwardFrontier, forwardParents, backwardParents)
+		} else {
+			backwardFrontier, meeting, err = bfs.expandLevel(ctx, backwardFrontier, backwardParents, forwardParents)
+		}
 		if err != nil {
 			return nil, err
 		}
-		
-		if path != nil && !pathsFound[pathKey(path.Path)] && !hasCycle(path.Path) {
-			paths = append(paths, path)
-			pathsFound[pathKey(path.Path)] = true
+
+		if meeting == "" {
+			continue
 		}
-	}
-	
-	return paths, nil
-}
 
-// PathState represents the state during path finding
-type PathState struct {
-	FamilyID string
-	Path     []string
-	Visited  map[string]bool
-}
+		// Searches met! Reconstruct path through the meeting family
+		fullPath := reverse(parentChain(meeting, forwardParents))
+		fullPath = append(fullPath, parentChain(meeting, backwardParents)[1:]...)
 
-// findPathWithExclusions finds a path while excluding previously found paths
-func (bfs *BidirectionalBFS) findPathWithExclusions(ctx context.Context, fromID, toID string, maxDepth int, excludedPaths map[string]bool) (*models.ConnectionPath, error) {
-	if fromID == toID {
-		return &models.ConnectionPath{
+		connectionPath := &models.ConnectionPath{
 			SourceFamilyID: fromID,
 			TargetFamilyID: toID,
-			Path:           []string{fromID},
-			Degree:         0,
-			PathStrength:   1.0,
-			Verified:       true,
+			Path:           fullPath,
+			Degree:         len(fullPath) - 1,
 			CalculatedAt:   time.Now(),
-		}, nil
-	}
+		}
 
-	queue := []PathState{{FamilyID: fromID, Path: []string{fromID}, Visited: map[string]bool{fromID: true}}}
-	visited := map[string]bool{fromID: true}
-	
-	for len(queue) > 0 && len(queue[0].Path) <= maxDepth {
-		state := queue[0]
-		queue = queue[1:]
-		
-		neighbors, err := bfs.repo.GetNeighbors(ctx, state.FamilyID)
-		if err != nil {
+		// Calculate path strength
+		if err := calculatePathStrength(ctx, bfs.repo, connectionPath, bfs.decay); err != nil {
 			return nil, err
 		}
-		
-		for _, neighbor := range neighbors {
-			// Found target
-			if neighbor == toID {
-				fullPath := append(state.Path, neighbor)
-				
-				// Check for cycles
-				if hasCycle(fullPath) {
-					continue
-				}
-				
-				// Check if this path was already found
-				if excludedPaths[pathKey(fullPath)] {
-					continue
-				}
-				
-				connectionPath := &models.ConnectionPath{
-					SourceFamilyID: fromID,
-					TargetFamilyID: toID,
-					Path:           fullPath,
-					Degree:         len(fullPath) - 1,
-					CalculatedAt:   time.Now(),
-				}
-				
-				// Calculate path strength
-				if err := bfs.calculatePathStrength(ctx, connectionPath); err != nil {
-					return nil, err
-				}
-				
-				return connectionPath, nil
+
+		return connectionPath, nil
+	}
+
+	return nil, nil // No path found
+}
+
+// expandLevel expands a whole frontier with one bulk neighbor query. It returns the next
+// frontier and, if the level reached a family already visited by the opposite search, that family.
+func (bfs *BidirectionalBFS) expandLevel(ctx context.Context, frontier []string, parents, opposite map[string]string) ([]string, string, error) {
+	neighbors, err := bfs.repo.GetNeighborsBulk(ctx, frontier)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get neighbors for %d families: %w", len(frontier), err)
+	}
+
+	var next []string
+	for _, familyID := range frontier {
+		for _, edge := range neighbors[familyID] {
+			neighbor := edge.ToFamilyID
+			if _, visited := parents[neighbor]; visited {
+				continue // Already reached at this depth or earlier
 			}
-			
-			// Continue search if not visited and no cycle would be created
-			if !state.Visited[neighbor] && !visited[neighbor] {
-				newPath := append(state.Path, neighbor)
-				
-				// Check for cycles in the new path
-				if hasCycle(newPath) {
-					continue
-				}
-				
-				newVisited := make(map[string]bool)
-				for k, v := range state.Visited {
-					newVisited[k] = v
-				}
-				newVisited[neighbor] = true
-				visited[neighbor] = true
-				
-				queue = append(queue, PathState{
-					FamilyID: neighbor,
-					Path:     newPath,
-					Visited:  newVisited,
-				})
+
+			parents[neighbor] = familyID
+			if _, met := opposite[neighbor]; met {
+				return next, neighbor, nil
 			}
+			next = append(next, neighbor)
 		}
 	}
-	
-	return nil, nil // No path found
+
+	return next, "", nil
+}
+
+// parentChain follows parent pointers from a family back to the search origin
+func parentChain(familyID string, parents map[string]string) []string {
+	chain := []string{familyID}
+	for parent := parents[familyID]; parent != ""; parent = parents[parent] {
+		chain = append(chain, parent)
+	}
+	return chain
+}
+
+// FindMultiplePaths finds multiple distinct paths, fewest hops first, using Yen's K-shortest loopless paths
+func (bfs *BidirectionalBFS) FindMultiplePaths(ctx context.Context, fromID, toID string, maxDepth, maxPaths int, constraints *PathConstraints) ([]*models.ConnectionPath, error) {
+	return NewKShortestPaths(bfs.repo, RankByHops, bfs.decay).FindMultiplePaths(ctx, fromID, toID, maxDepth, maxPaths, constraints)
 }
 
-// calculatePathStrength calculates the strength of a path based on individual connection strengths
-func (bfs *BidirectionalBFS) calculatePathStrength(ctx context.Context, path *models.ConnectionPath) error {
+// calculatePathStrength fills in the strength, verification, per-hop edges and kinship of a
+// path from the connection records along it, fetched in one bulk query. Connection strengths
+// are discounted by decay.
+func calculatePathStrength(ctx context.Context, repo GraphRepository, path *models.ConnectionPath, decay *models.StrengthDecay) error {
 	if len(path.Path) < 2 {
 		path.PathStrength = 1.0
 		path.Verified = true
 		return nil
 	}
 	
-	strength := 1.0
-	allVerified := true
+	neighbors, err := repo.GetNeighborsBulk(ctx, path.Path[:len(path.Path)-1])
+	if err != nil {
+		return fmt.Errorf("failed to get connections along path: %w", err)
+	}
+	
+	connections := make([]models.FamilyConnection, 0, len(path.Path)-1)
 	
 	for i := 0; i < len(path.Path)-1; i++ {
-		connStrength, err := bfs.repo.GetConnectionStrength(ctx, path.Path[i], path.Path[i+1])
-		if err != nil {
-			return fmt.Errorf("failed to get connection strength between %s and %s: %w", 
-				path.Path[i], path.Path[i+1], err)
+		connection := findEdge(neighbors[path.Path[i]], path.Path[i+1])
+		if connection == nil {
+			return fmt.Errorf("no connection between %s and %s", path.Path[i], path.Path[i+1])
 		}
 		
-		strength *= connStrength
-		
-		// For simplicity, assume connection is verified if strength > 0.5
-		if connStrength <= 0.5 {
-			allVerified = false
-		}
+		connections = append(connections, *connection)
 	}
 	
-	path.PathStrength = strength
-	path.Verified = allVerified
+	path.CalculatePathStrength(connections, decay)
+	path.SpecificRelation, _ = kinship.PathRelation(path.Edges)
 	
 	return nil
 }
 
+// findEdge returns the edge leading to a family, or nil
+func findEdge(edges []*models.FamilyConnection, toID string) *models.FamilyConnection {
+	if i := edgeIndex(edges, toID); i >= 0 {
+		return edges[i]
+	}
+	return nil
+}
+
+// edgeIndex returns the position of the edge leading to a family, or -1
+func edgeIndex(edges []*models.FamilyConnection, toID string) int {
+	for i, edge := range edges {
+		if edge.ToFamilyID == toID {
+			return i
+		}
+	}
+	return -1
+}
+
 // ParallelPathFinder implements parallel path finding for multiple queries
 type ParallelPathFinder struct {
 	pathFinder PathFinder
@@ -354,9 +229,10 @@ func NewParallelPathFinder(pathFinder PathFinder, maxWorkers int) *ParallelPathF
 
 // PathQuery represents a path finding query
 type PathQuery struct {
-	FromID   string
-	ToID     string
-	MaxDepth int
+	FromID      string
+	ToID        string
+	MaxDepth    int
+	Constraints *PathConstraints
 }
 
 // PathResult represents the result of a path finding query
@@ -385,7 +261,7 @@ func (ppf *ParallelPathFinder) FindMultiplePathsParallel(ctx context.Context, qu
 			semaphore <- struct{}{}
 			defer func() { <-semaphore }()
 			
-			path, err := ppf.pathFinder.FindPath(ctx, q.FromID, q.ToID, q.MaxDepth)
+			path, err := ppf.pathFinder.FindPath(ctx, q.FromID, q.ToID, q.MaxDepth, q.Constraints)
 			results[index] = &PathResult{
 				Query: q,
 				Path:  path,
@@ -409,18 +285,6 @@ func reverse(slice []string) []string {
 	return reversed
 }
 
-// hasCycle checks if a path has any cycles (duplicate family IDs)
-func hasCycle(path []string) bool {
-	seen := make(map[string]bool)
-	for _, familyID := range path {
-		if seen[familyID] {
-			return true
-		}
-		seen[familyID] = true
-	}
-	return false
-}
-
 // pathKey creates a unique key for a path
 func pathKey(path []string) string {
 	key := ""
@@ -432,94 +296,3 @@ func pathKey(path []string) string {
 	}
 	return key
 }
-
-// PathCache provides caching for frequently accessed paths
-type PathCache struct {
-	cache map[string]*CachedPath
-	mutex sync.RWMutex
-	ttl   time.Duration
-}
-
-type CachedPath struct {
-	Path      *models.ConnectionPath
-	CachedAt  time.Time
-	ExpiresAt time.Time
-}
-
-// NewPathCache creates a new path cache
-func NewPathCache(ttl time.Duration) *PathCache {
-	return &PathCache{
-		cache: make(map[string]*CachedPath),
-		ttl:   ttl,
-	}
-}
-
-// Get retrieves a path from cache if it exists and is not expired
-func (pc *PathCache) Get(fromID, toID string) (*models.ConnectionPath, bool) {
-	pc.mutex.RLock()
-	defer pc.mutex.RUnlock()
-	
-	key := fmt.Sprintf("%s->%s", fromID, toID)
-	cached, exists := pc.cache[key]
-	
-	if !exists || time.Now().After(cached.ExpiresAt) {
-		return nil, false
-	}
-	
-	return cached.Path, true
-}
-
-// Set stores a path in the cache
-func (pc *PathCache) Set(fromID, toID string, path *models.ConnectionPath) {
-	pc.mutex.Lock()
-	defer pc.mutex.Unlock()
-	
-	key := fmt.Sprintf("%s->%s", fromID, toID)
-	now := time.Now()
-	
-	pc.cache[key] = &CachedPath{
-		Path:      path,
-		CachedAt:  now,
-		ExpiresAt: now.Add(pc.ttl),
-	}
-}
-
-// CachedPathFinder wraps a PathFinder with caching capabilities
-type CachedPathFinder struct {
-	pathFinder PathFinder
-	cache      *PathCache
-}
-
-// NewCachedPathFinder creates a new cached path finder
-func NewCachedPathFinder(pathFinder PathFinder, cacheTTL time.Duration) *CachedPathFinder {
-	return &CachedPathFinder{
-		pathFinder: pathFinder,
-		cache:      NewPathCache(cacheTTL),
-	}
-}
-
-// FindPath finds a path with caching
-func (cpf *CachedPathFinder) FindPath(ctx context.Context, fromID, toID string, maxDepth int) (*models.ConnectionPath, error) {
-	// Try to get from cache first
-	if cached, found := cpf.cache.Get(fromID, toID); found {
-		return cached, nil
-	}
-	
-	// Not in cache, find path
-	path, err := cpf.pathFinder.FindPath(ctx, fromID, toID, maxDepth)
-	if err != nil {
-		return nil, err
-	}
-	
-	// Cache the result if path was found
-	if path != nil {
-		cpf.cache.Set(fromID, toID, path)
-	}
-	
-	return path, nil
-}
-
-// FindMultiplePaths finds multiple paths (not cached for complexity)
-func (cpf *CachedPathFinder) FindMultiplePaths(ctx context.Context, fromID, toID string, maxDepth, maxPaths int) ([]*models.ConnectionPath, error) {
-	return cpf.pathFinder.FindMultiplePaths(ctx, fromID, toID, maxDepth, maxPaths)
-}
\ No newline at end of file
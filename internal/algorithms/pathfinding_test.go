@@ -0,0 +1,195 @@
+package algorithms_test
+
+import (
+	"context"
+	"families-linkedin/internal/algorithms"
+	"families-linkedin/internal/models"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestStrongestPathFinder(t *testing.T) {
+	tests := []struct {
+		name         string
+		edges        []edge
+		from, to     string
+		maxDepth     int
+		want         string
+		wantStrength float64
+	}{
+		{"longer stronger path beats shorter weaker one", []edge{{"A", "B", 0.3}, {"A", "C", 0.9}, {"C", "B", 0.9}}, "A", "B", 4, "A-C-B", 0.81},
+		{"hop bound keeps the shorter path", []edge{{"A", "B", 0.3}, {"A", "C", 0.9}, {"C", "B", 0.9}}, "A", "B", 1, "A-B", 0.3},
+		{"no path within the hop bound", []edge{{"A", "B", 0.9}, {"B", "C", 0.9}, {"C", "D", 0.9}}, "A", "D", 2, "", 0},
+		// The strong route reaches X in three hops with no hop left to go on to T, so the
+		// weaker one-hop label at X must survive beside it
+		{"shorter costlier label is not pruned", []edge{{"A", "X", 0.2}, {"X", "T", 0.9}, {"A", "P", 0.9}, {"P", "Q", 0.9}, {"Q", "X", 0.9}}, "A", "T", 3, "A-X-T", 0.18},
+		{"same family", []edge{{"A", "B", 0.9}}, "A", "A", 4, "A", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			finder := algorithms.NewStrongestPathFinder(newMemoryGraph(t, tt.edges...), nil)
+			path, err := finder.FindPath(context.Background(), tt.from, tt.to, tt.maxDepth, nil)
+			if err != nil {
+				t.Fatalf("FindPath: %v", err)
+			}
+			if got := route(path); got != tt.want {
+				t.Fatalf("FindPath = %q, want %q", got, tt.want)
+			}
+			if path != nil && math.Abs(path.PathStrength-tt.wantStrength) > 1e-9 {
+				t.Errorf("strength = %.4f, want %.4f", path.PathStrength, tt.wantStrength)
+			}
+		})
+	}
+}
+
+// countingGraph counts the neighbor queries made for each family, and the bulk queries
+type countingGraph struct {
+	*memoryGraph
+	queries     map[string]int
+	bulkQueries int
+}
+
+func (g *countingGraph) GetNeighbors(ctx context.Context, familyID string) ([]*models.FamilyConnection, error) {
+	g.queries[familyID]++
+	return g.memoryGraph.GetNeighbors(ctx, familyID)
+}
+
+func (g *countingGraph) GetNeighborsBulk(ctx context.Context, familyIDs []string) (map[string][]*models.FamilyConnection, error) {
+	g.bulkQueries++
+	return g.memoryGraph.GetNeighborsBulk(ctx, familyIDs)
+}
+
+func TestStrongestPathFinderPrunesDominatedLabels(t *testing.T) {
+	// B is reached directly and, later, through C in more hops at a greater cost. That
+	// second label is dominated and must not be expanded again.
+	graph := &countingGraph{
+		memoryGraph: newMemoryGraph(t,
+			edge{"A", "B", 0.5}, edge{"A", "C", 0.4}, edge{"C", "B", 0.4},
+			edge{"B", "T", 0.1},
+		),
+		queries: make(map[string]int),
+	}
+
+	path, err := algorithms.NewStrongestPathFinder(graph, nil).FindPath(context.Background(), "A", "T", 4, nil)
+	if err != nil {
+		t.Fatalf("FindPath: %v", err)
+	}
+	if got := route(path); got != "A-B-T" {
+		t.Errorf("FindPath = %q, want %q", got, "A-B-T")
+	}
+	if graph.queries["B"] != 1 {
+		t.Errorf("neighbors of B queried %d times, want once", graph.queries["B"])
+	}
+}
+
+func TestBidirectionalBFSFindPath(t *testing.T) {
+	memoryGraph := newMemoryGraph(t,
+		edge{"A", "B", 0.9}, edge{"B", "C", 0.9}, edge{"C", "D", 0.9},
+		edge{"A", "E", 0.5}, edge{"E", "D", 0.5},
+		edge{"X", "Y", 0.9},
+	)
+
+	tests := []struct {
+		name         string
+		from, to     string
+		maxDepth     int
+		want         string
+		wantStrength float64
+	}{
+		{"direct connection", "A", "B", 4, "A-B", 0.9},
+		{"fewest hops over strongest", "A", "D", 4, "A-E-D", 0.25},
+		{"reversed", "D", "A", 4, "D-E-A", 0.25},
+		{"same family", "A", "A", 4, "A", 1},
+		{"exactly max depth", "B", "E", 3, "B-A-E", 0.45},
+		{"beyond max depth", "B", "E", 1, "", 0},
+		{"separate component", "A", "X", 6, "", 0},
+		{"unknown family", "A", "Q", 6, "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			graph := &countingGraph{memoryGraph: memoryGraph, queries: make(map[string]int)}
+			finder := algorithms.NewBidirectionalBFS(graph, nil)
+
+			path, err := finder.FindPath(context.Background(), tt.from, tt.to, tt.maxDepth, nil)
+			if err != nil {
+				t.Fatalf("FindPath: %v", err)
+			}
+			if got := route(path); got != tt.want {
+				t.Fatalf("FindPath = %q, want %q", got, tt.want)
+			}
+			if len(graph.queries) != 0 {
+				t.Errorf("made single-family neighbor queries %v, want only bulk queries", graph.queries)
+			}
+			if path == nil {
+				if graph.bulkQueries > tt.maxDepth {
+					t.Errorf("made %d bulk queries, want at most one per level", graph.bulkQueries)
+				}
+				return
+			}
+			// One bulk query per level expanded, and one for the edges along the path
+			want := 0
+			if path.Degree > 0 {
+				want = path.Degree + 1
+			}
+			if graph.bulkQueries != want {
+				t.Errorf("made %d bulk queries, want %d", graph.bulkQueries, want)
+			}
+			if path.Degree != len(path.Path)-1 {
+				t.Errorf("degree = %d, want %d", path.Degree, len(path.Path)-1)
+			}
+			if math.Abs(path.PathStrength-tt.wantStrength) > 1e-9 {
+				t.Errorf("strength = %.4f, want %.4f", path.PathStrength, tt.wantStrength)
+			}
+		})
+	}
+}
+
+func TestKShortestPathsRanking(t *testing.T) {
+	// Three routes from A to D: two of two hops, and a longer one that is the strongest
+	graph := newMemoryGraph(t,
+		edge{"A", "B", 0.9}, edge{"B", "D", 0.9},
+		edge{"A", "C", 0.4}, edge{"C", "D", 0.4},
+		edge{"A", "E", 0.95}, edge{"E", "F", 0.95}, edge{"F", "D", 0.95},
+	)
+
+	tests := []struct {
+		name     string
+		rankBy   string
+		maxDepth int
+		maxPaths int
+		want     []string
+	}{
+		{"fewest hops, stronger first among equals", algorithms.RankByHops, 4, 5, []string{"A-B-D", "A-C-D", "A-E-F-D"}},
+		{"strongest first", algorithms.RankByStrength, 4, 5, []string{"A-E-F-D", "A-B-D", "A-C-D"}},
+		{"limited to max paths", algorithms.RankByStrength, 4, 2, []string{"A-E-F-D", "A-B-D"}},
+		{"limited to max depth", algorithms.RankByStrength, 2, 5, []string{"A-B-D", "A-C-D"}},
+		{"unknown ranking falls back to hops", "weight", 4, 1, []string{"A-B-D"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			finder := algorithms.NewKShortestPaths(graph, tt.rankBy, nil)
+			paths, err := finder.FindMultiplePaths(context.Background(), "A", "D", tt.maxDepth, tt.maxPaths, nil)
+			if err != nil {
+				t.Fatalf("FindMultiplePaths: %v", err)
+			}
+			if got := routes(paths); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FindMultiplePaths = %v, want %v", got, tt.want)
+			}
+
+			seen := make(map[string]bool)
+			for _, path := range paths {
+				if seen[route(path)] {
+					t.Errorf("path %s returned twice", route(path))
+				}
+				seen[route(path)] = true
+				if len(path.Edges) != path.Degree {
+					t.Errorf("path %s has %d edges, want one per hop", route(path), len(path.Edges))
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,350 @@
+package algorithms
+
+import (
+	"context"
+	"families-linkedin/internal/models"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+	"unsafe"
+)
+
+// SnapshotSource provides the full edge list a GraphSnapshot is built from
+type SnapshotSource interface {
+	GetAllConnections(ctx context.Context) ([]*models.FamilyConnection, error)
+}
+
+// GraphSnapshot is an in-process, compressed-sparse-row copy of the family graph.
+// It implements GraphRepository so the path finders can run entirely against RAM.
+// Connections created after the last full load are kept in a small overlay until
+// the next Refresh folds them into the CSR arrays.
+type GraphSnapshot struct {
+	source   SnapshotSource
+	fallback GraphRepository // Serves trust scores, which the snapshot does not hold
+	graph    *csrGraph
+	overlay  []overlayEdge
+	mutex    sync.RWMutex
+}
+
+// overlayEdge is a connection applied to the snapshot since its last full load
+type overlayEdge struct {
+	connection *models.FamilyConnection
+	appliedAt  time.Time
+}
+
+// NewGraphSnapshot creates an empty snapshot. Call Refresh to load it.
+func NewGraphSnapshot(source SnapshotSource, fallback GraphRepository) *GraphSnapshot {
+	return &GraphSnapshot{
+		source:   source,
+		fallback: fallback,
+		graph:    buildCSRGraph(nil, time.Time{}),
+	}
+}
+
+// Refresh reloads the whole graph from the source and swaps it in atomically
+func (gs *GraphSnapshot) Refresh(ctx context.Context) error {
+	loadStart := time.Now()
+
+	connections, err := gs.source.GetAllConnections(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load connections for graph snapshot: %w", err)
+	}
+
+	graph := buildCSRGraph(connections, loadStart)
+
+	gs.mutex.Lock()
+	defer gs.mutex.Unlock()
+
+	// Keep only the overlay edges the new load may have missed
+	var pending []overlayEdge
+	for _, edge := range gs.overlay {
+		if !edge.appliedAt.Before(loadStart) {
+			pending = append(pending, edge)
+		}
+	}
+
+	gs.graph = graph
+	gs.overlay = pending
+	return nil
+}
+
+// ApplyConnection makes a newly created or updated connection visible without a full reload
+func (gs *GraphSnapshot) ApplyConnection(connection *models.FamilyConnection) {
+	gs.mutex.Lock()
+	defer gs.mutex.Unlock()
+
+	gs.overlay = append(gs.overlay, overlayEdge{connection: connection, appliedAt: time.Now()})
+}
+
+// LoadedAt returns when the current CSR arrays were loaded
+func (gs *GraphSnapshot) LoadedAt() time.Time {
+	gs.mutex.RLock()
+	defer gs.mutex.RUnlock()
+
+	return gs.graph.loadedAt
+}
+
+// Staleness returns how long ago the current CSR arrays were loaded
+func (gs *GraphSnapshot) Staleness() time.Duration {
+	return time.Since(gs.LoadedAt())
+}
+
+// Stats reports the size of the snapshot
+func (gs *GraphSnapshot) Stats() SnapshotStats {
+	gs.mutex.RLock()
+	defer gs.mutex.RUnlock()
+
+	return SnapshotStats{
+		Families:     len(gs.graph.ids),
+		Connections:  len(gs.graph.targets) / 2,
+		OverlayEdges: len(gs.overlay),
+		MemoryBytes:  gs.graph.memoryBytes + int64(len(gs.overlay))*int64(unsafe.Sizeof(models.FamilyConnection{})),
+		LoadedAt:     gs.graph.loadedAt,
+	}
+}
+
+// SnapshotStats describes the size of a graph snapshot
+type SnapshotStats struct {
+	Families     int       `json:"families"`
+	Connections  int       `json:"connections"`
+	OverlayEdges int       `json:"overlay_edges"`
+	MemoryBytes  int64     `json:"memory_bytes"` // Approximate heap footprint
+	LoadedAt     time.Time `json:"loaded_at"`
+}
+
+func (gs *GraphSnapshot) GetNeighbors(ctx context.Context, familyID string) ([]*models.FamilyConnection, error) {
+	gs.mutex.RLock()
+	defer gs.mutex.RUnlock()
+
+	return gs.neighbors(familyID), nil
+}
+
+func (gs *GraphSnapshot) GetNeighborsBulk(ctx context.Context, familyIDs []string) (map[string][]*models.FamilyConnection, error) {
+	gs.mutex.RLock()
+	defer gs.mutex.RUnlock()
+
+	result := make(map[string][]*models.FamilyConnection, len(familyIDs))
+	for _, familyID := range familyIDs {
+		if neighbors := gs.neighbors(familyID); len(neighbors) > 0 {
+			result[familyID] = neighbors
+		}
+	}
+
+	return result, nil
+}
+
+func (gs *GraphSnapshot) GetConnection(ctx context.Context, from, to string) (*models.FamilyConnection, error) {
+	gs.mutex.RLock()
+	defer gs.mutex.RUnlock()
+
+	return findEdge(gs.neighbors(from), to), nil
+}
+
+func (gs *GraphSnapshot) GetFamilyTrustScore(ctx context.Context, familyID string) (float64, error) {
+	return gs.fallback.GetFamilyTrustScore(ctx, familyID)
+}
+
+// neighbors materializes the edges of a family from the CSR row and the overlay.
+// Callers must hold the snapshot lock.
+func (gs *GraphSnapshot) neighbors(familyID string) []*models.FamilyConnection {
+	edges := gs.graph.row(familyID)
+
+	for _, edge := range gs.overlay {
+		connection := edge.connection
+		var neighborID string
+		switch familyID {
+		case connection.FromFamilyID:
+			neighborID = connection.ToFamilyID
+		case connection.ToFamilyID:
+			neighborID = connection.FromFamilyID
+		default:
+			continue
+		}
+
+		oriented := *connection
+		oriented.FromFamilyID, oriented.ToFamilyID = familyID, neighborID
+
+		// Later records of a connection, such as a reconfirmation, replace earlier ones
+		if i := edgeIndex(edges, neighborID); i >= 0 {
+			edges[i] = &oriented
+			continue
+		}
+		edges = append(edges, &oriented)
+	}
+
+	return edges
+}
+
+// csrGraph stores the adjacency lists of all families in flat arrays.
+// The edges of family i are targets[offsets[i]:offsets[i+1]], with attributes at the same positions.
+type csrGraph struct {
+	index       map[string]int32
+	ids         []string
+	offsets     []int32
+	targets     []int32
+	attributes  []edgeAttributes
+	labels      []string // Interned relation type and specific relation values
+	loadedAt    time.Time
+	memoryBytes int64
+}
+
+// edgeAttributes is the compact form of a connection's properties
+type edgeAttributes struct {
+	strength         float64
+	established      int64 // Unix seconds
+	created          int64 // Unix seconds, zero when unknown
+	reconfirmed      int64 // Unix seconds, zero when never reconfirmed
+	relationType     uint16
+	specificRelation uint16
+	verified         bool
+}
+
+// buildCSRGraph builds the CSR arrays from a list of connections. Each connection is
+// added in both directions and duplicates between the same pair of families are dropped.
+func buildCSRGraph(connections []*models.FamilyConnection, loadedAt time.Time) *csrGraph {
+	graph := &csrGraph{
+		index:    make(map[string]int32),
+		loadedAt: loadedAt,
+	}
+
+	for _, connection := range connections {
+		graph.ids = append(graph.ids, connection.FromFamilyID, connection.ToFamilyID)
+	}
+	sort.Strings(graph.ids)
+	graph.ids = uniqueSorted(graph.ids)
+	for i, familyID := range graph.ids {
+		graph.index[familyID] = int32(i)
+	}
+
+	// Collect each family's edges, oriented away from it
+	type directedEdge struct {
+		from, to   int32
+		connection *models.FamilyConnection
+	}
+	directed := make([]directedEdge, 0, len(connections)*2)
+	for _, connection := range connections {
+		from, to := graph.index[connection.FromFamilyID], graph.index[connection.ToFamilyID]
+		directed = append(directed,
+			directedEdge{from: from, to: to, connection: connection},
+			directedEdge{from: to, to: from, connection: connection})
+	}
+	sort.SliceStable(directed, func(i, j int) bool {
+		if directed[i].from != directed[j].from {
+			return directed[i].from < directed[j].from
+		}
+		return directed[i].to < directed[j].to
+	})
+
+	labelIndex := make(map[string]uint16)
+	intern := func(label string) uint16 {
+		if id, ok := labelIndex[label]; ok {
+			return id
+		}
+		id := uint16(len(graph.labels))
+		labelIndex[label] = id
+		graph.labels = append(graph.labels, label)
+		return id
+	}
+
+	graph.offsets = make([]int32, len(graph.ids)+1)
+	for i, edge := range directed {
+		if i > 0 && edge.from == directed[i-1].from && edge.to == directed[i-1].to {
+			continue // Connections are stored in both directions
+		}
+
+		graph.targets = append(graph.targets, edge.to)
+		graph.attributes = append(graph.attributes, edgeAttributes{
+			strength:         edge.connection.Strength,
+			established:      edge.connection.EstablishedDate.Unix(),
+			created:          unixOrZero(edge.connection.CreatedAt),
+			reconfirmed:      unixOrZero(edge.connection.ReconfirmedAt),
+			relationType:     intern(edge.connection.RelationType),
+			specificRelation: intern(edge.connection.SpecificRelation),
+			verified:         edge.connection.Verified,
+		})
+		graph.offsets[edge.from+1]++
+	}
+	for i := 1; i < len(graph.offsets); i++ {
+		graph.offsets[i] += graph.offsets[i-1]
+	}
+
+	graph.memoryBytes = graph.estimateMemory()
+	return graph
+}
+
+// row materializes the edges of a family as connection records
+func (g *csrGraph) row(familyID string) []*models.FamilyConnection {
+	i, ok := g.index[familyID]
+	if !ok {
+		return nil
+	}
+
+	start, end := g.offsets[i], g.offsets[i+1]
+	edges := make([]*models.FamilyConnection, 0, end-start)
+	for e := start; e < end; e++ {
+		attributes := g.attributes[e]
+		edges = append(edges, &models.FamilyConnection{
+			FromFamilyID:     familyID,
+			ToFamilyID:       g.ids[g.targets[e]],
+			RelationType:     g.labels[attributes.relationType],
+			SpecificRelation: g.labels[attributes.specificRelation],
+			Strength:         attributes.strength,
+			Verified:         attributes.verified,
+			EstablishedDate:  time.Unix(attributes.established, 0).UTC(),
+			CreatedAt:        timeOrZero(attributes.created),
+			ReconfirmedAt:    timeOrZero(attributes.reconfirmed),
+		})
+	}
+
+	return edges
+}
+
+// estimateMemory approximates the heap bytes held by the CSR arrays and the ID index
+func (g *csrGraph) estimateMemory() int64 {
+	const mapEntryOverhead = 48 // Bucket share, hash and key header per map entry
+
+	bytes := int64(cap(g.offsets)+cap(g.targets)) * 4
+	bytes += int64(cap(g.attributes)) * int64(unsafe.Sizeof(edgeAttributes{}))
+	bytes += int64(cap(g.ids)) * int64(unsafe.Sizeof(""))
+	for _, familyID := range g.ids {
+		bytes += int64(len(familyID)) // Shared by ids and the index keys
+	}
+	bytes += int64(len(g.index)) * mapEntryOverhead
+	for _, label := range g.labels {
+		bytes += int64(len(label)) + int64(unsafe.Sizeof(""))
+	}
+
+	return bytes
+}
+
+// unixOrZero returns the Unix seconds of t, keeping the zero time as zero
+func unixOrZero(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+// timeOrZero reverses unixOrZero
+func timeOrZero(seconds int64) time.Time {
+	if seconds == 0 {
+		return time.Time{}
+	}
+	return time.Unix(seconds, 0).UTC()
+}
+
+// uniqueSorted removes adjacent duplicates from a sorted slice in place
+func uniqueSorted(values []string) []string {
+	if len(values) == 0 {
+		return values
+	}
+
+	unique := values[:1]
+	for _, value := range values[1:] {
+		if value != unique[len(unique)-1] {
+			unique = append(unique, value)
+		}
+	}
+	return unique
+}
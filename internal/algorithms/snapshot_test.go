@@ -0,0 +1,102 @@
+package algorithms_test
+
+import (
+	"context"
+	"families-linkedin/internal/algorithms"
+	"families-linkedin/internal/models"
+	"reflect"
+	"sort"
+	"strconv"
+	"testing"
+)
+
+// neighborStrengths renders a family's edges as "neighbor:strength", in neighbor order
+func neighborStrengths(edges []*models.FamilyConnection) []string {
+	rendered := make([]string, 0, len(edges))
+	for _, edge := range edges {
+		rendered = append(rendered, edge.ToFamilyID+":"+strconv.FormatFloat(edge.Strength, 'f', -1, 64))
+	}
+	sort.Strings(rendered)
+	return rendered
+}
+
+func TestGraphSnapshotMatchesStore(t *testing.T) {
+	ctx := context.Background()
+	graph := newMemoryGraph(t,
+		edge{"A", "B", 0.9}, edge{"A", "C", 0.5}, edge{"B", "C", 0.7}, edge{"C", "D", 0.3},
+	)
+	snapshot := algorithms.NewGraphSnapshot(graph.connections, graph)
+	if err := snapshot.Refresh(ctx); err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+
+	stats := snapshot.Stats()
+	if stats.Families != 4 || stats.Connections != 4 || stats.OverlayEdges != 0 {
+		t.Errorf("stats = %+v, want 4 families, 4 connections and no overlay", stats)
+	}
+
+	for _, familyID := range []string{"A", "B", "C", "D", "Z"} {
+		t.Run(familyID, func(t *testing.T) {
+			stored, err := graph.GetNeighbors(ctx, familyID)
+			if err != nil {
+				t.Fatalf("GetNeighbors from store: %v", err)
+			}
+			loaded, err := snapshot.GetNeighbors(ctx, familyID)
+			if err != nil {
+				t.Fatalf("GetNeighbors from snapshot: %v", err)
+			}
+			if got, want := neighborStrengths(loaded), neighborStrengths(stored); !reflect.DeepEqual(got, want) {
+				t.Errorf("snapshot neighbors = %v, store has %v", got, want)
+			}
+			for _, edge := range loaded {
+				if edge.FromFamilyID != familyID || !edge.Verified {
+					t.Errorf("edge %s-%s (verified %v) not oriented from %s as stored", edge.FromFamilyID, edge.ToFamilyID, edge.Verified, familyID)
+				}
+			}
+		})
+	}
+}
+
+func TestGraphSnapshotOverlay(t *testing.T) {
+	ctx := context.Background()
+	graph := newMemoryGraph(t, edge{"A", "B", 0.9}, edge{"B", "C", 0.7})
+	snapshot := algorithms.NewGraphSnapshot(graph.connections, graph)
+	if err := snapshot.Refresh(ctx); err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+
+	snapshot.ApplyConnection(models.NewFamilyConnection("C", "D", "RELATIVE", "COUSIN", 0.6, true))
+	snapshot.ApplyConnection(models.NewFamilyConnection("B", "A", "RELATIVE", "COUSIN", 0.4, true)) // Weakened
+
+	tests := []struct {
+		familyID string
+		want     []string
+	}{
+		{"A", []string{"B:0.4"}},
+		{"B", []string{"A:0.4", "C:0.7"}},
+		{"C", []string{"B:0.7", "D:0.6"}},
+		{"D", []string{"C:0.6"}},
+	}
+	for _, tt := range tests {
+		edges, err := snapshot.GetNeighbors(ctx, tt.familyID)
+		if err != nil {
+			t.Fatalf("GetNeighbors(%s): %v", tt.familyID, err)
+		}
+		if got := neighborStrengths(edges); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("neighbors of %s = %v, want %v", tt.familyID, got, tt.want)
+		}
+	}
+
+	path, err := algorithms.NewBidirectionalBFS(snapshot, nil).FindPath(ctx, "A", "D", 4, nil)
+	if err != nil || route(path) != "A-B-C-D" {
+		t.Errorf("FindPath over the overlay = %q, %v; want A-B-C-D", route(path), err)
+	}
+
+	// A full load folds in everything applied before it started
+	if err := snapshot.Refresh(ctx); err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+	if stats := snapshot.Stats(); stats.OverlayEdges != 0 {
+		t.Errorf("overlay edges after refresh = %d, want 0", stats.OverlayEdges)
+	}
+}
@@ -0,0 +1,32 @@
+package algorithms
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// SearchStats counts the repository round trips made while answering a single search
+type SearchStats struct {
+	roundTrips int64
+}
+
+// RoundTrips returns the number of repository round trips recorded so far
+func (s *SearchStats) RoundTrips() int64 {
+	return atomic.LoadInt64(&s.roundTrips)
+}
+
+type searchStatsKey struct{}
+
+// WithSearchStats attaches a fresh SearchStats to the context. Repositories that reach
+// the database call RecordRoundTrip with the same context to count their queries.
+func WithSearchStats(ctx context.Context) (context.Context, *SearchStats) {
+	stats := &SearchStats{}
+	return context.WithValue(ctx, searchStatsKey{}, stats), stats
+}
+
+// RecordRoundTrip counts one repository round trip against the search in ctx, if any
+func RecordRoundTrip(ctx context.Context) {
+	if stats, ok := ctx.Value(searchStatsKey{}).(*SearchStats); ok {
+		atomic.AddInt64(&stats.roundTrips, 1)
+	}
+}
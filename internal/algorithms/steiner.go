@@ -0,0 +1,253 @@
+package algorithms
+
+import (
+	"context"
+	"families-linkedin/internal/models"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// FindSteinerTree approximates the smallest tree of connections joining a group of families
+// with the Kou-Markowsky-Berman heuristic. Shortest paths between every pair of families form
+// a complete graph; its minimum spanning tree is expanded back into the connections along the
+// chosen paths, a spanning tree over those connections, strongest first, drops any cycles,
+// and intermediaries left as leaves are pruned. The result has at most twice as many
+// connections as the smallest tree.
+//
+// The pair searches run in parallel on pathFinder, so they follow its snapshot, cache and
+// constraints. When no path within maxDepth joins part of the group to the rest, the tree
+// joins the largest part, earliest listed family first, and the others are reported as
+// unreachable.
+func FindSteinerTree(ctx context.Context, pathFinder PathFinder, familyIDs []string, maxDepth int, constraints *PathConstraints, maxWorkers int) (*models.SteinerTree, error) {
+	terminals := make([]string, 0, len(familyIDs))
+	seen := make(map[string]bool, len(familyIDs))
+	for _, familyID := range familyIDs {
+		if !seen[familyID] {
+			seen[familyID] = true
+			terminals = append(terminals, familyID)
+		}
+	}
+
+	tree := &models.SteinerTree{
+		FamilyIDs:            terminals,
+		IntermediaryIDs:      []string{},
+		UnreachableFamilyIDs: []string{},
+		Edges:                []models.PathEdge{},
+		CalculatedAt:         time.Now(),
+	}
+	if len(terminals) < 2 {
+		return tree, nil
+	}
+
+	// Shortest path between every pair of families
+	var queries []*PathQuery
+	for i := range terminals {
+		for j := i + 1; j < len(terminals); j++ {
+			queries = append(queries, &PathQuery{FromID: terminals[i], ToID: terminals[j], MaxDepth: maxDepth, Constraints: constraints})
+		}
+	}
+
+	results, err := NewParallelPathFinder(pathFinder, maxWorkers).FindMultiplePathsParallel(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+
+	index := make(map[string]int, len(terminals))
+	for i, familyID := range terminals {
+		index[familyID] = i
+	}
+	closure := make([][]*models.ConnectionPath, len(terminals))
+	for i := range closure {
+		closure[i] = make([]*models.ConnectionPath, len(terminals))
+	}
+	for _, result := range results {
+		if result.Error != nil {
+			return nil, fmt.Errorf("failed to find path from %s to %s: %w", result.Query.FromID, result.Query.ToID, result.Error)
+		}
+		i, j := index[result.Query.FromID], index[result.Query.ToID]
+		closure[i][j], closure[j][i] = result.Path, result.Path
+	}
+
+	// Grow the tree from the largest group of families the pair paths join
+	root, rootSize := 0, 0
+	grouped := make([]bool, len(terminals))
+	for i := range terminals {
+		if grouped[i] {
+			continue
+		}
+		grouped[i] = true
+		group := []int{i}
+		for head := 0; head < len(group); head++ {
+			for j := range terminals {
+				if !grouped[j] && closure[group[head]][j] != nil {
+					grouped[j] = true
+					group = append(group, j)
+				}
+			}
+		}
+		if len(group) > rootSize {
+			root, rootSize = i, len(group)
+		}
+	}
+
+	// Prim's minimum spanning tree over the pair paths, fewest hops first and strongest
+	// among equals
+	inTree := make([]bool, len(terminals))
+	inTree[root] = true
+	var chosen []*models.ConnectionPath
+	for {
+		var best *models.ConnectionPath
+		bestTerminal := -1
+		for i := range terminals {
+			if !inTree[i] {
+				continue
+			}
+			for j := range terminals {
+				path := closure[i][j]
+				if inTree[j] || path == nil {
+					continue
+				}
+				if best == nil || path.Degree < best.Degree || (path.Degree == best.Degree && path.PathStrength > best.PathStrength) {
+					best, bestTerminal = path, j
+				}
+			}
+		}
+		if best == nil {
+			break
+		}
+		inTree[bestTerminal] = true
+		chosen = append(chosen, best)
+	}
+
+	isTerminal := make(map[string]bool, len(terminals))
+	for i, familyID := range terminals {
+		if inTree[i] {
+			isTerminal[familyID] = true
+		} else {
+			tree.UnreachableFamilyIDs = append(tree.UnreachableFamilyIDs, familyID)
+		}
+	}
+
+	// Expand the chosen paths into their connections, once per pair of families
+	edgesByPair := make(map[string]models.PathEdge)
+	for _, path := range chosen {
+		for _, edge := range path.Edges {
+			key := edgePairKey(edge.FromFamilyID, edge.ToFamilyID)
+			if _, exists := edgesByPair[key]; !exists {
+				edgesByPair[key] = edge
+			}
+		}
+	}
+	candidates := make([]models.PathEdge, 0, len(edgesByPair))
+	for _, edge := range edgesByPair {
+		candidates = append(candidates, edge)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].DecayedStrength != candidates[j].DecayedStrength {
+			return candidates[i].DecayedStrength > candidates[j].DecayedStrength
+		}
+		return edgePairKey(candidates[i].FromFamilyID, candidates[i].ToFamilyID) < edgePairKey(candidates[j].FromFamilyID, candidates[j].ToFamilyID)
+	})
+
+	// Kruskal's spanning tree over the expanded connections drops the cycles where paths overlap
+	parents := make(map[string]string)
+	var find func(familyID string) string
+	find = func(familyID string) string {
+		parent, ok := parents[familyID]
+		if !ok || parent == familyID {
+			return familyID
+		}
+		root := find(parent)
+		parents[familyID] = root
+		return root
+	}
+	adjacent := make(map[string][]models.PathEdge)
+	for _, edge := range candidates {
+		fromRoot, toRoot := find(edge.FromFamilyID), find(edge.ToFamilyID)
+		if fromRoot == toRoot {
+			continue
+		}
+		parents[fromRoot] = toRoot
+		adjacent[edge.FromFamilyID] = append(adjacent[edge.FromFamilyID], edge)
+		adjacent[edge.ToFamilyID] = append(adjacent[edge.ToFamilyID], edge)
+	}
+
+	// Prune intermediaries left as leaves, which join nothing
+	removed := make(map[string]bool)
+	degree := make(map[string]int, len(adjacent))
+	var leaves []string
+	for familyID, edges := range adjacent {
+		degree[familyID] = len(edges)
+		if len(edges) == 1 && !isTerminal[familyID] {
+			leaves = append(leaves, familyID)
+		}
+	}
+	for len(leaves) > 0 {
+		leaf := leaves[len(leaves)-1]
+		leaves = leaves[:len(leaves)-1]
+		removed[leaf] = true
+		for _, edge := range adjacent[leaf] {
+			other := otherEnd(edge, leaf)
+			if removed[other] {
+				continue
+			}
+			degree[other]--
+			if degree[other] == 1 && !isTerminal[other] {
+				leaves = append(leaves, other)
+			}
+		}
+	}
+
+	// Walk the tree outward from its root family, orienting every connection away from it
+	visited := map[string]bool{terminals[root]: true}
+	queue := []string{terminals[root]}
+	for head := 0; head < len(queue); head++ {
+		familyID := queue[head]
+		for _, edge := range adjacent[familyID] {
+			next := otherEnd(edge, familyID)
+			if removed[next] || visited[next] {
+				continue
+			}
+			visited[next] = true
+			queue = append(queue, next)
+
+			if edge.FromFamilyID != familyID {
+				edge.FromFamilyID, edge.ToFamilyID = edge.ToFamilyID, edge.FromFamilyID
+			}
+			tree.Edges = append(tree.Edges, edge)
+			if !isTerminal[next] {
+				tree.IntermediaryIDs = append(tree.IntermediaryIDs, next)
+			}
+		}
+	}
+
+	if len(tree.Edges) > 0 {
+		tree.TotalStrength = 1.0
+		tree.Verified = true
+	}
+	for _, edge := range tree.Edges {
+		tree.TotalStrength *= edge.DecayedStrength
+		if !edge.Verified {
+			tree.Verified = false
+		}
+	}
+
+	return tree, nil
+}
+
+// edgePairKey identifies the connection between two families regardless of direction
+func edgePairKey(family1ID, family2ID string) string {
+	if family1ID > family2ID {
+		family1ID, family2ID = family2ID, family1ID
+	}
+	return family1ID + "|" + family2ID
+}
+
+// otherEnd returns the family at the other end of an edge
+func otherEnd(edge models.PathEdge, familyID string) string {
+	if edge.FromFamilyID == familyID {
+		return edge.ToFamilyID
+	}
+	return edge.FromFamilyID
+}
@@ -0,0 +1,66 @@
+package algorithms_test
+
+import (
+	"context"
+	"families-linkedin/internal/algorithms"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestFindSteinerTree(t *testing.T) {
+	// A, B and C hang off the hub H, L lies three hops beyond C, and X-Z is a separate component
+	graph := newMemoryGraph(t,
+		edge{"A", "H", 0.9}, edge{"B", "H", 0.8}, edge{"C", "H", 0.7},
+		edge{"C", "K", 0.6}, edge{"K", "L", 0.6},
+		edge{"X", "Z", 0.9},
+	)
+
+	tests := []struct {
+		name              string
+		families          []string
+		wantEdges         []string // Sorted "from-to" pairs
+		wantIntermediary  []string
+		wantUnreachable   []string
+		wantFamilyIDCount int
+	}{
+		{"single family", []string{"A"}, []string{}, []string{}, []string{}, 1},
+		{"duplicates count once", []string{"A", "A", "H"}, []string{"A-H"}, []string{}, []string{}, 2},
+		{"joined through the hub", []string{"A", "B", "C"}, []string{"A-H", "B-H", "C-H"}, []string{"H"}, []string{}, 3},
+		{"chain of intermediaries", []string{"A", "L"}, []string{"A-H", "C-H", "C-K", "K-L"}, []string{"C", "H", "K"}, []string{}, 2},
+		{"separate component reported", []string{"A", "B", "Z"}, []string{"A-H", "B-H"}, []string{"H"}, []string{"Z"}, 3},
+	}
+
+	finder := algorithms.NewBidirectionalBFS(graph, nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree, err := algorithms.FindSteinerTree(context.Background(), finder, tt.families, 6, nil, 4)
+			if err != nil {
+				t.Fatalf("FindSteinerTree: %v", err)
+			}
+
+			edges := []string{}
+			for _, e := range tree.Edges {
+				pair := []string{e.FromFamilyID, e.ToFamilyID}
+				sort.Strings(pair)
+				edges = append(edges, pair[0]+"-"+pair[1])
+			}
+			sort.Strings(edges)
+			intermediaries := append([]string{}, tree.IntermediaryIDs...)
+			sort.Strings(intermediaries)
+
+			if !reflect.DeepEqual(edges, tt.wantEdges) {
+				t.Errorf("edges = %v, want %v", edges, tt.wantEdges)
+			}
+			if !reflect.DeepEqual(intermediaries, tt.wantIntermediary) {
+				t.Errorf("intermediaries = %v, want %v", intermediaries, tt.wantIntermediary)
+			}
+			if !reflect.DeepEqual(tree.UnreachableFamilyIDs, tt.wantUnreachable) {
+				t.Errorf("unreachable = %v, want %v", tree.UnreachableFamilyIDs, tt.wantUnreachable)
+			}
+			if len(tree.FamilyIDs) != tt.wantFamilyIDCount {
+				t.Errorf("families = %v, want %d", tree.FamilyIDs, tt.wantFamilyIDCount)
+			}
+		})
+	}
+}
@@ -0,0 +1,248 @@
+package algorithms
+
+import (
+	"container/heap"
+	"context"
+	"families-linkedin/internal/models"
+	"fmt"
+	"math"
+	"time"
+)
+
+// StrongestPathFinder finds the path that maximizes the product of connection strengths.
+// It runs Dijkstra over -log(strength) edge weights, bounded by the maximum hop count,
+// so a longer chain of strong relatives can win over a short path through a weak link.
+type StrongestPathFinder struct {
+	repo  GraphRepository
+	decay *models.StrengthDecay // Applied to connection strengths before weighting
+}
+
+// NewStrongestPathFinder creates a new strength-weighted path finder
+func NewStrongestPathFinder(repo GraphRepository, decay *models.StrengthDecay) *StrongestPathFinder {
+	return &StrongestPathFinder{repo: repo, decay: decay}
+}
+
+// FindPath finds the strongest path between two families within maxDepth hops
+func (spf *StrongestPathFinder) FindPath(ctx context.Context, fromID, toID string, maxDepth int, constraints *PathConstraints) (*models.ConnectionPath, error) {
+	return firstPath(findConstrainedPaths(ctx, spf.repo, fromID, toID, maxDepth, 1, constraints, RankByStrength, spf.decay,
+		func(ctx context.Context, repo GraphRepository, fromID, toID string, maxDepth, _ int) ([]*models.ConnectionPath, error) {
+			return singlePath(NewStrongestPathFinder(repo, spf.decay).findPath(ctx, fromID, toID, maxDepth))
+		}))
+}
+
+// findPath runs the unconstrained strength-weighted search
+func (spf *StrongestPathFinder) findPath(ctx context.Context, fromID, toID string, maxDepth int) (*models.ConnectionPath, error) {
+	if fromID == toID {
+		return &models.ConnectionPath{
+			SourceFamilyID: fromID,
+			TargetFamilyID: toID,
+			Path:           []string{fromID},
+			Degree:         0,
+			PathStrength:   1.0,
+			Verified:       true,
+			CalculatedAt:   time.Now(),
+		}, nil
+	}
+
+	path, err := weightedSearch(ctx, spf.repo, fromID, toID, maxDepth, strengthWeight, spf.decay, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if path == nil {
+		return nil, nil // No path found
+	}
+
+	connectionPath := &models.ConnectionPath{
+		SourceFamilyID: fromID,
+		TargetFamilyID: toID,
+		Path:           path,
+		Degree:         len(path) - 1,
+		CalculatedAt:   time.Now(),
+	}
+
+	if err := calculatePathStrength(ctx, spf.repo, connectionPath, spf.decay); err != nil {
+		return nil, err
+	}
+
+	return connectionPath, nil
+}
+
+// FindMultiplePaths finds up to maxPaths distinct loopless paths, strongest first
+func (spf *StrongestPathFinder) FindMultiplePaths(ctx context.Context, fromID, toID string, maxDepth, maxPaths int, constraints *PathConstraints) ([]*models.ConnectionPath, error) {
+	return NewKShortestPaths(spf.repo, RankByStrength, spf.decay).FindMultiplePaths(ctx, fromID, toID, maxDepth, maxPaths, constraints)
+}
+
+// edgeWeight converts a connection strength into a non-negative search cost.
+// Returning +Inf removes the edge from the search.
+type edgeWeight func(strength float64) float64
+
+// strengthWeight maps strength to -log(strength) so that minimizing the sum
+// of weights maximizes the product of strengths
+func strengthWeight(strength float64) float64 {
+	if strength <= 0 {
+		return math.Inf(1)
+	}
+	if strength >= 1 {
+		return 0
+	}
+	return -math.Log(strength)
+}
+
+// searchState is a label in the hop-bounded Dijkstra search
+type searchState struct {
+	familyID string
+	hops     int
+	cost     float64
+	parent   *searchState
+	index    int
+}
+
+// onPath reports whether a family already appears on the path leading to this state
+func (s *searchState) onPath(familyID string) bool {
+	for state := s; state != nil; state = state.parent {
+		if state.familyID == familyID {
+			return true
+		}
+	}
+	return false
+}
+
+// path reconstructs the family IDs from the search origin to this state
+func (s *searchState) path() []string {
+	path := make([]string, s.hops+1)
+	for state := s; state != nil; state = state.parent {
+		path[state.hops] = state.familyID
+	}
+	return path
+}
+
+type stateQueue []*searchState
+
+func (q stateQueue) Len() int { return len(q) }
+func (q stateQueue) Less(i, j int) bool {
+	if q[i].cost != q[j].cost {
+		return q[i].cost < q[j].cost
+	}
+	return q[i].hops < q[j].hops
+}
+func (q stateQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+func (q *stateQueue) Push(x interface{}) {
+	state := x.(*searchState)
+	state.index = len(*q)
+	*q = append(*q, state)
+}
+func (q *stateQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	state := old[n-1]
+	*q = old[:n-1]
+	return state
+}
+
+// hopWeight ranks paths by hop count, breaking ties between equally long paths by strength
+func hopWeight(strength float64) float64 {
+	return 1 + math.Min(strengthWeight(strength), 100)*1e-6
+}
+
+// searchExclusions removes families and edges from a weighted search
+type searchExclusions struct {
+	families map[string]bool
+	edges    map[string]bool
+}
+
+func (e *searchExclusions) excludesFamily(familyID string) bool {
+	return e != nil && e.families[familyID]
+}
+
+func (e *searchExclusions) excludesEdge(fromID, toID string) bool {
+	return e != nil && e.edges[edgeKey(fromID, toID)]
+}
+
+// edgeKey identifies an undirected edge between two families
+func edgeKey(fromID, toID string) string {
+	if fromID > toID {
+		fromID, toID = toID, fromID
+	}
+	return fromID + "|" + toID
+}
+
+// weightedSearch runs a hop-bounded Dijkstra search and returns the cheapest loopless path,
+// or nil when the target is unreachable within maxHops. Edges are weighted by their
+// strength after decay.
+func weightedSearch(ctx context.Context, repo GraphRepository, fromID, toID string, maxHops int, weight edgeWeight, decay *models.StrengthDecay, exclusions *searchExclusions) ([]string, error) {
+	now := time.Now()
+	queue := &stateQueue{}
+	heap.Push(queue, &searchState{familyID: fromID})
+
+	// settled keeps the (hops, cost) labels already expanded per family, used to
+	// discard labels that are both longer and costlier than a settled one
+	settled := make(map[string][]*searchState)
+
+	for queue.Len() > 0 {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		state := heap.Pop(queue).(*searchState)
+		if state.familyID == toID {
+			return state.path(), nil
+		}
+
+		if isDominated(settled[state.familyID], state.hops, state.cost) {
+			continue
+		}
+		settled[state.familyID] = append(settled[state.familyID], state)
+
+		if state.hops >= maxHops {
+			continue
+		}
+
+		edges, err := repo.GetNeighbors(ctx, state.familyID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get neighbors for %s: %w", state.familyID, err)
+		}
+
+		for _, edge := range edges {
+			neighbor := edge.ToFamilyID
+			if state.onPath(neighbor) {
+				continue // Keep paths loopless
+			}
+			if exclusions.excludesFamily(neighbor) || exclusions.excludesEdge(state.familyID, neighbor) {
+				continue
+			}
+
+			cost := weight(edge.DecayedStrength(decay, now))
+			if math.IsInf(cost, 1) {
+				continue
+			}
+
+			next := &searchState{
+				familyID: neighbor,
+				hops:     state.hops + 1,
+				cost:     state.cost + cost,
+				parent:   state,
+			}
+			if isDominated(settled[neighbor], next.hops, next.cost) {
+				continue
+			}
+			heap.Push(queue, next)
+		}
+	}
+
+	return nil, nil
+}
+
+// isDominated reports whether a settled label reaches the same family in no more hops at no greater cost
+func isDominated(labels []*searchState, hops int, cost float64) bool {
+	for _, label := range labels {
+		if label.hops <= hops && label.cost <= cost {
+			return true
+		}
+	}
+	return false
+}
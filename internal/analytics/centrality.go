@@ -0,0 +1,221 @@
+package analytics
+
+import (
+	"families-linkedin/internal/models"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// CentralityOptions tunes the centrality computations
+type CentralityOptions struct {
+	Damping       float64 // PageRank damping factor
+	Tolerance     float64 // PageRank stops once the L1 change of an iteration falls below this
+	MaxIterations int
+	// BetweennessSamples is the number of source families Brandes' algorithm starts from.
+	// Zero, or at least the number of families, computes exact betweenness.
+	BetweennessSamples int
+	Seed               int64 // Seeds the source sampling so repeated runs agree
+}
+
+// DefaultCentralityOptions returns the options used by the batch job
+func DefaultCentralityOptions() CentralityOptions {
+	return CentralityOptions{
+		Damping:            0.85,
+		Tolerance:          1e-6,
+		MaxIterations:      100,
+		BetweennessSamples: 256,
+		Seed:               1,
+	}
+}
+
+// CentralityResult holds the scores of every family in the graph
+type CentralityResult struct {
+	Scores             map[string]models.Centrality
+	Families           int
+	Connections        int
+	PageRankIterations int
+	BetweennessSources int // Equals Families when betweenness is exact
+}
+
+// ComputeCentrality computes PageRank, betweenness and degree centrality for every family
+func ComputeCentrality(g *Graph, options CentralityOptions) *CentralityResult {
+	pageRank, iterations := PageRank(g, options.Damping, options.Tolerance, options.MaxIterations)
+	betweenness, sources := Betweenness(g, options.BetweennessSamples, rand.New(rand.NewSource(options.Seed)))
+	degree := DegreeCentrality(g)
+
+	now := time.Now()
+	scores := make(map[string]models.Centrality, g.Len())
+	for node := 0; node < g.Len(); node++ {
+		scores[g.ID(node)] = models.Centrality{
+			PageRank:         pageRank[node],
+			Betweenness:      betweenness[node],
+			DegreeCentrality: degree[node],
+			Degree:           g.Degree(node),
+			CalculatedAt:     now,
+		}
+	}
+
+	return &CentralityResult{
+		Scores:             scores,
+		Families:           g.Len(),
+		Connections:        g.EdgeCount(),
+		PageRankIterations: iterations,
+		BetweennessSources: sources,
+	}
+}
+
+// PageRank computes strength-weighted PageRank by power iteration. A family passes its
+// rank to its neighbors in proportion to connection strength. Scores sum to 1.
+// It returns the scores and the number of iterations run.
+func PageRank(g *Graph, damping, tolerance float64, maxIterations int) ([]float64, int) {
+	n := g.Len()
+	if n == 0 {
+		return nil, 0
+	}
+
+	// Total outgoing weight per family
+	strength := make([]float64, n)
+	for node := 0; node < n; node++ {
+		for _, edge := range g.Neighbors(node) {
+			strength[node] += edge.Weight
+		}
+	}
+
+	rank := make([]float64, n)
+	for node := range rank {
+		rank[node] = 1 / float64(n)
+	}
+	next := make([]float64, n)
+
+	iterations := 0
+	for iterations < maxIterations {
+		iterations++
+
+		// Rank held by families without weighted connections is spread evenly
+		dangling := 0.0
+		for node := 0; node < n; node++ {
+			if strength[node] == 0 {
+				dangling += rank[node]
+			}
+		}
+
+		base := (1-damping)/float64(n) + damping*dangling/float64(n)
+		for node := range next {
+			next[node] = base
+		}
+		for node := 0; node < n; node++ {
+			if strength[node] == 0 {
+				continue
+			}
+			share := damping * rank[node] / strength[node]
+			for _, edge := range g.Neighbors(node) {
+				next[edge.To] += share * edge.Weight
+			}
+		}
+
+		change := 0.0
+		for node := range rank {
+			change += math.Abs(next[node] - rank[node])
+		}
+		rank, next = next, rank
+
+		if change < tolerance {
+			break
+		}
+	}
+
+	return rank, iterations
+}
+
+// Betweenness computes hop-count betweenness centrality with Brandes' algorithm, normalized
+// to [0, 1]. When samples is below the number of families, only that many randomly chosen
+// sources are expanded and the result is scaled up, trading accuracy for time; rng is
+// only used then. It returns the scores and the number of sources expanded.
+func Betweenness(g *Graph, samples int, rng *rand.Rand) ([]float64, int) {
+	n := g.Len()
+	centrality := make([]float64, n)
+	if n < 3 {
+		return centrality, n
+	}
+
+	var sources []int
+	if samples > 0 && samples < n {
+		sources = rng.Perm(n)[:samples]
+	} else {
+		sources = make([]int, n)
+		for node := range sources {
+			sources[node] = node
+		}
+	}
+
+	// Buffers reused across sources
+	distance := make([]int, n)
+	paths := make([]float64, n) // Number of shortest paths from the source
+	dependency := make([]float64, n)
+	predecessors := make([][]int, n)
+	order := make([]int, 0, n) // Nodes in order of non-decreasing distance
+	queue := make([]int, 0, n)
+
+	for _, source := range sources {
+		for node := 0; node < n; node++ {
+			distance[node] = -1
+			paths[node] = 0
+			dependency[node] = 0
+			predecessors[node] = predecessors[node][:0]
+		}
+		order = order[:0]
+		queue = append(queue[:0], source)
+		distance[source] = 0
+		paths[source] = 1
+
+		for head := 0; head < len(queue); head++ {
+			node := queue[head]
+			order = append(order, node)
+			for _, edge := range g.Neighbors(node) {
+				if distance[edge.To] < 0 {
+					distance[edge.To] = distance[node] + 1
+					queue = append(queue, edge.To)
+				}
+				if distance[edge.To] == distance[node]+1 {
+					paths[edge.To] += paths[node]
+					predecessors[edge.To] = append(predecessors[edge.To], node)
+				}
+			}
+		}
+
+		// Accumulate dependencies from the farthest nodes back towards the source
+		for i := len(order) - 1; i >= 0; i-- {
+			node := order[i]
+			for _, predecessor := range predecessors[node] {
+				dependency[predecessor] += paths[predecessor] / paths[node] * (1 + dependency[node])
+			}
+			if node != source {
+				centrality[node] += dependency[node]
+			}
+		}
+	}
+
+	// Scale sampled sources up to all sources, count each undirected pair once
+	// and normalize by the number of pairs excluding the node itself
+	scale := float64(n) / float64(len(sources)) / 2 / (float64(n-1) * float64(n-2) / 2)
+	for node := range centrality {
+		centrality[node] *= scale
+	}
+
+	return centrality, len(sources)
+}
+
+// DegreeCentrality returns each family's share of the other families it is directly connected to
+func DegreeCentrality(g *Graph) []float64 {
+	n := g.Len()
+	centrality := make([]float64, n)
+	if n < 2 {
+		return centrality
+	}
+
+	for node := range centrality {
+		centrality[node] = float64(g.Degree(node)) / float64(n-1)
+	}
+	return centrality
+}
@@ -0,0 +1,90 @@
+package analytics_test
+
+import (
+	"families-linkedin/internal/analytics"
+	"families-linkedin/internal/models"
+	"math"
+	"testing"
+)
+
+// link is a verified connection between two test families
+func link(from, to string, strength float64) *models.FamilyConnection {
+	return models.NewFamilyConnection(from, to, "RELATIVE", "COUSIN", strength, true)
+}
+
+func TestCentrality(t *testing.T) {
+	tests := []struct {
+		name            string
+		connections     []*models.FamilyConnection
+		wantBetweenness map[string]float64
+		wantDegree      map[string]float64
+	}{
+		{
+			name:            "star",
+			connections:     []*models.FamilyConnection{link("H", "A", 0.5), link("H", "B", 0.5), link("H", "C", 0.5), link("H", "D", 0.5)},
+			wantBetweenness: map[string]float64{"H": 1, "A": 0, "B": 0, "C": 0, "D": 0},
+			wantDegree:      map[string]float64{"H": 1, "A": 0.25, "B": 0.25, "C": 0.25, "D": 0.25},
+		},
+		{
+			// B and C each sit on two of the three paths between the other families
+			name:            "path",
+			connections:     []*models.FamilyConnection{link("A", "B", 0.5), link("B", "C", 0.5), link("C", "D", 0.5)},
+			wantBetweenness: map[string]float64{"A": 0, "B": 2.0 / 3, "C": 2.0 / 3, "D": 0},
+			wantDegree:      map[string]float64{"A": 1.0 / 3, "B": 2.0 / 3, "C": 2.0 / 3, "D": 1.0 / 3},
+		},
+		{
+			name:            "pair",
+			connections:     []*models.FamilyConnection{link("A", "B", 0.5)},
+			wantBetweenness: map[string]float64{"A": 0, "B": 0},
+			wantDegree:      map[string]float64{"A": 1, "B": 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := analytics.NewGraph(tt.connections)
+			result := analytics.ComputeCentrality(g, analytics.DefaultCentralityOptions())
+
+			if result.BetweennessSources != g.Len() {
+				t.Errorf("betweenness expanded %d sources, want all %d", result.BetweennessSources, g.Len())
+			}
+
+			totalRank := 0.0
+			for familyID, scores := range result.Scores {
+				if math.Abs(scores.Betweenness-tt.wantBetweenness[familyID]) > 1e-9 {
+					t.Errorf("betweenness of %s = %.4f, want %.4f", familyID, scores.Betweenness, tt.wantBetweenness[familyID])
+				}
+				if math.Abs(scores.DegreeCentrality-tt.wantDegree[familyID]) > 1e-9 {
+					t.Errorf("degree centrality of %s = %.4f, want %.4f", familyID, scores.DegreeCentrality, tt.wantDegree[familyID])
+				}
+				totalRank += scores.PageRank
+			}
+			if math.Abs(totalRank-1) > 1e-6 {
+				t.Errorf("PageRank sums to %.6f, want 1", totalRank)
+			}
+		})
+	}
+}
+
+func TestPageRankFavoursStrongHubs(t *testing.T) {
+	// H is connected to everyone; A's only other connection is weak
+	g := analytics.NewGraph([]*models.FamilyConnection{
+		link("H", "A", 0.9), link("H", "B", 0.9), link("H", "C", 0.9), link("A", "B", 0.1),
+	})
+	rank, iterations := analytics.PageRank(g, 0.85, 1e-9, 100)
+	if iterations >= 100 {
+		t.Errorf("PageRank did not converge in %d iterations", iterations)
+	}
+
+	hub, _ := g.Index("H")
+	leaf, _ := g.Index("C")
+	for node, score := range rank {
+		if node != hub && score >= rank[hub] {
+			t.Errorf("rank of %s = %.4f, not below the hub's %.4f", g.ID(node), score, rank[hub])
+		}
+	}
+	a, _ := g.Index("A")
+	if rank[a] <= rank[leaf] {
+		t.Errorf("rank of A = %.4f, want above the leaf C's %.4f", rank[a], rank[leaf])
+	}
+}
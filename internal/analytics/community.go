@@ -0,0 +1,352 @@
+package analytics
+
+import (
+	"families-linkedin/internal/models"
+	"sort"
+)
+
+// maxBridgeExamples caps the connections listed for each pair of communities
+const maxBridgeExamples = 10
+
+// CommunityResult is a partition of the family graph into communities
+type CommunityResult struct {
+	// Assignments maps each family to its community. Community IDs start at 1, in order
+	// of decreasing size, so zero can mean "not assigned".
+	Assignments map[string]int
+	Modularity  float64
+	Levels      int // Louvain aggregation levels that improved modularity
+	Communities []CommunityStats
+	Bridges     []CommunityBridge // Strongest first
+}
+
+// CommunityStats describes one community
+type CommunityStats struct {
+	ID               int     `json:"id"`
+	Members          int     `json:"members"`
+	InternalStrength float64 `json:"internal_strength"` // Total strength of connections inside the community
+}
+
+// CommunityBridge summarizes the connections between two communities
+type CommunityBridge struct {
+	FromCommunity   int                        `json:"from_community"`
+	ToCommunity     int                        `json:"to_community"`
+	ConnectionCount int                        `json:"connection_count"`
+	TotalStrength   float64                    `json:"total_strength"`
+	Connections     []*models.FamilyConnection `json:"connections"` // The strongest few
+}
+
+// ComputeCommunities partitions the graph with Louvain and summarizes the communities
+// and the connections bridging them
+func ComputeCommunities(g *Graph, resolution float64) *CommunityResult {
+	membership, levels := Louvain(g, resolution)
+
+	result := &CommunityResult{
+		Assignments: make(map[string]int, g.Len()),
+		Modularity:  Modularity(g, membership, resolution),
+		Levels:      levels,
+	}
+
+	communityCount := 0
+	for _, community := range membership {
+		communityCount = max(communityCount, community+1)
+	}
+	result.Communities = make([]CommunityStats, communityCount)
+	for community := range result.Communities {
+		result.Communities[community].ID = community + 1
+	}
+
+	bridges := make(map[[2]int]*CommunityBridge)
+	for node := 0; node < g.Len(); node++ {
+		community := membership[node]
+		result.Assignments[g.ID(node)] = community + 1
+		result.Communities[community].Members++
+
+		for _, edge := range g.Neighbors(node) {
+			if edge.To < node {
+				continue // Visit each connection once
+			}
+
+			other := membership[edge.To]
+			if other == community {
+				result.Communities[community].InternalStrength += edge.Weight
+				continue
+			}
+
+			pair := [2]int{min(community, other) + 1, max(community, other) + 1}
+			bridge, ok := bridges[pair]
+			if !ok {
+				bridge = &CommunityBridge{FromCommunity: pair[0], ToCommunity: pair[1]}
+				bridges[pair] = bridge
+			}
+			bridge.ConnectionCount++
+			bridge.TotalStrength += edge.Weight
+			bridge.Connections = append(bridge.Connections, edge.Connection)
+		}
+	}
+
+	for _, bridge := range bridges {
+		sort.SliceStable(bridge.Connections, func(i, j int) bool {
+			return bridge.Connections[i].Strength > bridge.Connections[j].Strength
+		})
+		if len(bridge.Connections) > maxBridgeExamples {
+			bridge.Connections = bridge.Connections[:maxBridgeExamples]
+		}
+		result.Bridges = append(result.Bridges, *bridge)
+	}
+	sort.Slice(result.Bridges, func(i, j int) bool {
+		a, b := result.Bridges[i], result.Bridges[j]
+		if a.TotalStrength != b.TotalStrength {
+			return a.TotalStrength > b.TotalStrength
+		}
+		if a.FromCommunity != b.FromCommunity {
+			return a.FromCommunity < b.FromCommunity
+		}
+		return a.ToCommunity < b.ToCommunity
+	})
+
+	return result
+}
+
+// Louvain detects communities by greedily moving families between communities while
+// strength-weighted modularity improves, then collapsing each community into a single
+// node and repeating. It returns each node's community, numbered from 0 in order of
+// decreasing size, and the number of levels that improved the partition.
+func Louvain(g *Graph, resolution float64) ([]int, int) {
+	n := g.Len()
+	membership := make([]int, n)
+	for node := range membership {
+		membership[node] = node
+	}
+
+	level := newLouvainLevel(g)
+	levels := 0
+	for level.totalWeight > 0 {
+		communities, count, moved := level.moveNodes(resolution)
+		if !moved {
+			break
+		}
+		levels++
+
+		for node := range membership {
+			membership[node] = communities[membership[node]]
+		}
+		if count == len(level.degree) {
+			break
+		}
+		level = level.aggregate(communities, count)
+	}
+
+	return renumberBySize(membership), levels
+}
+
+// Modularity measures how much more strength falls inside communities than expected
+// if connections were placed at random, scaled by resolution
+func Modularity(g *Graph, membership []int, resolution float64) float64 {
+	internal := make(map[int]float64)
+	total := make(map[int]float64)
+	totalWeight := 0.0
+
+	for node := 0; node < g.Len(); node++ {
+		for _, edge := range g.Neighbors(node) {
+			total[membership[node]] += edge.Weight
+			if edge.To > node {
+				totalWeight += edge.Weight
+				if membership[edge.To] == membership[node] {
+					internal[membership[node]] += edge.Weight
+				}
+			}
+		}
+	}
+	if totalWeight == 0 {
+		return 0
+	}
+
+	modularity := 0.0
+	for community, degree := range total {
+		share := degree / (2 * totalWeight)
+		modularity += internal[community]/totalWeight - resolution*share*share
+	}
+	return modularity
+}
+
+// louvainLevel is the graph at one aggregation level. Each node is a community of the level below.
+type louvainLevel struct {
+	adjacency   [][]weightedEdge // Excludes self-loops
+	selfLoops   []float64        // Strength of connections inside each node
+	degree      []float64        // Total strength incident to each node, self-loops counted twice
+	totalWeight float64          // Total strength of all connections
+}
+
+type weightedEdge struct {
+	to     int
+	weight float64
+}
+
+func newLouvainLevel(g *Graph) *louvainLevel {
+	n := g.Len()
+	level := &louvainLevel{
+		adjacency: make([][]weightedEdge, n),
+		selfLoops: make([]float64, n),
+		degree:    make([]float64, n),
+	}
+
+	for node := 0; node < n; node++ {
+		for _, edge := range g.Neighbors(node) {
+			level.adjacency[node] = append(level.adjacency[node], weightedEdge{to: edge.To, weight: edge.Weight})
+			level.degree[node] += edge.Weight
+		}
+		level.totalWeight += level.degree[node]
+	}
+	level.totalWeight /= 2
+
+	return level
+}
+
+// moveNodes runs the local moving phase. It returns each node's community numbered
+// densely from 0, the number of communities and whether any node moved.
+func (l *louvainLevel) moveNodes(resolution float64) ([]int, int, bool) {
+	n := len(l.degree)
+	community := make([]int, n)
+	total := make([]float64, n) // Total degree of each community
+	for node := range community {
+		community[node] = node
+		total[node] = l.degree[node]
+	}
+
+	// Scratch space for the strength from a node to each neighboring community
+	weightTo := make([]float64, n)
+	seen := make([]bool, n)
+	neighbors := make([]int, 0)
+
+	const maxPasses = 100
+	moved := false
+	for pass := 0; pass < maxPasses; pass++ {
+		improved := false
+
+		for node := 0; node < n; node++ {
+			current := community[node]
+
+			neighbors = append(neighbors[:0], current)
+			seen[current] = true
+			for _, edge := range l.adjacency[node] {
+				c := community[edge.to]
+				if !seen[c] {
+					seen[c] = true
+					neighbors = append(neighbors, c)
+				}
+				weightTo[c] += edge.weight
+			}
+
+			// Take the node out, then put it where the modularity gain is largest
+			total[current] -= l.degree[node]
+			scale := resolution * l.degree[node] / (2 * l.totalWeight)
+
+			best := current
+			bestGain := weightTo[current] - total[current]*scale
+			for _, c := range neighbors[1:] {
+				if gain := weightTo[c] - total[c]*scale; gain > bestGain+1e-12 {
+					best, bestGain = c, gain
+				}
+			}
+
+			total[best] += l.degree[node]
+			community[node] = best
+			if best != current {
+				improved = true
+				moved = true
+			}
+
+			for _, c := range neighbors {
+				weightTo[c] = 0
+				seen[c] = false
+			}
+		}
+
+		if !improved {
+			break
+		}
+	}
+
+	// Renumber communities densely
+	dense := make(map[int]int)
+	for node, c := range community {
+		if _, ok := dense[c]; !ok {
+			dense[c] = len(dense)
+		}
+		community[node] = dense[c]
+	}
+
+	return community, len(dense), moved
+}
+
+// aggregate collapses each community into one node of the next level
+func (l *louvainLevel) aggregate(communities []int, count int) *louvainLevel {
+	next := &louvainLevel{
+		adjacency:   make([][]weightedEdge, count),
+		selfLoops:   make([]float64, count),
+		degree:      make([]float64, count),
+		totalWeight: l.totalWeight,
+	}
+
+	weights := make([]map[int]float64, count)
+	for node, c := range communities {
+		next.selfLoops[c] += l.selfLoops[node]
+		next.degree[c] += l.degree[node]
+
+		for _, edge := range l.adjacency[node] {
+			other := communities[edge.to]
+			if other == c {
+				next.selfLoops[c] += edge.weight / 2 // Seen from both ends
+				continue
+			}
+			if weights[c] == nil {
+				weights[c] = make(map[int]float64)
+			}
+			weights[c][other] += edge.weight
+		}
+	}
+
+	for c, neighbors := range weights {
+		for other, weight := range neighbors {
+			next.adjacency[c] = append(next.adjacency[c], weightedEdge{to: other, weight: weight})
+		}
+		sort.Slice(next.adjacency[c], func(i, j int) bool { return next.adjacency[c][i].to < next.adjacency[c][j].to })
+	}
+
+	return next
+}
+
+// renumberBySize renumbers communities from 0 in order of decreasing size,
+// breaking ties by the lowest node in the community
+func renumberBySize(membership []int) []int {
+	sizes := make(map[int]int)
+	first := make(map[int]int)
+	for node, c := range membership {
+		if _, ok := first[c]; !ok {
+			first[c] = node
+		}
+		sizes[c]++
+	}
+
+	order := make([]int, 0, len(sizes))
+	for c := range sizes {
+		order = append(order, c)
+	}
+	sort.Slice(order, func(i, j int) bool {
+		if sizes[order[i]] != sizes[order[j]] {
+			return sizes[order[i]] > sizes[order[j]]
+		}
+		return first[order[i]] < first[order[j]]
+	})
+
+	rank := make(map[int]int, len(order))
+	for i, c := range order {
+		rank[c] = i
+	}
+
+	renumbered := make([]int, len(membership))
+	for node, c := range membership {
+		renumbered[node] = rank[c]
+	}
+	return renumbered
+}
@@ -0,0 +1,117 @@
+package analytics_test
+
+import (
+	"families-linkedin/internal/analytics"
+	"families-linkedin/internal/models"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// families renders nodes as their sorted family IDs, e.g. "A B C"
+func families(g *analytics.Graph, nodes []int) string {
+	ids := make([]string, len(nodes))
+	for i, node := range nodes {
+		ids[i] = g.ID(node)
+	}
+	sort.Strings(ids)
+	return strings.Join(ids, " ")
+}
+
+// pair renders a connection as its two family IDs in order, e.g. "A-B"
+func pair(connection *models.FamilyConnection) string {
+	ids := []string{connection.FromFamilyID, connection.ToFamilyID}
+	sort.Strings(ids)
+	return ids[0] + "-" + ids[1]
+}
+
+func TestLouvain(t *testing.T) {
+	tests := []struct {
+		name          string
+		connections   []*models.FamilyConnection
+		isolated      []string
+		want          []string // Families of each community, sorted
+		wantBridges   []string
+		minModularity float64
+	}{
+		{
+			name: "two cliques joined by a weak connection",
+			connections: []*models.FamilyConnection{
+				link("A", "B", 0.9), link("B", "C", 0.9), link("A", "C", 0.9),
+				link("D", "E", 0.9), link("E", "F", 0.9), link("D", "F", 0.9),
+				link("C", "D", 0.1),
+			},
+			want:          []string{"A B C", "D E F"},
+			wantBridges:   []string{"C-D"},
+			minModularity: 0.4,
+		},
+		{
+			name:          "separate components",
+			connections:   []*models.FamilyConnection{link("A", "B", 0.5), link("C", "D", 0.5)},
+			want:          []string{"A B", "C D"},
+			wantBridges:   []string{},
+			minModularity: 0.4,
+		},
+		{
+			name:        "family without connections is its own community",
+			connections: []*models.FamilyConnection{link("A", "B", 0.5)},
+			isolated:    []string{"Z"},
+			want:        []string{"A B", "Z"},
+			wantBridges: []string{},
+		},
+		{
+			name:        "no families",
+			want:        []string{},
+			wantBridges: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			familyIDs := append([]string{}, tt.isolated...)
+			for _, connection := range tt.connections {
+				familyIDs = append(familyIDs, connection.FromFamilyID, connection.ToFamilyID)
+			}
+			g := analytics.NewFamilyGraph(familyIDs, tt.connections)
+
+			result := analytics.ComputeCommunities(g, 1.0)
+
+			members := make(map[int][]int)
+			for node := 0; node < g.Len(); node++ {
+				community := result.Assignments[g.ID(node)]
+				if community < 1 {
+					t.Fatalf("%s assigned community %d, want IDs from 1", g.ID(node), community)
+				}
+				members[community] = append(members[community], node)
+			}
+			got := []string{}
+			for _, nodes := range members {
+				got = append(got, families(g, nodes))
+			}
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("communities = %v, want %v", got, tt.want)
+			}
+
+			bridges := []string{}
+			for _, bridge := range result.Bridges {
+				for _, connection := range bridge.Connections {
+					bridges = append(bridges, pair(connection))
+				}
+			}
+			if !reflect.DeepEqual(bridges, tt.wantBridges) {
+				t.Errorf("bridges = %v, want %v", bridges, tt.wantBridges)
+			}
+
+			if result.Modularity < tt.minModularity {
+				t.Errorf("modularity = %.3f, want at least %.3f", result.Modularity, tt.minModularity)
+			}
+			for i, stats := range result.Communities {
+				if i > 0 && stats.Members > result.Communities[i-1].Members {
+					t.Errorf("communities not numbered by decreasing size: %+v", result.Communities)
+				}
+			}
+		})
+	}
+}
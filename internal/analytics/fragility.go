@@ -0,0 +1,196 @@
+package analytics
+
+import (
+	"families-linkedin/internal/models"
+	"sort"
+)
+
+// FragilityResult describes how the family graph falls apart into components and which
+// families and connections hold each component together
+type FragilityResult struct {
+	Components         [][]int             // Nodes of each connected component, largest first
+	ArticulationPoints []ArticulationPoint // Most stranding first
+	Bridges            []Bridge            // Most stranding first
+}
+
+// ArticulationPoint is a family whose removal splits its component
+type ArticulationPoint struct {
+	Node      int
+	Component int // Index into FragilityResult.Components
+	Pieces    int // Components left behind once the family is removed
+	// Stranded counts the families that would no longer reach the largest remaining piece
+	Stranded int
+}
+
+// Bridge is a connection whose removal splits its component
+type Bridge struct {
+	Connection *models.FamilyConnection
+	Component  int // Index into FragilityResult.Components
+	Stranded   int // Families on the smaller side
+}
+
+// AnalyzeFragility finds connected components, articulation points and bridges with
+// Tarjan's low-link depth-first search
+func AnalyzeFragility(g *Graph) *FragilityResult {
+	n := g.Len()
+	result := &FragilityResult{}
+
+	discovery := make([]int, n) // DFS discovery order, starting at 1; zero means unvisited
+	low := make([]int, n)       // Earliest discovery reachable from the node's subtree through one back edge
+	size := make([]int, n)      // Nodes in the DFS subtree
+
+	type frame struct {
+		node, parent, next int
+	}
+	var stack []frame
+	clock := 0
+	// Children whose subtree is cut off by removing a node, per node of the current component
+	cut := make(map[int][]int)
+
+	for root := 0; root < n; root++ {
+		if discovery[root] != 0 {
+			continue
+		}
+
+		componentIndex := len(result.Components)
+		firstBridge := len(result.Bridges)
+		var members []int
+		clear(cut)
+
+		clock++
+		discovery[root], low[root], size[root] = clock, clock, 1
+		stack = append(stack[:0], frame{node: root, parent: -1})
+
+		for len(stack) > 0 {
+			top := &stack[len(stack)-1]
+			node := top.node
+			edges := g.Neighbors(node)
+
+			if top.next < len(edges) {
+				neighbor := edges[top.next].To
+				top.next++
+
+				if neighbor == top.parent {
+					continue // The graph has no parallel edges, so this is the tree edge
+				}
+				if discovery[neighbor] != 0 {
+					low[node] = min(low[node], discovery[neighbor])
+					continue
+				}
+
+				clock++
+				discovery[neighbor], low[neighbor], size[neighbor] = clock, clock, 1
+				stack = append(stack, frame{node: neighbor, parent: node})
+				continue
+			}
+
+			// All neighbors visited; report the finished subtree to the parent
+			parent := top.parent
+			stack = stack[:len(stack)-1]
+			members = append(members, node)
+
+			if parent < 0 {
+				continue
+			}
+			size[parent] += size[node]
+			low[parent] = min(low[parent], low[node])
+
+			if low[node] >= discovery[parent] {
+				cut[parent] = append(cut[parent], node)
+			}
+			if low[node] > discovery[parent] {
+				connection := edgeBetween(g, parent, node)
+				result.Bridges = append(result.Bridges, Bridge{
+					Connection: connection,
+					Component:  componentIndex,
+					Stranded:   size[node], // Fixed up below once the component size is known
+				})
+			}
+		}
+
+		total := len(members)
+		for node, children := range cut {
+			// The root only separates anything with two or more DFS children
+			if node == root && len(children) < 2 {
+				continue
+			}
+
+			pieces := make([]int, 0, len(children)+1)
+			remaining := total - 1
+			for _, child := range children {
+				pieces = append(pieces, size[child])
+				remaining -= size[child]
+			}
+			if remaining > 0 {
+				pieces = append(pieces, remaining)
+			}
+
+			largest := 0
+			for _, piece := range pieces {
+				largest = max(largest, piece)
+			}
+			result.ArticulationPoints = append(result.ArticulationPoints, ArticulationPoint{
+				Node:      node,
+				Component: componentIndex,
+				Pieces:    len(pieces),
+				Stranded:  total - 1 - largest,
+			})
+		}
+		for i := firstBridge; i < len(result.Bridges); i++ {
+			bridge := &result.Bridges[i]
+			bridge.Stranded = min(bridge.Stranded, total-bridge.Stranded)
+		}
+
+		sort.Ints(members)
+		result.Components = append(result.Components, members)
+	}
+
+	// Largest components first, keeping indexes in step
+	order := make([]int, len(result.Components))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return len(result.Components[order[i]]) > len(result.Components[order[j]])
+	})
+	rank := make([]int, len(order))
+	components := make([][]int, len(order))
+	for i, c := range order {
+		rank[c] = i
+		components[i] = result.Components[c]
+	}
+	result.Components = components
+	for i := range result.ArticulationPoints {
+		result.ArticulationPoints[i].Component = rank[result.ArticulationPoints[i].Component]
+	}
+	for i := range result.Bridges {
+		result.Bridges[i].Component = rank[result.Bridges[i].Component]
+	}
+
+	sort.Slice(result.ArticulationPoints, func(i, j int) bool {
+		a, b := result.ArticulationPoints[i], result.ArticulationPoints[j]
+		if a.Stranded != b.Stranded {
+			return a.Stranded > b.Stranded
+		}
+		return a.Node < b.Node
+	})
+	sort.Slice(result.Bridges, func(i, j int) bool {
+		a, b := result.Bridges[i], result.Bridges[j]
+		if a.Stranded != b.Stranded {
+			return a.Stranded > b.Stranded
+		}
+		if a.Connection.FromFamilyID != b.Connection.FromFamilyID {
+			return a.Connection.FromFamilyID < b.Connection.FromFamilyID
+		}
+		return a.Connection.ToFamilyID < b.Connection.ToFamilyID
+	})
+
+	return result
+}
+
+// edgeBetween returns the connection between two adjacent nodes
+func edgeBetween(g *Graph, from, to int) *models.FamilyConnection {
+	edges := g.Neighbors(from)
+	i := sort.Search(len(edges), func(i int) bool { return edges[i].To >= to })
+	return edges[i].Connection
+}
@@ -0,0 +1,91 @@
+package analytics_test
+
+import (
+	"families-linkedin/internal/analytics"
+	"families-linkedin/internal/models"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestAnalyzeFragility(t *testing.T) {
+	tests := []struct {
+		name               string
+		connections        []*models.FamilyConnection
+		wantComponents     []string
+		wantArticulation   []string // Family, pieces and stranded families, most stranding first
+		wantBridges        []string // Most stranding first
+		wantBridgeStranded []int
+	}{
+		{
+			name:               "chain",
+			connections:        []*models.FamilyConnection{link("A", "B", 0.5), link("B", "C", 0.5), link("C", "D", 0.5)},
+			wantComponents:     []string{"A B C D"},
+			wantArticulation:   []string{"B/2/1", "C/2/1"},
+			wantBridges:        []string{"B-C", "A-B", "C-D"},
+			wantBridgeStranded: []int{2, 1, 1},
+		},
+		{
+			name:               "cycle",
+			connections:        []*models.FamilyConnection{link("A", "B", 0.5), link("B", "C", 0.5), link("C", "A", 0.5)},
+			wantComponents:     []string{"A B C"},
+			wantArticulation:   []string{},
+			wantBridges:        []string{},
+			wantBridgeStranded: []int{},
+		},
+		{
+			name: "bow tie",
+			connections: []*models.FamilyConnection{
+				link("A", "B", 0.5), link("B", "C", 0.5), link("C", "A", 0.5),
+				link("C", "D", 0.5), link("D", "E", 0.5), link("E", "C", 0.5),
+			},
+			wantComponents:     []string{"A B C D E"},
+			wantArticulation:   []string{"C/2/2"},
+			wantBridges:        []string{},
+			wantBridgeStranded: []int{},
+		},
+		{
+			name: "star and a separate pair",
+			connections: []*models.FamilyConnection{
+				link("H", "A", 0.5), link("H", "B", 0.5), link("H", "C", 0.5),
+				link("X", "Y", 0.5),
+			},
+			wantComponents:     []string{"A B C H", "X Y"},
+			wantArticulation:   []string{"H/3/2"},
+			wantBridges:        []string{"A-H", "B-H", "C-H", "X-Y"},
+			wantBridgeStranded: []int{1, 1, 1, 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := analytics.NewGraph(tt.connections)
+			result := analytics.AnalyzeFragility(g)
+
+			components := []string{}
+			for _, nodes := range result.Components {
+				components = append(components, families(g, nodes))
+			}
+			if !reflect.DeepEqual(components, tt.wantComponents) {
+				t.Errorf("components = %v, want %v", components, tt.wantComponents)
+			}
+
+			articulation := []string{}
+			for _, point := range result.ArticulationPoints {
+				articulation = append(articulation, fmt.Sprintf("%s/%d/%d", g.ID(point.Node), point.Pieces, point.Stranded))
+			}
+			if !reflect.DeepEqual(articulation, tt.wantArticulation) {
+				t.Errorf("articulation points = %v, want %v", articulation, tt.wantArticulation)
+			}
+
+			bridges, stranded := []string{}, []int{}
+			for _, bridge := range result.Bridges {
+				bridges = append(bridges, pair(bridge.Connection))
+				stranded = append(stranded, bridge.Stranded)
+			}
+			if !reflect.DeepEqual(bridges, tt.wantBridges) || !reflect.DeepEqual(stranded, tt.wantBridgeStranded) {
+				t.Errorf("bridges = %v stranding %v, want %v stranding %v", bridges, stranded, tt.wantBridges, tt.wantBridgeStranded)
+			}
+		})
+	}
+}
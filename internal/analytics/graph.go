@@ -0,0 +1,110 @@
+// Package analytics computes whole-graph measures over the FAMILY_RELATION graph.
+// Unlike internal/algorithms, which answers per-request path queries, everything here
+// runs as a batch over the full edge list.
+package analytics
+
+import (
+	"families-linkedin/internal/models"
+	"sort"
+)
+
+// Graph is an undirected, weighted view of the family graph indexed by dense integers.
+// Families are numbered in ID order so results are deterministic.
+type Graph struct {
+	ids       []string
+	index     map[string]int
+	adjacency [][]Edge
+	edges     int
+}
+
+// Edge is one side of an undirected connection
+type Edge struct {
+	To         int
+	Weight     float64 // Connection strength
+	Connection *models.FamilyConnection
+}
+
+// NewGraph builds a graph from a list of connections. The store keeps each connection as
+// two directed relationships, so duplicates between the same pair of families are dropped.
+func NewGraph(connections []*models.FamilyConnection) *Graph {
+	familyIDs := make([]string, 0, 2*len(connections))
+	for _, connection := range connections {
+		familyIDs = append(familyIDs, connection.FromFamilyID, connection.ToFamilyID)
+	}
+	return NewFamilyGraph(familyIDs, connections)
+}
+
+// NewFamilyGraph builds a graph over the given families, including those without any
+// connections. Connections to families outside the list are dropped.
+func NewFamilyGraph(familyIDs []string, connections []*models.FamilyConnection) *Graph {
+	g := &Graph{index: make(map[string]int, len(familyIDs))}
+
+	for _, familyID := range familyIDs {
+		g.index[familyID] = 0
+	}
+	g.ids = make([]string, 0, len(g.index))
+	for familyID := range g.index {
+		g.ids = append(g.ids, familyID)
+	}
+	sort.Strings(g.ids)
+	for i, familyID := range g.ids {
+		g.index[familyID] = i
+	}
+
+	g.adjacency = make([][]Edge, len(g.ids))
+	seen := make(map[[2]int]bool, len(connections))
+	for _, connection := range connections {
+		from, fromOK := g.index[connection.FromFamilyID]
+		to, toOK := g.index[connection.ToFamilyID]
+		if !fromOK || !toOK || from == to {
+			continue
+		}
+
+		pair := [2]int{min(from, to), max(from, to)}
+		if seen[pair] {
+			continue
+		}
+		seen[pair] = true
+
+		g.adjacency[from] = append(g.adjacency[from], Edge{To: to, Weight: connection.Strength, Connection: connection})
+		g.adjacency[to] = append(g.adjacency[to], Edge{To: from, Weight: connection.Strength, Connection: connection})
+		g.edges++
+	}
+
+	for _, edges := range g.adjacency {
+		sort.Slice(edges, func(i, j int) bool { return edges[i].To < edges[j].To })
+	}
+
+	return g
+}
+
+// Len returns the number of families in the graph
+func (g *Graph) Len() int {
+	return len(g.ids)
+}
+
+// EdgeCount returns the number of undirected connections in the graph
+func (g *Graph) EdgeCount() int {
+	return g.edges
+}
+
+// ID returns the family ID of a node
+func (g *Graph) ID(node int) string {
+	return g.ids[node]
+}
+
+// Index returns the node of a family
+func (g *Graph) Index(familyID string) (int, bool) {
+	node, ok := g.index[familyID]
+	return node, ok
+}
+
+// Neighbors returns the edges of a node, ordered by neighbor
+func (g *Graph) Neighbors(node int) []Edge {
+	return g.adjacency[node]
+}
+
+// Degree returns the number of connections of a node
+func (g *Graph) Degree(node int) int {
+	return len(g.adjacency[node])
+}
@@ -0,0 +1,167 @@
+package analytics
+
+import (
+	"math"
+	"sort"
+)
+
+// unknownDistance marks a family a landmark does not reach, or only beyond the
+// farthest hop count a byte can hold
+const unknownDistance = math.MaxUint8
+
+// DistanceOracle bounds the degree of separation between any two families from BFS
+// distances to a fixed set of landmark families, in the style of ALT. By the triangle
+// inequality, for every landmark L the hops between u and v are at least
+// |d(u,L) - d(v,L)| and at most d(u,L) + d(L,v). High-degree landmarks sit on many
+// shortest paths, so the bounds are often equal and the degree is known without a search.
+type DistanceOracle struct {
+	ids        []string
+	index      map[string]int
+	components []int   // Connected component of every node
+	landmarks  []int   // Landmark nodes, highest degree first
+	distances  []uint8 // distances[node*len(landmarks)+i] is the hop count between node and landmark i
+}
+
+// DistanceBounds bound the number of hops between two families
+type DistanceBounds struct {
+	Lower       int  // At least this many hops
+	Upper       int  // At most this many hops; -1 when no landmark reaches both families
+	Unreachable bool // No path joins the families
+}
+
+// Exact reports whether the bounds pin down the distance
+func (b DistanceBounds) Exact() bool {
+	return b.Unreachable || b.Lower == b.Upper
+}
+
+// NewDistanceOracle picks the count families of highest degree as landmarks and runs a
+// BFS from each. It keeps one byte per family and landmark.
+func NewDistanceOracle(g *Graph, count int) *DistanceOracle {
+	n := g.Len()
+	oracle := &DistanceOracle{
+		ids:        make([]string, n),
+		index:      make(map[string]int, n),
+		components: make([]int, n),
+	}
+	for node := 0; node < n; node++ {
+		oracle.ids[node] = g.ID(node)
+		oracle.index[g.ID(node)] = node
+		oracle.components[node] = -1
+	}
+
+	// Label components so families in different ones are known to be unreachable
+	queue := make([]int, 0, n)
+	component := 0
+	for root := 0; root < n; root++ {
+		if oracle.components[root] >= 0 {
+			continue
+		}
+		oracle.components[root] = component
+		queue = append(queue[:0], root)
+		for head := 0; head < len(queue); head++ {
+			for _, edge := range g.Neighbors(queue[head]) {
+				if oracle.components[edge.To] < 0 {
+					oracle.components[edge.To] = component
+					queue = append(queue, edge.To)
+				}
+			}
+		}
+		component++
+	}
+
+	// Highest degree first, ties broken by node order so landmarks are deterministic
+	candidates := make([]int, 0, n)
+	for node := 0; node < n; node++ {
+		if g.Degree(node) > 0 {
+			candidates = append(candidates, node)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return g.Degree(candidates[i]) > g.Degree(candidates[j])
+	})
+	oracle.landmarks = candidates[:min(max(count, 0), len(candidates))]
+
+	k := len(oracle.landmarks)
+	oracle.distances = make([]uint8, n*k)
+	for i := range oracle.distances {
+		oracle.distances[i] = unknownDistance
+	}
+
+	for i, landmark := range oracle.landmarks {
+		oracle.distances[landmark*k+i] = 0
+		queue = append(queue[:0], landmark)
+
+		for head := 0; head < len(queue); head++ {
+			node := queue[head]
+			distance := oracle.distances[node*k+i]
+			if distance == unknownDistance-1 {
+				continue // Farther families do not fit in a byte
+			}
+			for _, edge := range g.Neighbors(node) {
+				if oracle.distances[edge.To*k+i] == unknownDistance {
+					oracle.distances[edge.To*k+i] = distance + 1
+					queue = append(queue, edge.To)
+				}
+			}
+		}
+	}
+
+	return oracle
+}
+
+// Bounds returns the bounds on the hops between two families. A family missing from the
+// oracle's graph has no connections, so it reaches no other family. Without a landmark in
+// their component, connected families only get the trivial lower bound of one hop.
+func (o *DistanceOracle) Bounds(fromID, toID string) DistanceBounds {
+	if fromID == toID {
+		return DistanceBounds{Lower: 0, Upper: 0}
+	}
+
+	from, fromOK := o.index[fromID]
+	to, toOK := o.index[toID]
+	if !fromOK || !toOK || o.components[from] != o.components[to] {
+		return DistanceBounds{Unreachable: true, Upper: -1}
+	}
+
+	k := len(o.landmarks)
+	fromDistances := o.distances[from*k : (from+1)*k]
+	toDistances := o.distances[to*k : (to+1)*k]
+
+	bounds := DistanceBounds{Lower: 1, Upper: -1}
+	for i := 0; i < k; i++ {
+		fromDistance, toDistance := int(fromDistances[i]), int(toDistances[i])
+		if fromDistance == unknownDistance || toDistance == unknownDistance {
+			continue // The landmark lies in another component, or too far away
+		}
+
+		if upper := fromDistance + toDistance; bounds.Upper < 0 || upper < bounds.Upper {
+			bounds.Upper = upper
+		}
+		if lower := abs(fromDistance - toDistance); lower > bounds.Lower {
+			bounds.Lower = lower
+		}
+	}
+
+	return bounds
+}
+
+// Families returns the number of families the oracle covers
+func (o *DistanceOracle) Families() int {
+	return len(o.ids)
+}
+
+// Landmarks returns the landmark family IDs, highest degree first
+func (o *DistanceOracle) Landmarks() []string {
+	landmarks := make([]string, len(o.landmarks))
+	for i, node := range o.landmarks {
+		landmarks[i] = o.ids[node]
+	}
+	return landmarks
+}
+
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
@@ -0,0 +1,47 @@
+package analytics_test
+
+import (
+	"families-linkedin/internal/analytics"
+	"families-linkedin/internal/models"
+	"reflect"
+	"testing"
+)
+
+func TestDistanceOracleBounds(t *testing.T) {
+	// The hub H joins A, B, C and D; E hangs off D; X-Y is a separate component
+	g := analytics.NewGraph([]*models.FamilyConnection{
+		link("H", "A", 0.5), link("H", "B", 0.5), link("H", "C", 0.5), link("H", "D", 0.5),
+		link("D", "E", 0.5), link("X", "Y", 0.5),
+	})
+	oracle := analytics.NewDistanceOracle(g, 2)
+
+	if got, want := oracle.Landmarks(), []string{"H", "D"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("landmarks = %v, want %v", got, want)
+	}
+
+	tests := []struct {
+		name     string
+		from, to string
+		want     analytics.DistanceBounds
+	}{
+		{"same family", "A", "A", analytics.DistanceBounds{Lower: 0, Upper: 0}},
+		{"next to a landmark", "A", "H", analytics.DistanceBounds{Lower: 1, Upper: 1}},
+		{"through a landmark", "A", "E", analytics.DistanceBounds{Lower: 1, Upper: 3}},
+		{"beside the second landmark", "H", "E", analytics.DistanceBounds{Lower: 2, Upper: 2}},
+		{"siblings of the hub", "A", "B", analytics.DistanceBounds{Lower: 1, Upper: 2}},
+		{"component without landmarks", "X", "Y", analytics.DistanceBounds{Lower: 1, Upper: -1}},
+		{"separate components", "A", "X", analytics.DistanceBounds{Upper: -1, Unreachable: true}},
+		{"unknown family", "A", "Q", analytics.DistanceBounds{Upper: -1, Unreachable: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := oracle.Bounds(tt.from, tt.to); got != tt.want {
+				t.Errorf("Bounds(%s, %s) = %+v, want %+v", tt.from, tt.to, got, tt.want)
+			}
+			if reversed := oracle.Bounds(tt.to, tt.from); reversed != tt.want {
+				t.Errorf("Bounds(%s, %s) = %+v, want the same both ways", tt.to, tt.from, reversed)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package analytics
+
+import (
+	"math"
+)
+
+// MaxTrustScore is the top of the trust score scale enforced by models.Family.UpdateTrustScore
+const MaxTrustScore = 10.0
+
+// TrustOptions tunes the propagated trust computation
+type TrustOptions struct {
+	// Restart is the share of trust returned to the seed families at every step. It keeps
+	// trust anchored to the seeds and stops cliques from inflating each other.
+	Restart       float64
+	Tolerance     float64 // Iteration stops once the L1 change falls below this
+	MaxIterations int
+}
+
+// DefaultTrustOptions returns the options used by the batch job
+func DefaultTrustOptions() TrustOptions {
+	return TrustOptions{
+		Restart:       0.15,
+		Tolerance:     1e-9,
+		MaxIterations: 200,
+	}
+}
+
+// TrustResult holds the propagated trust of every family in the graph
+type TrustResult struct {
+	Scores     map[string]float64 // 0 to MaxTrustScore
+	Families   int
+	Seeds      int
+	Iterations int
+}
+
+// ComputeTrust propagates trust from the seed families and scales it to 0-MaxTrustScore.
+// Seeds missing from the graph are ignored; with no seeds every family scores 0.
+func ComputeTrust(g *Graph, seedIDs []string, options TrustOptions) *TrustResult {
+	var seeds []int
+	for _, seedID := range seedIDs {
+		if node, ok := g.Index(seedID); ok {
+			seeds = append(seeds, node)
+		}
+	}
+
+	trust, iterations := EigenTrust(g, seeds, options)
+	scores := NormalizeTrust(trust)
+
+	result := &TrustResult{
+		Scores:     make(map[string]float64, g.Len()),
+		Families:   g.Len(),
+		Seeds:      len(seeds),
+		Iterations: iterations,
+	}
+	for node := 0; node < g.Len(); node++ {
+		result.Scores[g.ID(node)] = scores[node]
+	}
+	return result
+}
+
+// EigenTrust computes global trust in the style of EigenTrust. Each family splits the
+// trust it holds among its verified connections in proportion to strength; unverified
+// connections carry none. Every step a Restart share of all trust goes back to the seeds,
+// as does the trust of families without verified connections. Trust sums to 1 and is
+// zero for families no verified path from a seed reaches. It returns the trust and the
+// number of iterations run.
+func EigenTrust(g *Graph, seeds []int, options TrustOptions) ([]float64, int) {
+	n := g.Len()
+	trust := make([]float64, n)
+	if n == 0 || len(seeds) == 0 {
+		return trust, 0
+	}
+
+	// Pre-trusted distribution, uniform over the seeds
+	pretrust := make([]float64, n)
+	for _, seed := range seeds {
+		pretrust[seed] = 1
+	}
+	for node := range pretrust {
+		pretrust[node] /= float64(len(seeds))
+	}
+	copy(trust, pretrust)
+
+	// Total verified strength per family
+	strength := make([]float64, n)
+	for node := 0; node < n; node++ {
+		for _, edge := range g.Neighbors(node) {
+			if edge.Connection.Verified {
+				strength[node] += edge.Weight
+			}
+		}
+	}
+
+	next := make([]float64, n)
+	iterations := 0
+	for iterations < options.MaxIterations {
+		iterations++
+
+		// Trust that has nowhere to flow returns to the seeds
+		returned := options.Restart
+		for node := 0; node < n; node++ {
+			next[node] = 0
+			if strength[node] == 0 {
+				returned += (1 - options.Restart) * trust[node]
+			}
+		}
+
+		for node := 0; node < n; node++ {
+			if strength[node] == 0 || trust[node] == 0 {
+				continue
+			}
+			share := (1 - options.Restart) * trust[node] / strength[node]
+			for _, edge := range g.Neighbors(node) {
+				if edge.Connection.Verified {
+					next[edge.To] += share * edge.Weight
+				}
+			}
+		}
+
+		change := 0.0
+		for node := range next {
+			next[node] += returned * pretrust[node]
+			change += math.Abs(next[node] - trust[node])
+		}
+		trust, next = next, trust
+
+		if change < options.Tolerance {
+			break
+		}
+	}
+
+	return trust, iterations
+}
+
+// NormalizeTrust maps trust onto 0-MaxTrustScore. Trust falls off geometrically with
+// distance from the seeds, so the scale is logarithmic in trust relative to an even share:
+// the most trusted family scores MaxTrustScore and untrusted families score 0.
+// Scores are rounded to two decimals.
+func NormalizeTrust(trust []float64) []float64 {
+	scores := make([]float64, len(trust))
+	n := float64(len(trust))
+
+	highest := 0.0
+	for _, value := range trust {
+		highest = max(highest, value)
+	}
+	if highest == 0 {
+		return scores
+	}
+
+	top := math.Log1p(highest * n)
+	for node, value := range trust {
+		score := MaxTrustScore * math.Log1p(value*n) / top
+		scores[node] = math.Round(score*100) / 100
+	}
+	return scores
+}
@@ -0,0 +1,102 @@
+package analytics_test
+
+import (
+	"families-linkedin/internal/analytics"
+	"families-linkedin/internal/models"
+	"math"
+	"testing"
+)
+
+func TestComputeTrust(t *testing.T) {
+	tests := []struct {
+		name        string
+		connections []*models.FamilyConnection
+		seeds       []string
+		descending  []string // Families in strictly decreasing order of trust
+		untrusted   []string // Families scoring 0
+	}{
+		{
+			name:        "trust falls off with distance from the seed",
+			connections: []*models.FamilyConnection{link("S", "A", 0.9), link("A", "B", 0.9), link("B", "C", 0.9)},
+			seeds:       []string{"S"},
+			descending:  []string{"A", "B", "C"},
+		},
+		{
+			name:        "stronger connections carry more trust",
+			connections: []*models.FamilyConnection{link("S", "Strong", 0.9), link("S", "Weak", 0.1)},
+			seeds:       []string{"S"},
+			descending:  []string{"Strong", "Weak"},
+		},
+		{
+			name: "unreached and unverified families hold none",
+			connections: []*models.FamilyConnection{
+				link("S", "A", 0.9), link("X", "Y", 0.9),
+				models.NewFamilyConnection("A", "U", "RELATIVE", "COUSIN", 0.9, false),
+			},
+			seeds:     []string{"S"},
+			untrusted: []string{"X", "Y", "U"},
+		},
+		{
+			name:        "a clique without seeds cannot vouch for itself",
+			connections: []*models.FamilyConnection{link("S", "A", 0.9), link("X", "Y", 0.9), link("Y", "Z", 0.9), link("Z", "X", 0.9)},
+			seeds:       []string{"S"},
+			untrusted:   []string{"X", "Y", "Z"},
+		},
+		{
+			name:        "no seeds",
+			connections: []*models.FamilyConnection{link("A", "B", 0.9)},
+			untrusted:   []string{"A", "B"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := analytics.NewGraph(tt.connections)
+
+			result := analytics.ComputeTrust(g, tt.seeds, analytics.DefaultTrustOptions())
+			if result.Seeds != len(tt.seeds) {
+				t.Errorf("seeds = %d, want %d", result.Seeds, len(tt.seeds))
+			}
+
+			for i := 1; i < len(tt.descending); i++ {
+				higher, lower := tt.descending[i-1], tt.descending[i]
+				if result.Scores[higher] <= result.Scores[lower] {
+					t.Errorf("trust of %s (%.2f) not above %s (%.2f)", higher, result.Scores[higher], lower, result.Scores[lower])
+				}
+			}
+			for _, familyID := range tt.untrusted {
+				if score := result.Scores[familyID]; score != 0 {
+					t.Errorf("trust of %s = %.2f, want 0", familyID, score)
+				}
+			}
+			highest := 0.0
+			for familyID, score := range result.Scores {
+				if score < 0 || score > analytics.MaxTrustScore {
+					t.Errorf("trust of %s = %.2f, outside 0-%.0f", familyID, score, analytics.MaxTrustScore)
+				}
+				highest = max(highest, score)
+			}
+			if len(tt.seeds) > 0 && highest != analytics.MaxTrustScore {
+				t.Errorf("highest trust = %.2f, want the top of the scale", highest)
+			}
+		})
+	}
+}
+
+func TestEigenTrustConserves(t *testing.T) {
+	g := analytics.NewGraph([]*models.FamilyConnection{link("S", "A", 0.9), link("A", "B", 0.5), link("B", "S", 0.2), link("B", "C", 0.7)})
+	seed, _ := g.Index("S")
+
+	trust, iterations := analytics.EigenTrust(g, []int{seed}, analytics.DefaultTrustOptions())
+	if iterations >= analytics.DefaultTrustOptions().MaxIterations {
+		t.Errorf("EigenTrust did not converge in %d iterations", iterations)
+	}
+
+	total := 0.0
+	for _, value := range trust {
+		total += value
+	}
+	if math.Abs(total-1) > 1e-6 {
+		t.Errorf("total trust = %.6f, want 1", total)
+	}
+}
@@ -1,10 +1,13 @@
 package api
 
 import (
+	"families-linkedin/internal/algorithms"
 	"families-linkedin/internal/models"
 	"families-linkedin/internal/service"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
@@ -19,7 +22,9 @@ func NewConnectionHandler(connectionService *service.ConnectionService) *Connect
 	}
 }
 
-// FindConnectionPath finds the shortest path between two families
+// FindConnectionPath finds a path between two families.
+// mode=shortest (default) minimizes hops, mode=strongest maximizes connection strength.
+// relation_types, min_strength, verified_only, exclude and via constrain the route.
 func (h *ConnectionHandler) FindConnectionPath(c *gin.Context) {
 	fromFamilyID := c.Query("from")
 	toFamilyID := c.Query("to")
@@ -36,7 +41,19 @@ func (h *ConnectionHandler) FindConnectionPath(c *gin.Context) {
 		}
 	}
 
-	path, err := h.connectionService.FindConnectionPath(c.Request.Context(), fromFamilyID, toFamilyID, maxDepth)
+	mode := c.DefaultQuery("mode", algorithms.PathModeShortest)
+	if !algorithms.IsValidPathMode(mode) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid 'mode', expected 'shortest' or 'strongest'"})
+		return
+	}
+
+	constraints, err := parsePathConstraints(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	path, err := h.connectionService.FindConnectionPath(c.Request.Context(), fromFamilyID, toFamilyID, maxDepth, mode, constraints)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -44,20 +61,26 @@ func (h *ConnectionHandler) FindConnectionPath(c *gin.Context) {
 
 	if path == nil {
 		c.JSON(http.StatusNotFound, gin.H{
-			"message": "No connection path found",
-			"from":    fromFamilyID,
-			"to":      toFamilyID,
+			"message":     "No connection path found",
+			"from":        fromFamilyID,
+			"to":          toFamilyID,
+			"mode":        mode,
+			"constraints": constraints,
 		})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"path":    path,
-		"message": "Connection path found successfully",
+		"path":        path,
+		"mode":        mode,
+		"constraints": constraints,
+		"message":     "Connection path found successfully",
 	})
 }
 
-// FindMultipleConnectionPaths finds multiple paths between two families
+// FindMultipleConnectionPaths finds ranked, distinct paths between two families.
+// rank=hops (default) orders by fewest hops, rank=strength by connection strength.
+// Accepts the same route constraints as FindConnectionPath.
 func (h *ConnectionHandler) FindMultipleConnectionPaths(c *gin.Context) {
 	fromFamilyID := c.Query("from")
 	toFamilyID := c.Query("to")
@@ -81,19 +104,33 @@ func (h *ConnectionHandler) FindMultipleConnectionPaths(c *gin.Context) {
 		}
 	}
 
-	paths, err := h.connectionService.FindMultipleConnectionPaths(c.Request.Context(), fromFamilyID, toFamilyID, maxDepth, maxPaths)
+	rankBy := c.DefaultQuery("rank", algorithms.RankByHops)
+	if !algorithms.IsValidRanking(rankBy) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid 'rank', expected 'hops' or 'strength'"})
+		return
+	}
+
+	constraints, err := parsePathConstraints(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	paths, err := h.connectionService.FindMultipleConnectionPaths(c.Request.Context(), fromFamilyID, toFamilyID, maxDepth, maxPaths, rankBy, constraints)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"paths":     paths,
-		"count":     len(paths),
-		"from":      fromFamilyID,
-		"to":        toFamilyID,
-		"max_depth": maxDepth,
-		"message":   "Multiple connection paths found successfully",
+		"paths":       paths,
+		"count":       len(paths),
+		"from":        fromFamilyID,
+		"to":          toFamilyID,
+		"max_depth":   maxDepth,
+		"rank":        rankBy,
+		"constraints": constraints,
+		"message":     "Multiple connection paths found successfully",
 	})
 }
 
@@ -130,6 +167,108 @@ func (h *ConnectionHandler) FindCommonConnections(c *gin.Context) {
 	})
 }
 
+// GetMutualConnections lists, a page at a time, the families directly connected to both families
+func (h *ConnectionHandler) GetMutualConnections(c *gin.Context) {
+	family1ID := c.Query("family1")
+	family2ID := c.Query("family2")
+
+	if family1ID == "" || family2ID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Both 'family1' and 'family2' IDs are required"})
+		return
+	}
+	if family1ID == family2ID {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "'family1' and 'family2' must be different families"})
+		return
+	}
+
+	limit := 20 // default
+	if l := c.Query("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= 100 {
+			limit = parsed
+		}
+	}
+
+	offset := 0
+	if o := c.Query("offset"); o != "" {
+		if parsed, err := strconv.Atoi(o); err == nil && parsed > 0 {
+			offset = parsed
+		}
+	}
+
+	page, err := h.connectionService.GetMutualConnections(c.Request.Context(), family1ID, family2ID, offset, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"mutual":  page,
+		"message": "Mutual connections retrieved successfully",
+	})
+}
+
+// CountMutualConnections counts the families a family has in common with each listed family
+func (h *ConnectionHandler) CountMutualConnections(c *gin.Context) {
+	familyID := c.Query("family")
+	otherFamilyIDs := splitQueryList(c.Query("others"))
+
+	if familyID == "" || len(otherFamilyIDs) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Both 'family' and 'others' are required"})
+		return
+	}
+	if len(otherFamilyIDs) > service.MaxMutualCountFamilies {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("At most %d families can be counted at once", service.MaxMutualCountFamilies)})
+		return
+	}
+
+	counts, err := h.connectionService.CountMutualConnections(c.Request.Context(), familyID, otherFamilyIDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"family_id":     familyID,
+		"mutual_counts": counts,
+		"message":       "Mutual connections counted successfully",
+	})
+}
+
+// GetDegrees returns the degree of separation between a viewer and each listed family
+func (h *ConnectionHandler) GetDegrees(c *gin.Context) {
+	viewerID := c.Query("viewer")
+	familyIDs := splitQueryList(c.Query("targets"))
+
+	if viewerID == "" || len(familyIDs) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Both 'viewer' and 'targets' are required"})
+		return
+	}
+	if len(familyIDs) > service.MaxDegreeTargets {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("At most %d targets can be looked up at once", service.MaxDegreeTargets)})
+		return
+	}
+
+	maxDepth := 4 // default
+	if depth := c.Query("max_depth"); depth != "" {
+		if d, err := strconv.Atoi(depth); err == nil && d > 0 && d <= 6 {
+			maxDepth = d
+		}
+	}
+
+	degrees, err := h.connectionService.GetDegrees(c.Request.Context(), viewerID, familyIDs, maxDepth)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"viewer":    viewerID,
+		"degrees":   degrees,
+		"max_depth": maxDepth,
+		"message":   "Degrees retrieved successfully",
+	})
+}
+
 // GetFamilyNetwork retrieves the network connections for a family
 func (h *ConnectionHandler) GetFamilyNetwork(c *gin.Context) {
 	familyID := c.Param("familyId")
@@ -171,6 +310,94 @@ func (h *ConnectionHandler) GetNetworkStats(c *gin.Context) {
 	})
 }
 
+// GetTopConnectors lists the families that bridge the most other families, optionally
+// filtered by region or caste
+func (h *ConnectionHandler) GetTopConnectors(c *gin.Context) {
+	criteria := &models.ConnectorCriteria{
+		Region: c.Query("region"),
+		Caste:  c.Query("caste"),
+		Limit:  20, // default
+	}
+	if l := c.Query("limit"); l != "" {
+		if limit, err := strconv.Atoi(l); err == nil && limit > 0 && limit <= 100 {
+			criteria.Limit = limit
+		}
+	}
+
+	connectors, err := h.connectionService.GetTopConnectors(c.Request.Context(), criteria)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"connectors": connectors,
+		"count":      len(connectors),
+		"criteria":   criteria,
+		"message":    "Top connectors retrieved successfully",
+	})
+}
+
+// RecomputeAnalytics recomputes graph centrality and communities immediately instead of waiting for the batch job
+func (h *ConnectionHandler) RecomputeAnalytics(c *gin.Context) {
+	summary, err := h.connectionService.RecomputeAnalytics(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"summary": summary,
+		"message": "Graph analytics recomputed successfully",
+	})
+}
+
+// GetCommunities lists the detected family communities with their member counts and the
+// connections bridging them
+func (h *ConnectionHandler) GetCommunities(c *gin.Context) {
+	limit := 50 // default
+	if l := c.Query("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= 200 {
+			limit = parsed
+		}
+	}
+
+	report, err := h.connectionService.GetCommunities(c.Request.Context(), limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"summary":     report.CommunitySummary,
+		"communities": report.Communities,
+		"bridges":     report.Bridges,
+		"message":     "Communities retrieved successfully",
+	})
+}
+
+// GetNetworkFragility reports the network's connected components and the families and
+// connections whose loss would split it
+func (h *ConnectionHandler) GetNetworkFragility(c *gin.Context) {
+	limit := 20 // default
+	if l := c.Query("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= 100 {
+			limit = parsed
+		}
+	}
+
+	report, err := h.connectionService.AnalyzeNetworkFragility(c.Request.Context(), limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"report":  report,
+		"message": "Network fragility analyzed successfully",
+	})
+}
+
 // CreateConnection creates a new connection between families
 func (h *ConnectionHandler) CreateConnection(c *gin.Context) {
 	var connectionRequest struct {
@@ -212,6 +439,93 @@ func (h *ConnectionHandler) CreateConnection(c *gin.Context) {
 	})
 }
 
+// ReconfirmConnection records that two families reconfirmed their connection, resetting
+// the decay of its strength
+func (h *ConnectionHandler) ReconfirmConnection(c *gin.Context) {
+	var reconfirmRequest struct {
+		FromFamilyID string `json:"from_family_id" binding:"required"`
+		ToFamilyID   string `json:"to_family_id" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&reconfirmRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	connection, err := h.connectionService.ReconfirmConnection(c.Request.Context(), reconfirmRequest.FromFamilyID, reconfirmRequest.ToFamilyID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if connection == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "families are not directly connected"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":    "Connection reconfirmed successfully",
+		"connection": connection,
+	})
+}
+
+// FindSteinerTree plans the connections joining a group of families through the fewest intermediaries
+func (h *ConnectionHandler) FindSteinerTree(c *gin.Context) {
+	var steinerRequest struct {
+		FamilyIDs     []string `json:"family_ids" binding:"required"`
+		MaxDepth      int      `json:"max_depth"`
+		RelationTypes []string `json:"relation_types"`
+		MinStrength   float64  `json:"min_strength"`
+		VerifiedOnly  bool     `json:"verified_only"`
+		Exclude       []string `json:"exclude"`
+	}
+
+	if err := c.ShouldBindJSON(&steinerRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if n := len(steinerRequest.FamilyIDs); n < service.MinSteinerFamilies || n > service.MaxSteinerFamilies {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Between %d and %d family IDs are required", service.MinSteinerFamilies, service.MaxSteinerFamilies)})
+		return
+	}
+	if steinerRequest.MinStrength < 0 || steinerRequest.MinStrength > 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid 'min_strength', expected a number between 0 and 1"})
+		return
+	}
+
+	maxDepth := 4 // default
+	if d := steinerRequest.MaxDepth; d > 0 && d <= 6 {
+		maxDepth = d
+	}
+
+	constraints := &algorithms.PathConstraints{
+		AllowedRelationTypes: steinerRequest.RelationTypes,
+		MinStrength:          steinerRequest.MinStrength,
+		VerifiedOnly:         steinerRequest.VerifiedOnly,
+		ExcludedFamilyIDs:    steinerRequest.Exclude,
+	}
+	if constraints.IsEmpty() {
+		constraints = nil
+	}
+
+	tree, err := h.connectionService.FindSteinerTree(c.Request.Context(), steinerRequest.FamilyIDs, maxDepth, constraints)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	message := "Families connected successfully"
+	if len(tree.UnreachableFamilyIDs) > 0 {
+		message = "Some families could not be connected within the search depth"
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"tree":        tree,
+		"constraints": constraints,
+		"max_depth":   maxDepth,
+		"message":     message,
+	})
+}
+
 // AnalyzeConnectionStrength analyzes the strength of connections in a path
 func (h *ConnectionHandler) AnalyzeConnectionStrength(c *gin.Context) {
 	fromFamilyID := c.Query("from")
@@ -230,7 +544,7 @@ func (h *ConnectionHandler) AnalyzeConnectionStrength(c *gin.Context) {
 	}
 
 	// First, find the path
-	path, err := h.connectionService.FindConnectionPath(c.Request.Context(), fromFamilyID, toFamilyID, maxDepth)
+	path, err := h.connectionService.FindConnectionPath(c.Request.Context(), fromFamilyID, toFamilyID, maxDepth, algorithms.PathModeShortest, nil)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -256,4 +570,47 @@ func (h *ConnectionHandler) AnalyzeConnectionStrength(c *gin.Context) {
 		"analysis": analysis,
 		"message":  "Connection strength analyzed successfully",
 	})
-}
\ No newline at end of file
+}
+
+// parsePathConstraints reads the optional route constraints from the query string.
+// It returns nil when no constraint is given.
+func parsePathConstraints(c *gin.Context) (*algorithms.PathConstraints, error) {
+	constraints := &algorithms.PathConstraints{
+		AllowedRelationTypes: splitQueryList(c.Query("relation_types")),
+		ExcludedFamilyIDs:    splitQueryList(c.Query("exclude")),
+		ViaFamilyID:          strings.TrimSpace(c.Query("via")),
+	}
+
+	if minStrength := c.Query("min_strength"); minStrength != "" {
+		s, err := strconv.ParseFloat(minStrength, 64)
+		if err != nil || s < 0 || s > 1 {
+			return nil, fmt.Errorf("invalid 'min_strength', expected a number between 0 and 1")
+		}
+		constraints.MinStrength = s
+	}
+
+	if verifiedOnly := c.Query("verified_only"); verifiedOnly != "" {
+		v, err := strconv.ParseBool(verifiedOnly)
+		if err != nil {
+			return nil, fmt.Errorf("invalid 'verified_only', expected true or false")
+		}
+		constraints.VerifiedOnly = v
+	}
+
+	if constraints.IsEmpty() {
+		return nil, nil
+	}
+
+	return constraints, nil
+}
+
+// splitQueryList splits a comma-separated query value, dropping empty entries
+func splitQueryList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -1,7 +1,9 @@
 package api
 
 import (
+	"errors"
 	"families-linkedin/internal/models"
+	"families-linkedin/internal/repository"
 	"families-linkedin/internal/service"
 	"net/http"
 	"strconv"
@@ -10,12 +12,14 @@ import (
 )
 
 type FamilyHandler struct {
-	familyService *service.FamilyService
+	familyService     *service.FamilyService
+	connectionService *service.ConnectionService
 }
 
-func NewFamilyHandler(familyService *service.FamilyService) *FamilyHandler {
+func NewFamilyHandler(familyService *service.FamilyService, connectionService *service.ConnectionService) *FamilyHandler {
 	return &FamilyHandler{
-		familyService: familyService,
+		familyService:     familyService,
+		connectionService: connectionService,
 	}
 }
 
@@ -149,11 +153,28 @@ func (h *FamilyHandler) SearchFamilies(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
+	response := gin.H{
 		"families": families,
 		"count":    len(families),
 		"criteria": criteria,
-	})
+	}
+
+	// With a viewer, show how many mutual families the viewer has with each result
+	if viewerID := c.Query("viewer"); viewerID != "" {
+		familyIDs := make([]string, len(families))
+		for i, family := range families {
+			familyIDs[i] = family.ID
+		}
+
+		mutualCounts, err := h.connectionService.CountMutualConnections(c.Request.Context(), viewerID, familyIDs)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		response["mutual_counts"] = mutualCounts
+	}
+
+	c.JSON(http.StatusOK, response)
 }
 
 // GetFamilyMembers retrieves all members of a family
@@ -263,7 +284,8 @@ func (h *FamilyHandler) CreateFamilyConnection(c *gin.Context) {
 	})
 }
 
-// GetFamilyTrustScore retrieves the current trust score for a family
+// GetFamilyTrustScore retrieves the current trust score for a family. With explain=true it
+// also breaks the connection-based score into its components and lists the score history.
 func (h *FamilyHandler) GetFamilyTrustScore(c *gin.Context) {
 	familyID := c.Param("id")
 	if familyID == "" {
@@ -271,6 +293,29 @@ func (h *FamilyHandler) GetFamilyTrustScore(c *gin.Context) {
 		return
 	}
 
+	if c.Query("explain") == "true" {
+		explanation, err := h.familyService.ExplainFamilyTrustScore(c.Request.Context(), familyID)
+		if errors.Is(err, repository.ErrFamilyNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"family_id":        familyID,
+			"trust_score":      explanation.TrustScore,
+			"source":           explanation.Source,
+			"updated_at":       explanation.UpdatedAt,
+			"connection_score": explanation.ConnectionScore,
+			"breakdown":        explanation.Breakdown,
+			"history":          explanation.History,
+		})
+		return
+	}
+
 	family, err := h.familyService.GetFamily(c.Request.Context(), familyID)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
@@ -304,3 +349,57 @@ func (h *FamilyHandler) CalculateFamilyTrustScore(c *gin.Context) {
 		"message":     "Trust score calculated and updated successfully",
 	})
 }
+
+// GetSuggestions returns families the family may know, with the reasons for each
+func (h *FamilyHandler) GetSuggestions(c *gin.Context) {
+	familyID := c.Param("id")
+	if familyID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Family ID is required"})
+		return
+	}
+
+	limit := 20 // default
+	if l := c.Query("limit"); l != "" {
+		if lim, err := strconv.Atoi(l); err == nil && lim > 0 && lim <= 100 {
+			limit = lim
+		}
+	}
+
+	suggestions, err := h.familyService.GetSuggestions(c.Request.Context(), familyID, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"family_id":   familyID,
+		"suggestions": suggestions,
+		"count":       len(suggestions),
+		"message":     "Suggestions retrieved successfully",
+	})
+}
+
+// DismissSuggestion stops a family from being suggested again
+func (h *FamilyHandler) DismissSuggestion(c *gin.Context) {
+	familyID := c.Param("id")
+	suggestedFamilyID := c.Param("suggestedId")
+	if familyID == "" || suggestedFamilyID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Family ID and suggested family ID are required"})
+		return
+	}
+	if familyID == suggestedFamilyID {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A family cannot dismiss itself"})
+		return
+	}
+
+	if err := h.familyService.DismissSuggestion(c.Request.Context(), familyID, suggestedFamilyID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"family_id":           familyID,
+		"suggested_family_id": suggestedFamilyID,
+		"message":             "Suggestion dismissed successfully",
+	})
+}
@@ -0,0 +1,71 @@
+package api
+
+import (
+	"families-linkedin/internal/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type IntroductionHandler struct {
+	introductionService *service.IntroductionService
+}
+
+func NewIntroductionHandler(introductionService *service.IntroductionService) *IntroductionHandler {
+	return &IntroductionHandler{
+		introductionService: introductionService,
+	}
+}
+
+// RecommendIntroductions ranks the families to approach for an introduction to a person
+func (h *IntroductionHandler) RecommendIntroductions(c *gin.Context) {
+	fromFamilyID := c.Query("from")
+	toPersonID := c.Query("to_person")
+
+	if fromFamilyID == "" || toPersonID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Both 'from' family ID and 'to_person' person ID are required"})
+		return
+	}
+
+	maxDepth := 4 // default
+	if depth := c.Query("max_depth"); depth != "" {
+		if d, err := strconv.Atoi(depth); err == nil && d > 0 && d <= 6 {
+			maxDepth = d
+		}
+	}
+
+	maxPaths := 5 // default
+	if paths := c.Query("max_paths"); paths != "" {
+		if p, err := strconv.Atoi(paths); err == nil && p > 0 && p <= 10 {
+			maxPaths = p
+		}
+	}
+
+	limit := 5 // default
+	if l := c.Query("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= 20 {
+			limit = parsed
+		}
+	}
+
+	plan, err := h.introductionService.RecommendIntroductions(c.Request.Context(), fromFamilyID, toPersonID, maxDepth, maxPaths, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if len(plan.Candidates) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{
+			"message":   "No connection path found to the person's family",
+			"from":      fromFamilyID,
+			"to_person": toPersonID,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"introductions": plan,
+		"message":       "Introductions recommended successfully",
+	})
+}
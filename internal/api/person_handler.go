@@ -1,6 +1,7 @@
 package api
 
 import (
+	"families-linkedin/internal/kinship"
 	"families-linkedin/internal/models"
 	"families-linkedin/internal/service"
 	"net/http"
@@ -75,7 +76,10 @@ func (h *PersonHandler) GetEligibleMatches(c *gin.Context) {
 		}
 	}
 
-	matches, err := h.familyService.GetEligibleMatches(c.Request.Context(), personID, maxDegree)
+	// Mutual matching also requires the candidate's preferences to accept the person
+	mutual := c.Query("mutual") == "true"
+
+	matches, rejections, err := h.familyService.GetEligibleMatches(c.Request.Context(), personID, maxDegree, mutual)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -87,11 +91,14 @@ func (h *PersonHandler) GetEligibleMatches(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"person_id":   personID,
-		"matches":     matches,
-		"count":       len(matches),
-		"max_degree":  maxDegree,
-		"message":     "Eligible matches found successfully",
+		"person_id":      personID,
+		"matches":        matches,
+		"count":          len(matches),
+		"rejections":     rejections,
+		"rejected_count": len(rejections),
+		"max_degree":     maxDegree,
+		"mutual":         mutual,
+		"message":        "Eligible matches found successfully",
 	})
 }
 
@@ -166,4 +173,101 @@ func (h *PersonHandler) SearchEligiblePersons(c *gin.Context) {
 		"criteria": criteria,
 		"message":  "This endpoint requires PersonRepository integration",
 	})
-}
\ No newline at end of file
+}
+
+// AddPersonRelation records that the person is a parent or spouse of another person
+func (h *PersonHandler) AddPersonRelation(c *gin.Context) {
+	personID := c.Param("id")
+	if personID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Person ID is required"})
+		return
+	}
+
+	var request struct {
+		Type       string `json:"type" binding:"required"`         // PARENT_OF, from this person to the child, or SPOUSE_OF
+		ToPersonID string `json:"to_person_id" binding:"required"` // The child or spouse
+	}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	relation := models.NewPersonRelation(personID, request.ToPersonID, request.Type)
+	if err := h.familyService.AddPersonRelation(c.Request.Context(), relation); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":  "Relation added successfully",
+		"relation": relation,
+	})
+}
+
+// GetPersonRelations lists a person's recorded parents, children and spouses
+func (h *PersonHandler) GetPersonRelations(c *gin.Context) {
+	personID := c.Param("id")
+	if personID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Person ID is required"})
+		return
+	}
+
+	relations, err := h.familyService.GetPersonRelations(c.Request.Context(), personID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"person_id": personID,
+		"relations": relations,
+		"count":     len(relations),
+		"message":   "Relations retrieved successfully",
+	})
+}
+
+// GetKinship explains how a person is related to another person, naming the relationship in
+// the requested locale
+func (h *PersonHandler) GetKinship(c *gin.Context) {
+	personID := c.Param("id")
+	otherID := c.Param("otherId")
+	if personID == "" || otherID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Both person IDs are required"})
+		return
+	}
+
+	maxDepth := service.DefaultKinshipDepth
+	if depth := c.Query("max_depth"); depth != "" {
+		if d, err := strconv.Atoi(depth); err == nil && d > 0 && d <= service.MaxKinshipDepth {
+			maxDepth = d
+		}
+	}
+
+	locale := c.DefaultQuery("locale", kinship.English.Code())
+	if _, ok := kinship.Lookup(locale); !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unsupported locale", "supported_locales": kinship.Codes()})
+		return
+	}
+
+	path, err := h.familyService.FindKinshipPath(c.Request.Context(), personID, otherID, maxDepth, locale)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if path == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"message":   "No kinship found",
+			"from":      personID,
+			"to":        otherID,
+			"max_depth": maxDepth,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"kinship":   path,
+		"max_depth": maxDepth,
+		"message":   "Kinship found successfully",
+	})
+}
@@ -7,11 +7,12 @@ import (
 )
 
 // SetupRoutes configures all API routes
-func SetupRoutes(router *gin.Engine, familyService *service.FamilyService, connectionService *service.ConnectionService) {
+func SetupRoutes(router *gin.Engine, familyService *service.FamilyService, connectionService *service.ConnectionService, introductionService *service.IntroductionService) {
 	// Create API handlers
-	familyHandler := NewFamilyHandler(familyService)
+	familyHandler := NewFamilyHandler(familyService, connectionService)
 	connectionHandler := NewConnectionHandler(connectionService)
 	personHandler := NewPersonHandler(familyService)
+	introductionHandler := NewIntroductionHandler(introductionService)
 
 	// API v1 group
 	v1 := router.Group("/api/v1")
@@ -29,6 +30,8 @@ func SetupRoutes(router *gin.Engine, familyService *service.FamilyService, conne
 			families.POST("/:id/connections", familyHandler.CreateFamilyConnection)
 			families.GET("/:id/trust-score", familyHandler.GetFamilyTrustScore)
 			families.POST("/:id/trust-score/calculate", familyHandler.CalculateFamilyTrustScore)
+			families.GET("/:id/suggestions", familyHandler.GetSuggestions)
+			families.POST("/:id/suggestions/:suggestedId/dismiss", familyHandler.DismissSuggestion)
 		}
 
 		// Person routes
@@ -37,6 +40,9 @@ func SetupRoutes(router *gin.Engine, familyService *service.FamilyService, conne
 			persons.GET("/:id", personHandler.GetPerson)
 			persons.PUT("/:id", personHandler.UpdatePerson)
 			persons.GET("/:id/matches", personHandler.GetEligibleMatches)
+			persons.POST("/:id/relations", personHandler.AddPersonRelation)
+			persons.GET("/:id/relations", personHandler.GetPersonRelations)
+			persons.GET("/:id/kinship/:otherId", personHandler.GetKinship)
 			persons.GET("", personHandler.SearchEligiblePersons)
 		}
 
@@ -46,10 +52,30 @@ func SetupRoutes(router *gin.Engine, familyService *service.FamilyService, conne
 			connections.GET("/path", connectionHandler.FindConnectionPath)
 			connections.GET("/paths", connectionHandler.FindMultipleConnectionPaths)
 			connections.GET("/common", connectionHandler.FindCommonConnections)
+			connections.GET("/mutual", connectionHandler.GetMutualConnections)
+			connections.GET("/mutual/count", connectionHandler.CountMutualConnections)
+			connections.GET("/degrees", connectionHandler.GetDegrees)
 			connections.GET("/network/:familyId", connectionHandler.GetFamilyNetwork)
 			connections.GET("/stats", connectionHandler.GetNetworkStats)
 			connections.POST("", connectionHandler.CreateConnection)
+			connections.POST("/reconfirm", connectionHandler.ReconfirmConnection)
+			connections.POST("/steiner", connectionHandler.FindSteinerTree)
 			connections.GET("/analyze", connectionHandler.AnalyzeConnectionStrength)
+			connections.GET("/analytics/connectors", connectionHandler.GetTopConnectors)
+			connections.GET("/analytics/communities", connectionHandler.GetCommunities)
+		}
+
+		// Introduction routes
+		introductions := v1.Group("/introductions")
+		{
+			introductions.GET("/recommend", introductionHandler.RecommendIntroductions)
+		}
+
+		// Admin routes
+		admin := v1.Group("/admin")
+		{
+			admin.GET("/network/fragility", connectionHandler.GetNetworkFragility)
+			admin.POST("/analytics/recompute", connectionHandler.RecomputeAnalytics)
 		}
 	}
 }
\ No newline at end of file
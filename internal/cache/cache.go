@@ -0,0 +1,51 @@
+// Package cache provides the shared cache backends used for path searches and family lookups.
+// Values are opaque bytes; codec.go serializes the models that are cached.
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// Backend stores cached values by key. Each value may carry tags so that every value
+// derived from some piece of data can be dropped together when that data changes.
+// Publish and Subscribe carry invalidation events between replicas sharing a backend.
+type Backend interface {
+	// Get returns the value for key, and false if it is missing or expired
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set stores a value for ttl and records it under each tag
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration, tags ...string) error
+	Delete(ctx context.Context, keys ...string) error
+	// InvalidateTags drops every value recorded under any of the tags and returns how many were dropped
+	InvalidateTags(ctx context.Context, tags ...string) (int, error)
+
+	Publish(ctx context.Context, channel string, payload []byte) error
+	// Subscribe calls handler for every payload published on channel until ctx is cancelled.
+	// It returns once the subscription is active.
+	Subscribe(ctx context.Context, channel string, handler func(payload []byte)) error
+
+	Close() error
+}
+
+// MetricsRecorder receives cache events. metrics.Collector satisfies it.
+type MetricsRecorder interface {
+	IncrementCounter(name string)
+	RecordValue(name string, value float64)
+}
+
+// recorder is a nil-safe wrapper around a MetricsRecorder
+type recorder struct {
+	metrics MetricsRecorder
+}
+
+func (r recorder) increment(name string) {
+	if r.metrics != nil {
+		r.metrics.IncrementCounter(name)
+	}
+}
+
+func (r recorder) record(name string, value float64) {
+	if r.metrics != nil {
+		r.metrics.RecordValue(name, value)
+	}
+}
@@ -0,0 +1,59 @@
+package cache
+
+import (
+	"encoding/json"
+	"families-linkedin/internal/models"
+	"fmt"
+)
+
+// codecVersion is embedded in every cached value so a deploy that changes a model's
+// shape treats values written by older replicas as misses instead of misreading them
+const codecVersion = 1
+
+type pathsEnvelope struct {
+	Version int                      `json:"v"`
+	Paths   []*models.ConnectionPath `json:"paths"`
+}
+
+type familyEnvelope struct {
+	Version int            `json:"v"`
+	Family  *models.Family `json:"family"`
+}
+
+// EncodePaths serializes the result of a path search. A nil or empty result is kept,
+// so searches that found no path can be cached too.
+func EncodePaths(paths []*models.ConnectionPath) ([]byte, error) {
+	return json.Marshal(pathsEnvelope{Version: codecVersion, Paths: paths})
+}
+
+// DecodePaths deserializes a value written by EncodePaths
+func DecodePaths(data []byte) ([]*models.ConnectionPath, error) {
+	var envelope pathsEnvelope
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return nil, fmt.Errorf("failed to decode cached paths: %w", err)
+	}
+	if envelope.Version != codecVersion {
+		return nil, fmt.Errorf("cached paths have version %d, want %d", envelope.Version, codecVersion)
+	}
+	return envelope.Paths, nil
+}
+
+// EncodeFamily serializes a family
+func EncodeFamily(family *models.Family) ([]byte, error) {
+	return json.Marshal(familyEnvelope{Version: codecVersion, Family: family})
+}
+
+// DecodeFamily deserializes a value written by EncodeFamily
+func DecodeFamily(data []byte) (*models.Family, error) {
+	var envelope familyEnvelope
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return nil, fmt.Errorf("failed to decode cached family: %w", err)
+	}
+	if envelope.Version != codecVersion {
+		return nil, fmt.Errorf("cached family has version %d, want %d", envelope.Version, codecVersion)
+	}
+	if envelope.Family == nil {
+		return nil, fmt.Errorf("cached family is empty")
+	}
+	return envelope.Family, nil
+}
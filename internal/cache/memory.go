@@ -0,0 +1,191 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryBackend is a size-bounded LRU cache held in process. Its pub/sub only reaches
+// subscribers in the same process, so each replica using it keeps its own cache.
+type MemoryBackend struct {
+	capacity    int
+	entries     map[string]*list.Element
+	lru         *list.List // Front is most recently used
+	tags        map[string]map[string]bool
+	subscribers map[string]map[*memorySubscriber]bool
+	metrics     recorder
+	mutex       sync.Mutex
+}
+
+// memoryEntry is a cached value
+type memoryEntry struct {
+	key       string
+	value     []byte
+	tags      []string
+	expiresAt time.Time
+}
+
+// memorySubscriber is a handler registered with Subscribe
+type memorySubscriber struct {
+	handler func(payload []byte)
+}
+
+// NewMemoryBackend creates an in-process cache holding at most capacity values.
+// metrics may be nil.
+func NewMemoryBackend(capacity int, metrics MetricsRecorder) *MemoryBackend {
+	if capacity <= 0 {
+		capacity = 10000 // Default capacity
+	}
+
+	return &MemoryBackend{
+		capacity:    capacity,
+		entries:     make(map[string]*list.Element),
+		lru:         list.New(),
+		tags:        make(map[string]map[string]bool),
+		subscribers: make(map[string]map[*memorySubscriber]bool),
+		metrics:     recorder{metrics: metrics},
+	}
+}
+
+func (m *MemoryBackend) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	element, exists := m.entries[key]
+	if !exists {
+		return nil, false, nil
+	}
+
+	entry := element.Value.(*memoryEntry)
+	if time.Now().After(entry.expiresAt) {
+		m.remove(element)
+		m.metrics.increment("cache_expirations")
+		return nil, false, nil
+	}
+
+	m.lru.MoveToFront(element)
+	return entry.value, true, nil
+}
+
+func (m *MemoryBackend) Set(ctx context.Context, key string, value []byte, ttl time.Duration, tags ...string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if element, exists := m.entries[key]; exists {
+		m.remove(element)
+	}
+
+	m.entries[key] = m.lru.PushFront(&memoryEntry{
+		key:       key,
+		value:     value,
+		tags:      tags,
+		expiresAt: time.Now().Add(ttl),
+	})
+	for _, tag := range tags {
+		if m.tags[tag] == nil {
+			m.tags[tag] = make(map[string]bool)
+		}
+		m.tags[tag][key] = true
+	}
+
+	for len(m.entries) > m.capacity {
+		m.remove(m.lru.Back())
+		m.metrics.increment("cache_evictions")
+	}
+
+	m.metrics.record("cache_entries", float64(len(m.entries)))
+	return nil
+}
+
+func (m *MemoryBackend) Delete(ctx context.Context, keys ...string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for _, key := range keys {
+		if element, exists := m.entries[key]; exists {
+			m.remove(element)
+		}
+	}
+
+	m.metrics.record("cache_entries", float64(len(m.entries)))
+	return nil
+}
+
+func (m *MemoryBackend) InvalidateTags(ctx context.Context, tags ...string) (int, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	dropped := 0
+	for _, tag := range tags {
+		for key := range m.tags[tag] {
+			if element, exists := m.entries[key]; exists {
+				m.remove(element)
+				dropped++
+			}
+		}
+	}
+
+	m.metrics.record("cache_entries", float64(len(m.entries)))
+	return dropped, nil
+}
+
+func (m *MemoryBackend) Publish(ctx context.Context, channel string, payload []byte) error {
+	m.mutex.Lock()
+	handlers := make([]func([]byte), 0, len(m.subscribers[channel]))
+	for subscriber := range m.subscribers[channel] {
+		handlers = append(handlers, subscriber.handler)
+	}
+	m.mutex.Unlock()
+
+	for _, handler := range handlers {
+		handler(payload)
+	}
+	return nil
+}
+
+func (m *MemoryBackend) Subscribe(ctx context.Context, channel string, handler func(payload []byte)) error {
+	subscriber := &memorySubscriber{handler: handler}
+
+	m.mutex.Lock()
+	if m.subscribers[channel] == nil {
+		m.subscribers[channel] = make(map[*memorySubscriber]bool)
+	}
+	m.subscribers[channel][subscriber] = true
+	m.mutex.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		m.mutex.Lock()
+		defer m.mutex.Unlock()
+		delete(m.subscribers[channel], subscriber)
+	}()
+
+	return nil
+}
+
+// Close drops every cached value
+func (m *MemoryBackend) Close() error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.entries = make(map[string]*list.Element)
+	m.lru.Init()
+	m.tags = make(map[string]map[string]bool)
+	return nil
+}
+
+// remove drops an entry and its tag references. Callers must hold the lock.
+func (m *MemoryBackend) remove(element *list.Element) {
+	entry := m.lru.Remove(element).(*memoryEntry)
+	delete(m.entries, entry.key)
+
+	for _, tag := range entry.tags {
+		delete(m.tags[tag], entry.key)
+		if len(m.tags[tag]) == 0 {
+			delete(m.tags, tag)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package cache
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// cachedKeys lists which of the keys the backend still holds
+func cachedKeys(t *testing.T, backend Backend, keys ...string) []string {
+	t.Helper()
+
+	held := []string{}
+	for _, key := range keys {
+		_, found, err := backend.Get(context.Background(), key)
+		if err != nil {
+			t.Fatalf("Get(%s): %v", key, err)
+		}
+		if found {
+			held = append(held, key)
+		}
+	}
+	return held
+}
+
+func TestMemoryBackendEvictsLeastRecentlyUsed(t *testing.T) {
+	tests := []struct {
+		name  string
+		steps []string // "set:key" or "get:key", against a capacity of 3
+		want  []string
+	}{
+		{"within capacity", []string{"set:a", "set:b", "set:c"}, []string{"a", "b", "c"}},
+		{"oldest evicted", []string{"set:a", "set:b", "set:c", "set:d"}, []string{"b", "c", "d"}},
+		{"read refreshes recency", []string{"set:a", "set:b", "set:c", "get:a", "set:d"}, []string{"a", "c", "d"}},
+		{"overwrite refreshes recency", []string{"set:a", "set:b", "set:c", "set:a", "set:d"}, []string{"a", "c", "d"}},
+		{"missing read changes nothing", []string{"set:a", "set:b", "set:c", "get:z", "set:d"}, []string{"b", "c", "d"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			backend := NewMemoryBackend(3, nil)
+			for _, step := range tt.steps {
+				key := step[4:]
+				switch step[:3] {
+				case "set":
+					if err := backend.Set(ctx, key, []byte(key), time.Minute); err != nil {
+						t.Fatalf("Set(%s): %v", key, err)
+					}
+				case "get":
+					backend.Get(ctx, key)
+				}
+			}
+
+			if got := cachedKeys(t, backend, "a", "b", "c", "d", "z"); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("cached keys = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMemoryBackendExpires(t *testing.T) {
+	ctx := context.Background()
+	backend := NewMemoryBackend(10, nil)
+	backend.Set(ctx, "short", []byte("value"), 10*time.Millisecond)
+	backend.Set(ctx, "long", []byte("value"), time.Minute)
+
+	time.Sleep(30 * time.Millisecond)
+	if got := cachedKeys(t, backend, "short", "long"); !reflect.DeepEqual(got, []string{"long"}) {
+		t.Errorf("cached keys = %v, want only the unexpired value", got)
+	}
+}
+
+func TestMemoryBackendInvalidateTags(t *testing.T) {
+	tests := []struct {
+		name        string
+		tags        []string
+		wantDropped int
+		wantHeld    []string
+	}{
+		{"one tag", []string{"family:A"}, 2, []string{"c"}},
+		{"shared value counted once", []string{"family:A", "family:B"}, 3, []string{}},
+		{"unknown tag", []string{"family:Z"}, 0, []string{"a", "b", "c"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			backend := NewMemoryBackend(10, nil)
+			backend.Set(ctx, "a", []byte("a"), time.Minute, "family:A")
+			backend.Set(ctx, "b", []byte("b"), time.Minute, "family:A", "family:B")
+			backend.Set(ctx, "c", []byte("c"), time.Minute, "family:B")
+
+			dropped, err := backend.InvalidateTags(ctx, tt.tags...)
+			if err != nil {
+				t.Fatalf("InvalidateTags: %v", err)
+			}
+			if dropped != tt.wantDropped {
+				t.Errorf("dropped = %d, want %d", dropped, tt.wantDropped)
+			}
+			if got := cachedKeys(t, backend, "a", "b", "c"); !reflect.DeepEqual(got, tt.wantHeld) {
+				t.Errorf("cached keys = %v, want %v", got, tt.wantHeld)
+			}
+		})
+	}
+}
+
+func TestMemoryBackendEvictionReleasesTags(t *testing.T) {
+	ctx := context.Background()
+	backend := NewMemoryBackend(1, nil)
+	backend.Set(ctx, "a", []byte("a"), time.Minute, "family:A")
+	backend.Set(ctx, "b", []byte("b"), time.Minute, "family:B")
+
+	if dropped, _ := backend.InvalidateTags(ctx, "family:A"); dropped != 0 {
+		t.Errorf("dropped = %d for the tag of an evicted value, want 0", dropped)
+	}
+	if len(backend.tags) != 1 {
+		t.Errorf("tags held = %d, want only the cached value's", len(backend.tags))
+	}
+}
+
+func TestMemoryBackendPubSub(t *testing.T) {
+	backend := NewMemoryBackend(10, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var received []string
+	backend.Subscribe(ctx, "invalidate", func(payload []byte) { received = append(received, string(payload)) })
+	backend.Publish(context.Background(), "invalidate", []byte("one"))
+	backend.Publish(context.Background(), "other", []byte("ignored"))
+
+	cancel()
+	deadline := time.Now().Add(time.Second)
+	for {
+		backend.mutex.Lock()
+		remaining := len(backend.subscribers["invalidate"])
+		backend.mutex.Unlock()
+		if remaining == 0 || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	backend.Publish(context.Background(), "invalidate", []byte("after cancel"))
+
+	if !reflect.DeepEqual(received, []string{"one"}) {
+		t.Errorf("received = %v, want only the payload published while subscribed", received)
+	}
+}
@@ -0,0 +1,427 @@
+package cache
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RedisOptions configures a RedisBackend
+type RedisOptions struct {
+	Address     string
+	Password    string
+	DB          int
+	PoolSize    int
+	DialTimeout time.Duration
+	IOTimeout   time.Duration // Per command, when the context has no deadline
+	KeyPrefix   string        // Namespaces keys, tag sets and channels
+}
+
+// RedisBackend is a cache shared by every replica, stored in any server speaking the Redis
+// protocol. Tags are kept as Redis sets of keys that expire with their newest member.
+type RedisBackend struct {
+	options   RedisOptions
+	pool      chan *redisConn
+	metrics   recorder
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+// redisConn is one connection to the server
+type redisConn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+	writer *bufio.Writer
+}
+
+// subscriptionRetryLimit caps the backoff between attempts to restore a lost subscription
+const subscriptionRetryLimit = 30 * time.Second
+
+// NewRedisBackend connects to a Redis server and verifies it responds. metrics may be nil.
+func NewRedisBackend(ctx context.Context, options RedisOptions, metrics MetricsRecorder) (*RedisBackend, error) {
+	if options.PoolSize <= 0 {
+		options.PoolSize = 10
+	}
+	if options.DialTimeout <= 0 {
+		options.DialTimeout = 5 * time.Second
+	}
+	if options.IOTimeout <= 0 {
+		options.IOTimeout = 3 * time.Second
+	}
+
+	rb := &RedisBackend{
+		options: options,
+		pool:    make(chan *redisConn, options.PoolSize),
+		metrics: recorder{metrics: metrics},
+		closed:  make(chan struct{}),
+	}
+
+	reply, err := rb.do(ctx, "PING")
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach Redis at %s: %w", options.Address, err)
+	}
+	if reply != "PONG" {
+		return nil, fmt.Errorf("unexpected PING reply from Redis at %s: %v", options.Address, reply)
+	}
+
+	return rb, nil
+}
+
+func (rb *RedisBackend) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	reply, err := rb.do(ctx, "GET", rb.options.KeyPrefix+key)
+	if err != nil {
+		return nil, false, err
+	}
+	if reply == nil {
+		return nil, false, nil
+	}
+
+	value, ok := reply.([]byte)
+	if !ok {
+		return nil, false, fmt.Errorf("unexpected GET reply type %T", reply)
+	}
+	return value, true, nil
+}
+
+func (rb *RedisBackend) Set(ctx context.Context, key string, value []byte, ttl time.Duration, tags ...string) error {
+	milliseconds := strconv.FormatInt(max(ttl.Milliseconds(), 1), 10)
+	key = rb.options.KeyPrefix + key
+
+	commands := [][]string{{"SET", key, string(value), "PX", milliseconds}}
+	for _, tag := range tags {
+		tagKey := rb.tagKey(tag)
+		commands = append(commands,
+			[]string{"SADD", tagKey, key},
+			[]string{"PEXPIRE", tagKey, milliseconds})
+	}
+
+	_, err := rb.pipeline(ctx, commands...)
+	return err
+}
+
+func (rb *RedisBackend) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	args := []string{"DEL"}
+	for _, key := range keys {
+		args = append(args, rb.options.KeyPrefix+key)
+	}
+
+	_, err := rb.do(ctx, args...)
+	return err
+}
+
+// InvalidateTags reads and deletes the tag sets in one MULTI/EXEC transaction, so a key
+// tagged concurrently is either listed here or recorded in a fresh tag set, never lost.
+// The listed values are deleted afterwards.
+func (rb *RedisBackend) InvalidateTags(ctx context.Context, tags ...string) (int, error) {
+	if len(tags) == 0 {
+		return 0, nil
+	}
+
+	tagKeys := []string{"DEL"}
+	commands := [][]string{{"MULTI"}}
+	for _, tag := range tags {
+		tagKeys = append(tagKeys, rb.tagKey(tag))
+		commands = append(commands, []string{"SMEMBERS", rb.tagKey(tag)})
+	}
+	commands = append(commands, tagKeys, []string{"EXEC"})
+
+	replies, err := rb.pipeline(ctx, commands...)
+	if err != nil {
+		return 0, err
+	}
+
+	// EXEC replies with the queued commands' replies, or null when the transaction was aborted
+	results, ok := replies[len(replies)-1].([]interface{})
+	if !ok || len(results) != len(tags)+1 {
+		rb.metrics.increment("cache_backend_errors")
+		return 0, fmt.Errorf("unexpected EXEC reply for tag invalidation: %v", replies[len(replies)-1])
+	}
+
+	// Values shared by several tags are listed once
+	seen := make(map[string]bool)
+	keys := []string{"DEL"}
+	for _, reply := range results[:len(tags)] {
+		members, _ := reply.([]interface{})
+		for _, member := range members {
+			key, _ := member.([]byte)
+			if key != nil && !seen[string(key)] {
+				seen[string(key)] = true
+				keys = append(keys, string(key))
+			}
+		}
+	}
+
+	if len(keys) == 1 {
+		return 0, nil
+	}
+	reply, err := rb.do(ctx, keys...)
+	if err != nil {
+		return 0, err
+	}
+	dropped, _ := reply.(int64)
+	return int(dropped), nil
+}
+
+func (rb *RedisBackend) Publish(ctx context.Context, channel string, payload []byte) error {
+	_, err := rb.do(ctx, "PUBLISH", rb.options.KeyPrefix+channel, string(payload))
+	return err
+}
+
+// Subscribe holds a dedicated connection for the subscription. If the connection drops,
+// it resubscribes with backoff until ctx is cancelled.
+func (rb *RedisBackend) Subscribe(ctx context.Context, channel string, handler func(payload []byte)) error {
+	channel = rb.options.KeyPrefix + channel
+
+	conn, err := rb.subscribe(ctx, channel)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		backoff := time.Second
+		for {
+			rb.receive(ctx, conn, channel, handler)
+			if ctx.Err() != nil || rb.isClosed() {
+				return
+			}
+
+			// The connection dropped, so restore the subscription
+			rb.metrics.increment("cache_subscription_errors")
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-rb.closed:
+					return
+				case <-time.After(backoff):
+				}
+
+				if conn, err = rb.subscribe(ctx, channel); err == nil {
+					backoff = time.Second
+					break
+				}
+				backoff = min(backoff*2, subscriptionRetryLimit)
+			}
+		}
+	}()
+
+	return nil
+}
+
+// subscribe opens a connection subscribed to channel
+func (rb *RedisBackend) subscribe(ctx context.Context, channel string) (*redisConn, error) {
+	conn, err := rb.dial(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.conn.SetDeadline(rb.deadline(ctx))
+	reply, err := conn.roundTrip([]string{"SUBSCRIBE", channel})
+	if err != nil {
+		conn.conn.Close()
+		return nil, fmt.Errorf("failed to subscribe to %s: %w", channel, err)
+	}
+	if confirmation, ok := reply[0].([]interface{}); !ok || len(confirmation) < 1 || string(bytesOf(confirmation[0])) != "subscribe" {
+		conn.conn.Close()
+		return nil, fmt.Errorf("unexpected SUBSCRIBE reply: %v", reply[0])
+	}
+
+	conn.conn.SetDeadline(time.Time{})
+	return conn, nil
+}
+
+// receive delivers published messages to handler until the connection fails or ctx is cancelled
+func (rb *RedisBackend) receive(ctx context.Context, conn *redisConn, channel string, handler func(payload []byte)) {
+	stop := context.AfterFunc(ctx, func() { conn.conn.Close() })
+	defer stop()
+	defer conn.conn.Close()
+
+	for {
+		reply, err := readReply(conn.reader)
+		if err != nil {
+			return
+		}
+
+		message, ok := reply.([]interface{})
+		if !ok || len(message) != 3 || string(bytesOf(message[0])) != "message" || string(bytesOf(message[1])) != channel {
+			continue
+		}
+		handler(bytesOf(message[2]))
+	}
+}
+
+// Close closes the pooled connections. Subscriptions end when their contexts are cancelled.
+func (rb *RedisBackend) Close() error {
+	rb.closeOnce.Do(func() {
+		close(rb.closed)
+		for {
+			select {
+			case conn := <-rb.pool:
+				conn.conn.Close()
+			default:
+				return
+			}
+		}
+	})
+	return nil
+}
+
+func (rb *RedisBackend) isClosed() bool {
+	select {
+	case <-rb.closed:
+		return true
+	default:
+		return false
+	}
+}
+
+func (rb *RedisBackend) tagKey(tag string) string {
+	return rb.options.KeyPrefix + "tag:" + tag
+}
+
+// do runs a single command
+func (rb *RedisBackend) do(ctx context.Context, args ...string) (interface{}, error) {
+	replies, err := rb.pipeline(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	return replies[0], nil
+}
+
+// pipeline sends several commands in one round trip and returns their replies in order.
+// An error reply from the server is returned as the error.
+func (rb *RedisBackend) pipeline(ctx context.Context, commands ...[]string) ([]interface{}, error) {
+	if rb.isClosed() {
+		return nil, fmt.Errorf("redis cache backend is closed")
+	}
+
+	conn, err := rb.getConn(ctx)
+	if err != nil {
+		rb.metrics.increment("cache_backend_errors")
+		return nil, err
+	}
+
+	conn.conn.SetDeadline(rb.deadline(ctx))
+	replies, err := conn.roundTrip(commands...)
+	if err != nil {
+		// The connection state is unknown after an I/O error
+		conn.conn.Close()
+		rb.metrics.increment("cache_backend_errors")
+		return nil, fmt.Errorf("redis command %s failed: %w", commands[0][0], err)
+	}
+	rb.putConn(conn)
+
+	for i, reply := range replies {
+		if redisErr, ok := reply.(RedisError); ok {
+			rb.metrics.increment("cache_backend_errors")
+			return nil, fmt.Errorf("redis command %s failed: %w", commands[i][0], redisErr)
+		}
+	}
+
+	return replies, nil
+}
+
+func (rb *RedisBackend) deadline(ctx context.Context) time.Time {
+	if deadline, ok := ctx.Deadline(); ok {
+		return deadline
+	}
+	return time.Now().Add(rb.options.IOTimeout)
+}
+
+// getConn takes an idle connection from the pool or dials a new one
+func (rb *RedisBackend) getConn(ctx context.Context) (*redisConn, error) {
+	select {
+	case conn := <-rb.pool:
+		return conn, nil
+	default:
+		return rb.dial(ctx)
+	}
+}
+
+// putConn returns a healthy connection to the pool, closing it if the pool is full
+func (rb *RedisBackend) putConn(conn *redisConn) {
+	select {
+	case rb.pool <- conn:
+	default:
+		conn.conn.Close()
+	}
+}
+
+// dial opens and authenticates a connection
+func (rb *RedisBackend) dial(ctx context.Context) (*redisConn, error) {
+	dialer := net.Dialer{Timeout: rb.options.DialTimeout}
+	netConn, err := dialer.DialContext(ctx, "tcp", rb.options.Address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+	}
+
+	conn := &redisConn{
+		conn:   netConn,
+		reader: bufio.NewReader(netConn),
+		writer: bufio.NewWriter(netConn),
+	}
+
+	var setup [][]string
+	if rb.options.Password != "" {
+		setup = append(setup, []string{"AUTH", rb.options.Password})
+	}
+	if rb.options.DB != 0 {
+		setup = append(setup, []string{"SELECT", strconv.Itoa(rb.options.DB)})
+	}
+	if len(setup) == 0 {
+		return conn, nil
+	}
+
+	netConn.SetDeadline(rb.deadline(ctx))
+	replies, err := conn.roundTrip(setup...)
+	if err == nil {
+		for i, reply := range replies {
+			if redisErr, ok := reply.(RedisError); ok {
+				err = fmt.Errorf("redis %s failed: %w", setup[i][0], redisErr)
+				break
+			}
+		}
+	}
+	if err != nil {
+		netConn.Close()
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+// roundTrip writes the commands and reads one reply per command
+func (c *redisConn) roundTrip(commands ...[]string) ([]interface{}, error) {
+	for _, args := range commands {
+		if err := writeCommand(c.writer, args...); err != nil {
+			return nil, err
+		}
+	}
+	if err := c.writer.Flush(); err != nil {
+		return nil, err
+	}
+
+	replies := make([]interface{}, len(commands))
+	for i := range replies {
+		reply, err := readReply(c.reader)
+		if err != nil {
+			return nil, err
+		}
+		replies[i] = reply
+	}
+	return replies, nil
+}
+
+// bytesOf returns a bulk string reply, or nil for any other reply
+func bytesOf(reply interface{}) []byte {
+	value, _ := reply.([]byte)
+	return value
+}
@@ -0,0 +1,256 @@
+package cache
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+)
+
+// newTestBackend starts a stand-in server and a backend connected to it, both closed with the test
+func newTestBackend(t *testing.T, password string) (*RedisBackend, *redisStandIn) {
+	t.Helper()
+
+	server, err := startRedisStandIn("127.0.0.1:0", password)
+	if err != nil {
+		t.Fatalf("startRedisStandIn: %v", err)
+	}
+	t.Cleanup(func() { server.Close() })
+
+	return connectTestBackend(t, server.Addr(), password), server
+}
+
+func connectTestBackend(t *testing.T, address, password string) *RedisBackend {
+	t.Helper()
+
+	backend, err := NewRedisBackend(context.Background(), RedisOptions{Address: address, Password: password, KeyPrefix: "test:"}, nil)
+	if err != nil {
+		t.Fatalf("NewRedisBackend: %v", err)
+	}
+	t.Cleanup(func() { backend.Close() })
+	return backend
+}
+
+func TestRedisBackendAuthenticates(t *testing.T) {
+	backend, server := newTestBackend(t, "secret")
+	if err := backend.Set(context.Background(), "key", []byte("value"), time.Minute); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	if _, err := NewRedisBackend(context.Background(), RedisOptions{Address: server.Addr(), Password: "wrong"}, nil); err == nil {
+		t.Errorf("NewRedisBackend with a wrong password succeeded")
+	}
+}
+
+func TestRedisBackendGetSet(t *testing.T) {
+	ctx := context.Background()
+	backend, _ := newTestBackend(t, "")
+
+	tests := []struct {
+		name      string
+		ttl       time.Duration
+		wait      time.Duration
+		wantFound bool
+	}{
+		{"fresh value", time.Minute, 0, true},
+		{"expired value", 20 * time.Millisecond, 50 * time.Millisecond, false},
+		{"sub-millisecond TTL still stores", time.Microsecond, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := backend.Set(ctx, tt.name, []byte("value"), tt.ttl); err != nil {
+				t.Fatalf("Set: %v", err)
+			}
+			time.Sleep(tt.wait)
+
+			value, found, err := backend.Get(ctx, tt.name)
+			if err != nil {
+				t.Fatalf("Get: %v", err)
+			}
+			if found != tt.wantFound {
+				t.Fatalf("Get found = %v, want %v", found, tt.wantFound)
+			}
+			if found && string(value) != "value" {
+				t.Errorf("Get = %q, want %q", value, "value")
+			}
+		})
+	}
+
+	if _, found, err := backend.Get(ctx, "missing"); err != nil || found {
+		t.Errorf("Get(missing) = found %v, err %v; want not found", found, err)
+	}
+
+	if err := backend.Delete(ctx, "fresh value"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, found, _ := backend.Get(ctx, "fresh value"); found {
+		t.Errorf("value still cached after Delete")
+	}
+}
+
+func TestRedisBackendInvalidateTags(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		invalidate  []string
+		wantDropped int
+		wantKept    []string
+	}{
+		{"one tag", []string{"family:A"}, 2, []string{"path:B-C", "untagged"}},
+		{"shared values counted once", []string{"family:A", "family:B"}, 3, []string{"untagged"}},
+		{"unknown tag", []string{"family:Z"}, 0, []string{"path:A-B", "path:A-C", "path:B-C", "untagged"}},
+		{"no tags", nil, 0, []string{"path:A-B", "path:A-C", "path:B-C", "untagged"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend, _ := newTestBackend(t, "")
+			values := map[string][]string{
+				"path:A-B": {"family:A", "family:B"},
+				"path:A-C": {"family:A", "family:C"},
+				"path:B-C": {"family:B", "family:C"},
+				"untagged": nil,
+			}
+			for key, tags := range values {
+				if err := backend.Set(ctx, key, []byte(key), time.Minute, tags...); err != nil {
+					t.Fatalf("Set(%s): %v", key, err)
+				}
+			}
+
+			dropped, err := backend.InvalidateTags(ctx, tt.invalidate...)
+			if err != nil {
+				t.Fatalf("InvalidateTags: %v", err)
+			}
+			if dropped != tt.wantDropped {
+				t.Errorf("InvalidateTags dropped %d, want %d", dropped, tt.wantDropped)
+			}
+
+			var kept []string
+			for key := range values {
+				if _, found, _ := backend.Get(ctx, key); found {
+					kept = append(kept, key)
+				}
+			}
+			sort.Strings(kept)
+			if len(kept) != len(tt.wantKept) {
+				t.Fatalf("kept %v, want %v", kept, tt.wantKept)
+			}
+			for i := range kept {
+				if kept[i] != tt.wantKept[i] {
+					t.Fatalf("kept %v, want %v", kept, tt.wantKept)
+				}
+			}
+		})
+	}
+}
+
+func TestRedisBackendInvalidateTagsClearsTagSets(t *testing.T) {
+	ctx := context.Background()
+	backend, _ := newTestBackend(t, "")
+
+	backend.Set(ctx, "old", []byte("old"), time.Minute, "family:A")
+	if _, err := backend.InvalidateTags(ctx, "family:A"); err != nil {
+		t.Fatalf("InvalidateTags: %v", err)
+	}
+
+	// A value re-cached afterwards is tracked by a fresh tag set holding only itself
+	backend.Set(ctx, "new", []byte("new"), time.Minute, "family:A")
+	backend.Set(ctx, "old", []byte("old"), time.Minute)
+	dropped, err := backend.InvalidateTags(ctx, "family:A")
+	if err != nil {
+		t.Fatalf("InvalidateTags: %v", err)
+	}
+	if dropped != 1 {
+		t.Errorf("InvalidateTags dropped %d, want 1", dropped)
+	}
+	if _, found, _ := backend.Get(ctx, "old"); !found {
+		t.Errorf("value untagged since the first invalidation was dropped")
+	}
+}
+
+// receiveWithin waits for one payload on received
+func receiveWithin(t *testing.T, received <-chan string, timeout time.Duration) string {
+	t.Helper()
+
+	select {
+	case payload := <-received:
+		return payload
+	case <-time.After(timeout):
+		t.Fatalf("no message received within %v", timeout)
+		return ""
+	}
+}
+
+func TestRedisBackendPubSub(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	publisher, server := newTestBackend(t, "")
+	subscriber := connectTestBackend(t, server.Addr(), "")
+
+	received := make(chan string, 10)
+	if err := subscriber.Subscribe(ctx, "invalidations", func(payload []byte) { received <- string(payload) }); err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+
+	for _, payload := range []string{"family:A", "family:B"} {
+		if err := publisher.Publish(ctx, "invalidations", []byte(payload)); err != nil {
+			t.Fatalf("Publish: %v", err)
+		}
+		if got := receiveWithin(t, received, time.Second); got != payload {
+			t.Errorf("received %q, want %q", got, payload)
+		}
+	}
+
+	publisher.Publish(ctx, "other", []byte("ignored"))
+	select {
+	case payload := <-received:
+		t.Errorf("received %q published on another channel", payload)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestRedisBackendSubscriberReconnects(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	server, err := startRedisStandIn("127.0.0.1:0", "")
+	if err != nil {
+		t.Fatalf("startRedisStandIn: %v", err)
+	}
+	address := server.Addr()
+	subscriber := connectTestBackend(t, address, "")
+
+	received := make(chan string, 10)
+	if err := subscriber.Subscribe(ctx, "invalidations", func(payload []byte) { received <- string(payload) }); err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+
+	// Restart the server on the same address, dropping the subscription
+	server.Close()
+	server, err = startRedisStandIn(address, "")
+	if err != nil {
+		t.Fatalf("restart stand-in: %v", err)
+	}
+	defer server.Close()
+	publisher := connectTestBackend(t, address, "")
+
+	// The subscriber retries after a backoff, so publish until a message arrives
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if err := publisher.Publish(ctx, "invalidations", []byte("family:A")); err != nil {
+			t.Fatalf("Publish: %v", err)
+		}
+		select {
+		case payload := <-received:
+			if payload != "family:A" {
+				t.Errorf("received %q, want %q", payload, "family:A")
+			}
+			return
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+	t.Fatalf("subscription not restored within 5s of the restart")
+}
@@ -0,0 +1,123 @@
+package cache
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// RESP (REdis Serialization Protocol) encoding for the Redis client. The server side used by the
+// tests' stand-in lives in standin_test.go.
+// Replies decode to string (simple strings), RedisError, int64, []byte (bulk strings) and
+// []interface{} (arrays). Null bulk strings and arrays decode to nil.
+
+// RedisError is an error reply sent by a Redis server
+type RedisError string
+
+func (e RedisError) Error() string {
+	return string(e)
+}
+
+// maxBulkLength guards against allocating for a corrupt length prefix
+const maxBulkLength = 512 * 1024 * 1024
+
+// writeCommand encodes a command as an array of bulk strings
+func writeCommand(w *bufio.Writer, args ...string) error {
+	if _, err := fmt.Fprintf(w, "*%d\r\n", len(args)); err != nil {
+		return err
+	}
+	for _, arg := range args {
+		if err := writeBulk(w, []byte(arg)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeBulk(w *bufio.Writer, value []byte) error {
+	if value == nil {
+		_, err := w.WriteString("$-1\r\n")
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "$%d\r\n", len(value)); err != nil {
+		return err
+	}
+	if _, err := w.Write(value); err != nil {
+		return err
+	}
+	_, err := w.WriteString("\r\n")
+	return err
+}
+
+// readReply decodes one reply
+func readReply(r *bufio.Reader) (interface{}, error) {
+	line, err := readLine(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(line) == 0 {
+		return nil, fmt.Errorf("empty RESP line")
+	}
+
+	switch line[0] {
+	case '+':
+		return string(line[1:]), nil
+	case '-':
+		return RedisError(line[1:]), nil
+	case ':':
+		value, err := strconv.ParseInt(string(line[1:]), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RESP integer %q: %w", line[1:], err)
+		}
+		return value, nil
+	case '$':
+		length, err := parseLength(line[1:])
+		if err != nil || length < 0 {
+			return nil, err
+		}
+		value := make([]byte, length+2) // Includes the trailing CRLF
+		if _, err := io.ReadFull(r, value); err != nil {
+			return nil, err
+		}
+		return value[:length], nil
+	case '*':
+		length, err := parseLength(line[1:])
+		if err != nil || length < 0 {
+			return nil, err
+		}
+		values := make([]interface{}, length)
+		for i := range values {
+			if values[i], err = readReply(r); err != nil {
+				return nil, err
+			}
+		}
+		return values, nil
+	default:
+		return nil, fmt.Errorf("unexpected RESP type %q", line[0])
+	}
+}
+
+// readLine reads a CRLF-terminated line without the terminator
+func readLine(r *bufio.Reader) ([]byte, error) {
+	line, err := r.ReadSlice('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 2 || line[len(line)-2] != '\r' {
+		return nil, fmt.Errorf("malformed RESP line %q", line)
+	}
+	return line[:len(line)-2], nil
+}
+
+// parseLength parses a bulk string or array length, where -1 means null
+func parseLength(value []byte) (int, error) {
+	length, err := strconv.Atoi(string(value))
+	if err != nil {
+		return 0, fmt.Errorf("invalid RESP length %q: %w", value, err)
+	}
+	if length < -1 || length > maxBulkLength {
+		return 0, fmt.Errorf("RESP length %d out of range", length)
+	}
+	return length, nil
+}
@@ -0,0 +1,502 @@
+package cache
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// redisStandIn is a minimal in-process server speaking the Redis protocol. It implements
+// the commands RedisBackend uses (strings with expiry, sets, transactions, pub/sub) so the
+// Redis backend can be tested without an external server.
+type redisStandIn struct {
+	listener    net.Listener
+	password    string
+	values      map[string][]byte
+	sets        map[string]map[string]bool
+	expiries    map[string]time.Time
+	subscribers map[string]map[*standInConn]bool
+	connections map[*standInConn]bool
+	mutex       sync.Mutex
+	wg          sync.WaitGroup
+}
+
+// standInConn is one client connection to the stand-in
+type standInConn struct {
+	conn          net.Conn
+	writer        *bufio.Writer
+	authenticated bool
+	subscriptions map[string]bool
+	transaction   [][]string // Commands queued since MULTI, nil outside a transaction
+	writeMutex    sync.Mutex // Published messages are written from other connections' goroutines
+}
+
+// startRedisStandIn listens on address ("127.0.0.1:0" for a free port) and serves
+// clients until Close. Clients must AUTH when password is non-empty.
+func startRedisStandIn(address, password string) (*redisStandIn, error) {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start Redis stand-in: %w", err)
+	}
+
+	server := &redisStandIn{
+		listener:    listener,
+		password:    password,
+		values:      make(map[string][]byte),
+		sets:        make(map[string]map[string]bool),
+		expiries:    make(map[string]time.Time),
+		subscribers: make(map[string]map[*standInConn]bool),
+		connections: make(map[*standInConn]bool),
+	}
+
+	server.wg.Add(1)
+	go server.serve()
+	return server, nil
+}
+
+// Addr returns the address the stand-in listens on
+func (s *redisStandIn) Addr() string {
+	return s.listener.Addr().String()
+}
+
+// Close stops the listener and disconnects every client
+func (s *redisStandIn) Close() error {
+	err := s.listener.Close()
+
+	s.mutex.Lock()
+	for client := range s.connections {
+		client.conn.Close()
+	}
+	s.mutex.Unlock()
+
+	s.wg.Wait()
+	return err
+}
+
+func (s *redisStandIn) serve() {
+	defer s.wg.Done()
+
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		client := &standInConn{
+			conn:          conn,
+			writer:        bufio.NewWriter(conn),
+			authenticated: s.password == "",
+			subscriptions: make(map[string]bool),
+		}
+
+		s.mutex.Lock()
+		s.connections[client] = true
+		s.mutex.Unlock()
+
+		s.wg.Add(1)
+		go s.handle(client)
+	}
+}
+
+// handle runs the commands of one client until it disconnects
+func (s *redisStandIn) handle(client *standInConn) {
+	defer s.wg.Done()
+	defer s.disconnect(client)
+
+	reader := bufio.NewReader(client.conn)
+	for {
+		args, err := readCommand(reader)
+		if err != nil {
+			return
+		}
+		if len(args) == 0 {
+			continue
+		}
+
+		command := strings.ToUpper(args[0])
+		client.writeMutex.Lock()
+		err = s.execute(client, command, args[1:])
+		if err == nil {
+			err = client.writer.Flush()
+		}
+		client.writeMutex.Unlock()
+
+		if err != nil || command == "QUIT" {
+			return
+		}
+	}
+}
+
+// disconnect drops a client and its subscriptions
+func (s *redisStandIn) disconnect(client *standInConn) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for channel := range client.subscriptions {
+		delete(s.subscribers[channel], client)
+	}
+	delete(s.connections, client)
+	client.conn.Close()
+}
+
+// execute runs one command and writes its reply. Callers must hold the client's write lock.
+func (s *redisStandIn) execute(client *standInConn, command string, args []string) error {
+	w := client.writer
+
+	if command == "AUTH" {
+		if len(args) < 1 {
+			return wrongArguments(w, command)
+		}
+		if args[len(args)-1] != s.password {
+			return writeError(w, "WRONGPASS invalid username-password pair")
+		}
+		client.authenticated = true
+		return writeSimpleString(w, "OK")
+	}
+	if !client.authenticated {
+		return writeError(w, "NOAUTH Authentication required.")
+	}
+
+	switch {
+	case command == "MULTI":
+		if client.transaction != nil {
+			return writeError(w, "ERR MULTI calls can not be nested")
+		}
+		client.transaction = [][]string{}
+		return writeSimpleString(w, "OK")
+
+	case command == "DISCARD":
+		if client.transaction == nil {
+			return writeError(w, "ERR DISCARD without MULTI")
+		}
+		client.transaction = nil
+		return writeSimpleString(w, "OK")
+
+	case command == "EXEC":
+		if client.transaction == nil {
+			return writeError(w, "ERR EXEC without MULTI")
+		}
+		queued := client.transaction
+		client.transaction = nil
+
+		// The queued commands run under one hold of the lock, so no other client interleaves
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		if err := writeArrayHeader(w, len(queued)); err != nil {
+			return err
+		}
+		for _, args := range queued {
+			if err := s.run(client, args[0], args[1:]); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case client.transaction != nil:
+		client.transaction = append(client.transaction, append([]string{command}, args...))
+		return writeSimpleString(w, "QUEUED")
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.run(client, command, args)
+}
+
+// run executes a data or pub/sub command and writes its reply. Callers must hold the lock.
+func (s *redisStandIn) run(client *standInConn, command string, args []string) error {
+	w := client.writer
+
+	switch command {
+	case "PING":
+		if len(args) > 0 {
+			return writeBulk(w, []byte(args[0]))
+		}
+		return writeSimpleString(w, "PONG")
+
+	case "QUIT", "SELECT", "FLUSHDB", "FLUSHALL":
+		if command == "FLUSHDB" || command == "FLUSHALL" {
+			s.values = make(map[string][]byte)
+			s.sets = make(map[string]map[string]bool)
+			s.expiries = make(map[string]time.Time)
+		}
+		return writeSimpleString(w, "OK")
+
+	case "GET":
+		if len(args) != 1 {
+			return wrongArguments(w, command)
+		}
+		s.expire(args[0])
+		if _, isSet := s.sets[args[0]]; isSet {
+			return wrongType(w)
+		}
+		value, exists := s.values[args[0]]
+		if !exists {
+			return writeBulk(w, nil)
+		}
+		return writeBulk(w, value)
+
+	case "SET":
+		if len(args) < 2 {
+			return wrongArguments(w, command)
+		}
+		var ttl time.Duration
+		for i := 2; i < len(args); i++ {
+			option := strings.ToUpper(args[i])
+			if (option != "PX" && option != "EX") || i+1 >= len(args) {
+				return writeError(w, "ERR syntax error")
+			}
+			amount, err := strconv.ParseInt(args[i+1], 10, 64)
+			if err != nil || amount <= 0 {
+				return writeError(w, "ERR invalid expire time in 'set' command")
+			}
+			ttl = time.Duration(amount) * time.Millisecond
+			if option == "EX" {
+				ttl = time.Duration(amount) * time.Second
+			}
+			i++
+		}
+		s.drop(args[0])
+		s.values[args[0]] = []byte(args[1])
+		if ttl > 0 {
+			s.expiries[args[0]] = time.Now().Add(ttl)
+		}
+		return writeSimpleString(w, "OK")
+
+	case "DEL":
+		if len(args) < 1 {
+			return wrongArguments(w, command)
+		}
+		var deleted int64
+		for _, key := range args {
+			s.expire(key)
+			if s.exists(key) {
+				s.drop(key)
+				deleted++
+			}
+		}
+		return writeInteger(w, deleted)
+
+	case "EXISTS":
+		var count int64
+		for _, key := range args {
+			s.expire(key)
+			if s.exists(key) {
+				count++
+			}
+		}
+		return writeInteger(w, count)
+
+	case "SADD":
+		if len(args) < 2 {
+			return wrongArguments(w, command)
+		}
+		s.expire(args[0])
+		if _, isString := s.values[args[0]]; isString {
+			return wrongType(w)
+		}
+		if s.sets[args[0]] == nil {
+			s.sets[args[0]] = make(map[string]bool)
+		}
+		var added int64
+		for _, member := range args[1:] {
+			if !s.sets[args[0]][member] {
+				s.sets[args[0]][member] = true
+				added++
+			}
+		}
+		return writeInteger(w, added)
+
+	case "SMEMBERS":
+		if len(args) != 1 {
+			return wrongArguments(w, command)
+		}
+		s.expire(args[0])
+		if _, isString := s.values[args[0]]; isString {
+			return wrongType(w)
+		}
+		if err := writeArrayHeader(w, len(s.sets[args[0]])); err != nil {
+			return err
+		}
+		for member := range s.sets[args[0]] {
+			if err := writeBulk(w, []byte(member)); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case "PEXPIRE", "EXPIRE":
+		if len(args) != 2 {
+			return wrongArguments(w, command)
+		}
+		amount, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			return writeError(w, "ERR value is not an integer or out of range")
+		}
+		s.expire(args[0])
+		if !s.exists(args[0]) {
+			return writeInteger(w, 0)
+		}
+		ttl := time.Duration(amount) * time.Millisecond
+		if command == "EXPIRE" {
+			ttl = time.Duration(amount) * time.Second
+		}
+		s.expiries[args[0]] = time.Now().Add(ttl)
+		return writeInteger(w, 1)
+
+	case "PUBLISH":
+		if len(args) != 2 {
+			return wrongArguments(w, command)
+		}
+		receivers := s.subscribers[args[0]]
+		for receiver := range receivers {
+			go s.deliver(receiver, args[0], args[1])
+		}
+		return writeInteger(w, int64(len(receivers)))
+
+	case "SUBSCRIBE":
+		if len(args) < 1 {
+			return wrongArguments(w, command)
+		}
+		for _, channel := range args {
+			if s.subscribers[channel] == nil {
+				s.subscribers[channel] = make(map[*standInConn]bool)
+			}
+			s.subscribers[channel][client] = true
+			client.subscriptions[channel] = true
+			if err := s.writeSubscription(w, "subscribe", channel, len(client.subscriptions)); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case "UNSUBSCRIBE":
+		channels := args
+		if len(channels) == 0 {
+			for channel := range client.subscriptions {
+				channels = append(channels, channel)
+			}
+		}
+		for _, channel := range channels {
+			delete(s.subscribers[channel], client)
+			delete(client.subscriptions, channel)
+			if err := s.writeSubscription(w, "unsubscribe", channel, len(client.subscriptions)); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	default:
+		return writeError(w, fmt.Sprintf("ERR unknown command '%s'", strings.ToLower(command)))
+	}
+}
+
+// deliver writes a published message to a subscriber
+func (s *redisStandIn) deliver(receiver *standInConn, channel, payload string) {
+	receiver.writeMutex.Lock()
+	defer receiver.writeMutex.Unlock()
+
+	if err := s.writeMessage(receiver.writer, channel, payload); err == nil {
+		receiver.writer.Flush()
+	}
+}
+
+func (s *redisStandIn) writeMessage(w *bufio.Writer, channel, payload string) error {
+	if err := writeArrayHeader(w, 3); err != nil {
+		return err
+	}
+	for _, part := range []string{"message", channel, payload} {
+		if err := writeBulk(w, []byte(part)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *redisStandIn) writeSubscription(w *bufio.Writer, kind, channel string, count int) error {
+	if err := writeArrayHeader(w, 3); err != nil {
+		return err
+	}
+	if err := writeBulk(w, []byte(kind)); err != nil {
+		return err
+	}
+	if err := writeBulk(w, []byte(channel)); err != nil {
+		return err
+	}
+	return writeInteger(w, int64(count))
+}
+
+// expire drops a key whose TTL has passed. Callers must hold the lock.
+func (s *redisStandIn) expire(key string) {
+	if expiresAt, ok := s.expiries[key]; ok && !time.Now().Before(expiresAt) {
+		s.drop(key)
+	}
+}
+
+// exists reports whether a key holds a value. Callers must hold the lock.
+func (s *redisStandIn) exists(key string) bool {
+	_, isString := s.values[key]
+	_, isSet := s.sets[key]
+	return isString || isSet
+}
+
+// drop removes a key of any type. Callers must hold the lock.
+func (s *redisStandIn) drop(key string) {
+	delete(s.values, key)
+	delete(s.sets, key)
+	delete(s.expiries, key)
+}
+
+func wrongArguments(w *bufio.Writer, command string) error {
+	return writeError(w, fmt.Sprintf("ERR wrong number of arguments for '%s' command", strings.ToLower(command)))
+}
+
+func wrongType(w *bufio.Writer) error {
+	return writeError(w, "WRONGTYPE Operation against a key holding the wrong kind of value")
+}
+
+// readCommand decodes a command sent as an array of bulk strings
+func readCommand(r *bufio.Reader) ([]string, error) {
+	reply, err := readReply(r)
+	if err != nil {
+		return nil, err
+	}
+
+	values, ok := reply.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("command must be an array, got %T", reply)
+	}
+
+	args := make([]string, len(values))
+	for i, value := range values {
+		bulk, ok := value.([]byte)
+		if !ok {
+			return nil, fmt.Errorf("command argument must be a bulk string, got %T", value)
+		}
+		args[i] = string(bulk)
+	}
+	return args, nil
+}
+
+func writeSimpleString(w *bufio.Writer, value string) error {
+	_, err := fmt.Fprintf(w, "+%s\r\n", value)
+	return err
+}
+
+func writeError(w *bufio.Writer, message string) error {
+	_, err := fmt.Fprintf(w, "-%s\r\n", message)
+	return err
+}
+
+func writeInteger(w *bufio.Writer, value int64) error {
+	_, err := fmt.Fprintf(w, ":%d\r\n", value)
+	return err
+}
+
+func writeArrayHeader(w *bufio.Writer, length int) error {
+	_, err := fmt.Fprintf(w, "*%d\r\n", length)
+	return err
+}
@@ -12,7 +12,11 @@ type Config struct {
 	Environment string
 	Server      ServerConfig
 	Neo4j       Neo4jConfig
+	Storage     StorageConfig
+	Redis       RedisConfig
 	Performance PerformanceConfig
+	Decay       DecayConfig
+	Matching    MatchingConfig
 }
 
 type ServerConfig struct {
@@ -32,11 +36,60 @@ type Neo4jConfig struct {
 	MaxTransactionRetryTime time.Duration
 }
 
+// StorageConfig selects the graph backend: "neo4j" or "memory"
+type StorageConfig struct {
+	Backend string
+}
+
+// RedisConfig locates the Redis server used when PerformanceConfig.CacheBackend is "redis"
+type RedisConfig struct {
+	Address   string
+	Password  string
+	DB        int
+	PoolSize  int
+	KeyPrefix string
+}
+
+// DecayConfig discounts connection strength by the time since a connection was last
+// confirmed. Function is "none", "exponential" (halving every HalfLife) or "step"
+// (multiplying by StepFactor every StepInterval); no connection decays below MinFactor.
+type DecayConfig struct {
+	Function     string
+	HalfLife     time.Duration
+	StepInterval time.Duration
+	StepFactor   float64
+	MinFactor    float64
+}
+
+// MatchingConfig locates a JSON file of consanguinity and exogamy rule sets replacing the
+// built-in ones; empty keeps the built-in rules
+type MatchingConfig struct {
+	RulesFile string
+}
+
 type PerformanceConfig struct {
 	MaxPathDepth int
 	QueryTimeout time.Duration
 	CacheEnabled bool
 	CacheTTL     time.Duration
+	// CacheBackend selects where cached paths and families live: "memory" (per process)
+	// or "redis" (shared by all replicas)
+	CacheBackend string
+	// CacheMaxEntries bounds the number of values kept by the memory cache backend
+	CacheMaxEntries int
+
+	// GraphSnapshotEnabled serves path queries from an in-memory CSR copy of the graph
+	// instead of querying the database, reloading it every GraphSnapshotRefreshInterval
+	GraphSnapshotEnabled         bool
+	GraphSnapshotRefreshInterval time.Duration
+
+	// AnalyticsRefreshInterval is how often graph centrality is recomputed; zero disables the job
+	AnalyticsRefreshInterval time.Duration
+	// BetweennessSamples is the number of source families sampled for approximate betweenness
+	BetweennessSamples int
+	// LandmarkCount is the number of high-degree families the distance oracle keeps BFS
+	// distances from, at one byte per family and landmark
+	LandmarkCount int
 }
 
 func Load() (*Config, error) {
@@ -60,11 +113,41 @@ func Load() (*Config, error) {
 			ConnectionTimeout:       getDurationEnv("NEO4J_CONNECTION_TIMEOUT", 30*time.Second),
 			MaxTransactionRetryTime: getDurationEnv("NEO4J_MAX_TRANSACTION_RETRY_TIME", 30*time.Second),
 		},
+		Storage: StorageConfig{
+			Backend: getEnv("STORAGE_BACKEND", "neo4j"),
+		},
+		Redis: RedisConfig{
+			Address:   getEnv("REDIS_ADDRESS", "localhost:6379"),
+			Password:  getEnv("REDIS_PASSWORD", ""),
+			DB:        getIntEnv("REDIS_DB", 0),
+			PoolSize:  getIntEnv("REDIS_POOL_SIZE", 10),
+			KeyPrefix: getEnv("REDIS_KEY_PREFIX", "kinconnect:"),
+		},
 		Performance: PerformanceConfig{
 			MaxPathDepth: getIntEnv("MAX_PATH_DEPTH", 4),
 			QueryTimeout: getDurationEnv("QUERY_TIMEOUT", 30*time.Second),
 			CacheEnabled: getBoolEnv("CACHE_ENABLED", true),
 			CacheTTL:     getDurationEnv("CACHE_TTL", 5*time.Minute),
+
+			CacheBackend:    getEnv("CACHE_BACKEND", "memory"),
+			CacheMaxEntries: getIntEnv("CACHE_MAX_ENTRIES", 10000),
+
+			GraphSnapshotEnabled:         getBoolEnv("GRAPH_SNAPSHOT_ENABLED", false),
+			GraphSnapshotRefreshInterval: getDurationEnv("GRAPH_SNAPSHOT_REFRESH_INTERVAL", 5*time.Minute),
+
+			AnalyticsRefreshInterval: getDurationEnv("ANALYTICS_REFRESH_INTERVAL", time.Hour),
+			BetweennessSamples:       getIntEnv("BETWEENNESS_SAMPLES", 256),
+			LandmarkCount:            getIntEnv("LANDMARK_COUNT", 200),
+		},
+		Decay: DecayConfig{
+			Function:     getEnv("STRENGTH_DECAY_FUNCTION", "exponential"),
+			HalfLife:     getDurationEnv("STRENGTH_DECAY_HALF_LIFE", 3*365*24*time.Hour),
+			StepInterval: getDurationEnv("STRENGTH_DECAY_STEP_INTERVAL", 365*24*time.Hour),
+			StepFactor:   getFloatEnv("STRENGTH_DECAY_STEP_FACTOR", 0.8),
+			MinFactor:    getFloatEnv("STRENGTH_DECAY_MIN_FACTOR", 0.25),
+		},
+		Matching: MatchingConfig{
+			RulesFile: getEnv("MATCHING_RULES_FILE", ""),
 		},
 	}
 
@@ -96,6 +179,15 @@ func getBoolEnv(key string, defaultValue bool) bool {
 	return defaultValue
 }
 
+func getFloatEnv(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+	}
+	return defaultValue
+}
+
 func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
 	if value := os.Getenv(key); value != "" {
 		if duration, err := time.ParseDuration(value); err == nil {
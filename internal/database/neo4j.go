@@ -165,6 +165,7 @@ func (bw *BatchWriter) WriteFamiliesBatch(ctx context.Context, families []map[st
 					region: family.region,
 					caste: family.caste,
 					sub_caste: family.sub_caste,
+					gotra: family.gotra,
 					religion: family.religion,
 					languages: family.languages,
 					trust_score: family.trust_score,
@@ -233,6 +234,14 @@ func (bw *BatchWriter) WritePersonsBatch(ctx context.Context, persons []map[stri
 					body_type: person.body_type,
 					hobbies: person.hobbies,
 					profile_visibility: person.profile_visibility,
+					preferred_age_range: person.preferred_age_range,
+					preferred_education: person.preferred_education,
+					preferred_profession: person.preferred_profession,
+					preferred_location: person.preferred_location,
+					preferred_caste: person.preferred_caste,
+					preferred_income: person.preferred_income,
+					max_distance: person.max_distance,
+					flexible_on_requirements: person.flexible_on_requirements,
 					created_at: datetime(person.created_at),
 					updated_at: datetime(person.updated_at)
 				})
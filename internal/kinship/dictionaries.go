@@ -0,0 +1,83 @@
+package kinship
+
+// Hindi names relationships in romanized Hindi. Terms distinguish the father's side (chacha,
+// bua) from the mother's (mama, mausi); cousins compose as "mama ki beti". Every term is
+// gendered, so relatives of unknown gender have none.
+var Hindi = NewDictionary("hi", map[string]string{
+	"": "swayam",
+
+	"F": "pita", "M": "maa",
+	"S": "beta", "D": "beti",
+	"B": "bhai", "eB": "bada bhai", "yB": "chhota bhai",
+	"Z": "behen", "eZ": "didi", "yZ": "chhoti behen",
+	"H": "pati", "W": "patni",
+
+	// Grandparents and grandchildren
+	"FF": "dada", "FM": "dadi", "MF": "nana", "MM": "nani",
+	"SS": "pota", "SD": "poti", "DS": "nati", "DD": "natin",
+	"FFF": "pardada", "FFM": "pardadi", "MMF": "parnana", "MMM": "parnani",
+
+	// Parents' siblings and their spouses
+	"FB": "chacha", "FeB": "tau", "FyB": "chacha",
+	"FBW": "chachi", "FeBW": "tai", "FyBW": "chachi",
+	"FZ": "bua", "FZH": "fufa",
+	"MB": "mama", "MBW": "mami",
+	"MZ": "mausi", "MZH": "mausa",
+
+	// Siblings' children
+	"BS": "bhatija", "BD": "bhatiji",
+	"ZS": "bhanja", "ZD": "bhanji",
+
+	// In-laws
+	"HF": "sasur", "HM": "saas", "WF": "sasur", "WM": "saas",
+	"SW": "bahu", "DH": "damad",
+	"BW": "bhabhi", "ZH": "jija",
+	"WB": "sala", "WZ": "sali", "WZH": "sadhu",
+	"HB": "devar", "HeB": "jeth", "HyB": "devar", "HZ": "nanad",
+	"HBW": "devrani", "HeBW": "jethani", "HyBW": "devrani",
+
+	// Step-parents
+	"FW": "sauteli maa", "MH": "sautele pita",
+}, func(owner, relative, gender string) string {
+	// The possessive agrees with the relative: "mama ki beti", "mama ka beta"
+	if gender == "Female" {
+		return owner + " ki " + relative
+	}
+	return owner + " ka " + relative
+})
+
+// Tamil names relationships in romanized Tamil. Cross relatives (athai, mama) are told apart
+// from parallel ones, who are named like parents and siblings; cousins compose as "athai magal".
+var Tamil = NewDictionary("ta", map[string]string{
+	"": "naan",
+
+	"F": "appa", "M": "amma",
+	"S": "magan", "D": "magal",
+	"B": "sagodharan", "eB": "annan", "yB": "thambi",
+	"Z": "sagodhari", "eZ": "akka", "yZ": "thangai",
+	"H": "kanavan", "W": "manaivi",
+
+	// Grandparents and grandchildren
+	"FF": "thatha", "MF": "thatha", "FM": "paati", "MM": "paati",
+	"SS": "peran", "DS": "peran", "SD": "pethi", "DD": "pethi",
+
+	// Parents' siblings and their spouses
+	"FB": "periyappa/chithappa", "FeB": "periyappa", "FyB": "chithappa",
+	"FBW": "periyamma/chithi", "FeBW": "periyamma", "FyBW": "chithi",
+	"MZ": "periyamma/chithi", "MeZ": "periyamma", "MyZ": "chithi",
+	"MZH": "periyappa/chithappa", "MeZH": "periyappa", "MyZH": "chithappa",
+	"FZ": "athai", "FZH": "mama",
+	"MB": "mama", "MBW": "athai",
+
+	// Cross cousins
+	"FZS": "athai magan", "FZD": "athai magal",
+	"MBS": "mama magan", "MBD": "mama magal",
+
+	// In-laws
+	"HF": "maamanaar", "WF": "maamanaar", "HM": "maamiyaar", "WM": "maamiyaar",
+	"SW": "marumagal", "DH": "marumagan",
+	"eBW": "anni", "ZH": "machaan", "WB": "machaan",
+	"HZ": "naathanaar",
+}, func(owner, relative, gender string) string {
+	return owner + " " + relative
+})
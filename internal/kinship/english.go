@@ -0,0 +1,155 @@
+package kinship
+
+import (
+	"families-linkedin/internal/models"
+	"strings"
+)
+
+// english names relationships by their shape rather than a dictionary, so any number of
+// generations or degrees of cousinhood gets a term
+type english struct{}
+
+// English is the default locale
+var English Locale = english{}
+
+func (english) Code() string { return "en" }
+
+// lineal names n generations up or down: parent, grandparent, great-grandparent and so on
+var lineal = map[string][3]string{
+	models.KinParent: {"father", "mother", "parent"},
+	models.KinChild:  {"son", "daughter", "child"},
+}
+
+var (
+	auntOrUncle  = [3]string{"uncle", "aunt", "aunt or uncle"}
+	niblings     = [3]string{"nephew", "niece", "niece or nephew"}
+	inLawParent  = [3]string{"father-in-law", "mother-in-law", "parent-in-law"}
+	inLawChild   = [3]string{"son-in-law", "daughter-in-law", "child-in-law"}
+	inLawSibling = [3]string{"brother-in-law", "sister-in-law", "sibling-in-law"}
+	stepParent   = [3]string{"stepfather", "stepmother", "step-parent"}
+	stepChild    = [3]string{"stepson", "stepdaughter", "stepchild"}
+	ordinals     = []string{"first", "second", "third", "fourth", "fifth", "sixth", "seventh", "eighth"}
+	removals     = []string{"", "once", "twice", "three times", "four times", "five times"}
+)
+
+// Term names blood relatives at any distance, spouses, in-laws and step-relatives. Other
+// relationships through a spouse have no single English term.
+func (english) Term(relation Relation) (string, bool) {
+	links := relation.Links
+	gender := relation.Gender()
+	if len(links) == 0 {
+		return "self", true
+	}
+	if len(links) == 1 && links[0].Kin == models.KinSpouse {
+		return gendered(words[models.KinSpouse], gender), true
+	}
+
+	leadingSpouse := links[0].Kin == models.KinSpouse
+	trailingSpouse := links[len(links)-1].Kin == models.KinSpouse
+	if leadingSpouse {
+		links = links[1:]
+	}
+	if trailingSpouse {
+		links = links[:len(links)-1]
+	}
+
+	up, down, ok := generations(links)
+	if !ok {
+		return "", false
+	}
+
+	switch {
+	case !leadingSpouse && !trailingSpouse:
+		return bloodTerm(up, down, gender), true
+	case leadingSpouse && trailingSpouse:
+		return "", false
+	case up == 1 && down == 0:
+		if leadingSpouse {
+			return gendered(inLawParent, gender), true
+		}
+		return gendered(stepParent, gender), true
+	case up == 0 && down == 1:
+		if leadingSpouse {
+			return gendered(stepChild, gender), true
+		}
+		return gendered(inLawChild, gender), true
+	case up == 1 && down == 1:
+		return gendered(inLawSibling, gender), true
+	case up >= 2 && down == 1 && trailingSpouse:
+		// A parent's sibling's spouse is an aunt or uncle too
+		return bloodTerm(up, down, gender), true
+	}
+	return "", false
+}
+
+// generations counts the generations a chain of blood links climbs and then descends. It
+// fails for chains that descend and climb again or pass through a spouse.
+func generations(links []Link) (up, down int, ok bool) {
+	for _, link := range links {
+		switch link.Kin {
+		case models.KinParent:
+			if down > 0 {
+				return 0, 0, false
+			}
+			up++
+		case KinSibling:
+			if down > 0 {
+				return 0, 0, false
+			}
+			up++
+			down++
+		case models.KinChild:
+			down++
+		default:
+			return 0, 0, false
+		}
+	}
+	return up, down, true
+}
+
+// bloodTerm names a relative up generations above and down generations below the nearest
+// common ancestor
+func bloodTerm(up, down int, gender string) string {
+	switch {
+	case up == 0 && down == 0:
+		return "self"
+	case down == 0:
+		return generational(up, gendered(lineal[models.KinParent], gender))
+	case up == 0:
+		return generational(down, gendered(lineal[models.KinChild], gender))
+	case up == 1 && down == 1:
+		return gendered(words[KinSibling], gender)
+	case down == 1:
+		return greats(up-2) + gendered(auntOrUncle, gender)
+	case up == 1:
+		return greats(down-2) + gendered(niblings, gender)
+	}
+
+	degree := min(up, down) - 1
+	removed := max(up, down) - min(up, down)
+	term := "cousin"
+	if degree <= len(ordinals) {
+		term = ordinals[degree-1] + " cousin"
+	}
+	switch {
+	case removed == 0:
+	case removed < len(removals):
+		term += " " + removals[removed] + " removed"
+	default:
+		term += " removed"
+	}
+	return term
+}
+
+// generational names a parent or child n generations away: parent, grandparent,
+// great-grandparent
+func generational(n int, base string) string {
+	if n == 1 {
+		return base
+	}
+	return greats(n-2) + "grand" + base
+}
+
+func greats(n int) string {
+	return strings.Repeat("great-", n)
+}
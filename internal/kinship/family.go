@@ -0,0 +1,45 @@
+package kinship
+
+import (
+	"families-linkedin/internal/models"
+	"strings"
+)
+
+// familyRelations maps the specific relations of family connections that name a kinship to
+// notation. Connections are stored in both directions under one label, so only labels that
+// read the same from either family qualify; "FATHER" or "UNCLE" would be ambiguous.
+var familyRelations = map[string]string{
+	"SIBLING":  "G",
+	"SIBLINGS": "G",
+	"BROTHERS": "B",
+	"SISTERS":  "Z",
+	"SPOUSE":   "E",
+	"MARRIAGE": "E",
+	"COUSIN":   "PGC",
+	"COUSINS":  "PGC",
+}
+
+// PathRelation names the kinship a family-level path amounts to, e.g. "SIBLING_IN_LAW" for a
+// marriage followed by a sibling connection. It returns false when an edge's specific
+// relation names no kinship or the chain has no single English term.
+func PathRelation(edges []models.PathEdge) (string, bool) {
+	if len(edges) == 0 {
+		return "", false
+	}
+
+	var relation Relation
+	for _, edge := range edges {
+		notation, ok := familyRelations[strings.ToUpper(edge.SpecificRelation)]
+		if !ok {
+			return "", false
+		}
+		step, _ := ParseNotation(notation)
+		relation = Concat(relation, step)
+	}
+
+	term, ok := English.Term(relation)
+	if !ok {
+		return "", false
+	}
+	return strings.ToUpper(strings.NewReplacer(" ", "_", "-", "_").Replace(term)), true
+}
@@ -0,0 +1,123 @@
+package kinship
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Locale names relationships in one language
+type Locale interface {
+	Code() string
+	// Term names the relationship, or returns false when the locale has no term for it
+	Term(relation Relation) (string, bool)
+}
+
+var (
+	localesMu sync.RWMutex
+	locales   = map[string]Locale{}
+)
+
+func init() {
+	Register(English)
+	Register(Hindi)
+	Register(Tamil)
+}
+
+// Register makes a locale available by its code, replacing any registered under the same code
+func Register(locale Locale) {
+	localesMu.Lock()
+	defer localesMu.Unlock()
+	locales[locale.Code()] = locale
+}
+
+// Lookup returns the locale registered under a code
+func Lookup(code string) (Locale, bool) {
+	localesMu.RLock()
+	defer localesMu.RUnlock()
+	locale, ok := locales[code]
+	return locale, ok
+}
+
+// Codes lists the registered locale codes in order
+func Codes() []string {
+	localesMu.RLock()
+	defer localesMu.RUnlock()
+	codes := make([]string, 0, len(locales))
+	for code := range locales {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// JoinFunc composes the term for a relative's relative, e.g. "mama" and "beti" into
+// "mama ki beti". gender is the gender of the relative the second term names.
+type JoinFunc func(owner, relative, gender string) string
+
+// Dictionary is a locale backed by a table of terms keyed by genealogical notation. Keys may
+// mark siblings elder or younger ("FeB"); a relationship is looked up with its age markers
+// first and without them second. Relationships missing from the table are split into the
+// longest known pieces from the left and composed with the locale's join.
+type Dictionary struct {
+	code  string
+	terms map[string]string
+	join  JoinFunc
+}
+
+// NewDictionary creates a dictionary locale. Pass it to Register to make it available.
+func NewDictionary(code string, terms map[string]string, join JoinFunc) *Dictionary {
+	return &Dictionary{code: code, terms: terms, join: join}
+}
+
+// Code returns the locale code
+func (d *Dictionary) Code() string {
+	return d.code
+}
+
+// Term names the relationship from the table, composing it from known pieces when needed
+func (d *Dictionary) Term(relation Relation) (string, bool) {
+	links := relation.Links
+	if len(links) == 0 {
+		term, ok := d.terms[""]
+		return term, ok
+	}
+
+	var term string
+	for start := 0; start < len(links); {
+		piece, end, ok := d.longestPiece(links, start)
+		if !ok {
+			return "", false
+		}
+		if start == 0 {
+			term = piece
+		} else {
+			term = d.join(term, piece, links[end-1].Gender)
+		}
+		start = end
+	}
+	return term, true
+}
+
+// longestPiece finds the longest run of links from start the table has a term for
+func (d *Dictionary) longestPiece(links []Link, start int) (string, int, bool) {
+	for end := len(links); end > start; end-- {
+		if term, ok := d.lookup(links[start:end]); ok {
+			return term, end, true
+		}
+	}
+	return "", 0, false
+}
+
+func (d *Dictionary) lookup(links []Link) (string, bool) {
+	var aged, plain strings.Builder
+	for _, link := range links {
+		aged.WriteString(link.Token(true))
+		plain.WriteString(link.Token(false))
+	}
+	if term, ok := d.terms[aged.String()]; ok {
+		return term, true
+	}
+	term, ok := d.terms[plain.String()]
+	return term, ok
+}
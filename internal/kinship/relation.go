@@ -0,0 +1,191 @@
+// Package kinship reduces chains of parent, child and spouse hops between persons to
+// canonical relationships and names them through pluggable locale dictionaries.
+//
+// Relationships are written in the standard genealogical notation: F father, M mother,
+// P parent, S son, D daughter, C child, B brother, Z sister, G sibling, H husband, W wife
+// and E spouse, read from the person the chain starts at. "MBD" is a mother's brother's
+// daughter. A sibling may be marked elder or younger, "FeB" being a father's elder brother.
+package kinship
+
+import (
+	"families-linkedin/internal/models"
+	"strings"
+	"time"
+)
+
+// KinSibling is the step a parent hop followed by a child hop reduces to
+const KinSibling = "SIBLING"
+
+// Hop is one parent, child or spouse step, described by the person it leads to
+type Hop struct {
+	Kin    string    // models.KinParent, models.KinChild or models.KinSpouse
+	Gender string    // "Male", "Female" or empty when unknown
+	Born   time.Time // Zero when unknown
+}
+
+// Link is one step of a reduced relationship
+type Link struct {
+	Kin    string // models.KinParent, models.KinChild, KinSibling or models.KinSpouse
+	Gender string
+	Elder  int // For siblings: 1 when older than the sibling reached from, -1 when younger, 0 when unknown
+}
+
+// Relation is a chain of hops reduced to canonical form
+type Relation struct {
+	Links []Link
+}
+
+// letters spells each kind of link for a male, a female and an unknown gender
+var letters = map[string][3]string{
+	models.KinParent: {"F", "M", "P"},
+	models.KinChild:  {"S", "D", "C"},
+	KinSibling:       {"B", "Z", "G"},
+	models.KinSpouse: {"H", "W", "E"},
+}
+
+// words names each kind of link in English for a male, a female and an unknown gender
+var words = map[string][3]string{
+	models.KinParent: {"father", "mother", "parent"},
+	models.KinChild:  {"son", "daughter", "child"},
+	KinSibling:       {"brother", "sister", "sibling"},
+	models.KinSpouse: {"husband", "wife", "spouse"},
+}
+
+// gendered picks the male, female or neutral form
+func gendered(forms [3]string, gender string) string {
+	switch gender {
+	case "Male":
+		return forms[0]
+	case "Female":
+		return forms[1]
+	default:
+		return forms[2]
+	}
+}
+
+// Reduce turns a chain of hops from a person born at selfBorn into a relationship. A parent
+// hop followed by a child hop becomes a sibling, and a child hop followed by a parent hop
+// becomes the spouse who shares the child. Siblings are marked elder or younger when both
+// birth dates are known.
+func Reduce(selfBorn time.Time, hops []Hop) Relation {
+	links := make([]Link, 0, len(hops))
+	born := []time.Time{selfBorn} // born[i] is the birth date of the person link i-1 leads to
+
+	for _, hop := range hops {
+		last := len(links) - 1
+		switch {
+		case hop.Kin == models.KinChild && last >= 0 && links[last].Kin == models.KinParent:
+			links[last] = Link{Kin: KinSibling, Gender: hop.Gender, Elder: compareAge(hop.Born, born[last])}
+			born[last+1] = hop.Born
+		case hop.Kin == models.KinParent && last >= 0 && links[last].Kin == models.KinChild:
+			links[last] = Link{Kin: models.KinSpouse, Gender: hop.Gender}
+			born[last+1] = hop.Born
+		default:
+			links = append(links, Link{Kin: hop.Kin, Gender: hop.Gender})
+			born = append(born, hop.Born)
+		}
+	}
+
+	return Relation{Links: links}
+}
+
+// compareAge returns 1 when born is earlier than other, -1 when later and 0 when either is unknown
+func compareAge(born, other time.Time) int {
+	switch {
+	case born.IsZero() || other.IsZero() || born.Equal(other):
+		return 0
+	case born.Before(other):
+		return 1
+	default:
+		return -1
+	}
+}
+
+// ParseNotation reads a relationship written in genealogical notation, e.g. "MBD" or "FeB".
+// It returns false for any other letter or an age marker outside a sibling.
+func ParseNotation(notation string) (Relation, bool) {
+	var relation Relation
+	elder := 0
+	for _, letter := range notation {
+		switch letter {
+		case 'e':
+			elder = 1
+			continue
+		case 'y':
+			elder = -1
+			continue
+		}
+
+		link, ok := parseLetter(string(letter))
+		if !ok || (elder != 0 && link.Kin != KinSibling) {
+			return Relation{}, false
+		}
+		link.Elder = elder
+		elder = 0
+		relation.Links = append(relation.Links, link)
+	}
+	return relation, elder == 0
+}
+
+func parseLetter(letter string) (Link, bool) {
+	for kin, forms := range letters {
+		for i, form := range forms {
+			if form == letter {
+				return Link{Kin: kin, Gender: [3]string{"Male", "Female", ""}[i]}, true
+			}
+		}
+	}
+	return Link{}, false
+}
+
+// Concat joins two relationships, the second read from the person the first leads to
+func Concat(first, second Relation) Relation {
+	links := make([]Link, 0, len(first.Links)+len(second.Links))
+	links = append(links, first.Links...)
+	return Relation{Links: append(links, second.Links...)}
+}
+
+// Token spells one link in notation, with its age marker when withAge is set
+func (l Link) Token(withAge bool) string {
+	letter := gendered(letters[l.Kin], l.Gender)
+	if !withAge || l.Kin != KinSibling {
+		return letter
+	}
+	switch l.Elder {
+	case 1:
+		return "e" + letter
+	case -1:
+		return "y" + letter
+	default:
+		return letter
+	}
+}
+
+// Notation spells the relationship in genealogical notation, siblings marked elder or younger
+func (r Relation) Notation() string {
+	var notation strings.Builder
+	for _, link := range r.Links {
+		notation.WriteString(link.Token(true))
+	}
+	return notation.String()
+}
+
+// Chain reads the relationship as a chain of English kin words, e.g. "mother's brother's daughter"
+func (r Relation) Chain() string {
+	if len(r.Links) == 0 {
+		return "self"
+	}
+	chain := make([]string, len(r.Links))
+	for i, link := range r.Links {
+		chain[i] = gendered(words[link.Kin], link.Gender)
+	}
+	return strings.Join(chain, "'s ")
+}
+
+// Gender is the gender of the relative the relationship leads to
+func (r Relation) Gender() string {
+	if len(r.Links) == 0 {
+		return ""
+	}
+	return r.Links[len(r.Links)-1].Gender
+}
@@ -0,0 +1,158 @@
+package kinship_test
+
+import (
+	"families-linkedin/internal/kinship"
+	"families-linkedin/internal/models"
+	"testing"
+	"time"
+)
+
+func hop(kin, gender string) kinship.Hop {
+	return kinship.Hop{Kin: kin, Gender: gender}
+}
+
+func bornHop(kin, gender string, year int) kinship.Hop {
+	return kinship.Hop{Kin: kin, Gender: gender, Born: time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func TestReduce(t *testing.T) {
+	selfBorn := time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)
+	parent, child, spouse := models.KinParent, models.KinChild, models.KinSpouse
+
+	tests := []struct {
+		name     string
+		hops     []kinship.Hop
+		notation string
+		english  string // Empty when English has no single term
+		hindi    string // Empty when the Hindi dictionary has no term
+	}{
+		{"self", nil, "", "self", "swayam"},
+		{"father", []kinship.Hop{hop(parent, "Male")}, "F", "father", "pita"},
+		{"sibling of unknown age", []kinship.Hop{hop(parent, "Female"), hop(child, "Female")}, "Z", "sister", "behen"},
+		{"elder brother", []kinship.Hop{hop(parent, "Male"), bornHop(child, "Male", 1985)}, "eB", "brother", "bada bhai"},
+		{"younger sister", []kinship.Hop{hop(parent, "Male"), bornHop(child, "Female", 1995)}, "yZ", "sister", "chhoti behen"},
+		{"father's elder brother", []kinship.Hop{bornHop(parent, "Male", 1960), hop(parent, "Male"), bornHop(child, "Male", 1955)}, "FeB", "uncle", "tau"},
+		{"mother's brother's daughter", []kinship.Hop{hop(parent, "Female"), hop(parent, "Female"), hop(child, "Male"), hop(child, "Female")}, "MBD", "first cousin", "mama ki beti"},
+		{"second cousin once removed", []kinship.Hop{hop(parent, "Male"), hop(parent, "Male"), hop(parent, "Male"), hop(child, "Male"), hop(child, "Male"), hop(child, "Male"), hop(child, "Male")}, "FFBSSS", "second cousin once removed", "dada ka bhatija ka pota"},
+		{"great-grandmother", []kinship.Hop{hop(parent, "Female"), hop(parent, "Female"), hop(parent, "Female")}, "MMM", "great-grandmother", "parnani"},
+		{"grandson", []kinship.Hop{hop(child, "Male"), hop(child, "Male")}, "SS", "grandson", "pota"},
+		{"co-parent is a spouse", []kinship.Hop{hop(child, "Male"), hop(parent, "Female")}, "W", "wife", "patni"},
+		{"wife's father", []kinship.Hop{hop(spouse, "Female"), hop(parent, "Male")}, "WF", "father-in-law", "sasur"},
+		{"brother's wife", []kinship.Hop{hop(parent, "Male"), hop(child, "Male"), hop(spouse, "Female")}, "BW", "sister-in-law", "bhabhi"},
+		{"mother's brother's wife", []kinship.Hop{hop(parent, "Female"), hop(parent, "Male"), hop(child, "Male"), hop(spouse, "Female")}, "MBW", "aunt", "mami"},
+		{"father's wife", []kinship.Hop{hop(parent, "Male"), hop(spouse, "Female")}, "FW", "stepmother", "sauteli maa"},
+		{"parent's sibling of unknown gender", []kinship.Hop{hop(parent, ""), hop(parent, ""), hop(child, "")}, "PG", "aunt or uncle", ""},
+		{"spouse's sibling's spouse", []kinship.Hop{hop(spouse, "Female"), hop(parent, ""), hop(child, "Female"), hop(spouse, "Male")}, "WZH", "", "sadhu"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			relation := kinship.Reduce(selfBorn, tt.hops)
+			if got := relation.Notation(); got != tt.notation {
+				t.Fatalf("notation = %q, want %q", got, tt.notation)
+			}
+
+			english, ok := kinship.English.Term(relation)
+			if ok != (tt.english != "") || english != tt.english {
+				t.Errorf("English term = %q, %v; want %q", english, ok, tt.english)
+			}
+			hindi, ok := kinship.Hindi.Term(relation)
+			if ok != (tt.hindi != "") || hindi != tt.hindi {
+				t.Errorf("Hindi term = %q, %v; want %q", hindi, ok, tt.hindi)
+			}
+		})
+	}
+}
+
+func TestParseNotation(t *testing.T) {
+	tests := []struct {
+		notation string
+		ok       bool
+		chain    string
+	}{
+		{"", true, "self"},
+		{"MBD", true, "mother's brother's daughter"},
+		{"FeB", true, "father's brother"},
+		{"PGC", true, "parent's sibling's child"},
+		{"X", false, ""},
+		{"eF", false, ""},
+		{"Fe", false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.notation, func(t *testing.T) {
+			relation, ok := kinship.ParseNotation(tt.notation)
+			if ok != tt.ok {
+				t.Fatalf("ParseNotation(%q) ok = %v, want %v", tt.notation, ok, tt.ok)
+			}
+			if !ok {
+				return
+			}
+			if got := relation.Notation(); got != tt.notation {
+				t.Errorf("notation round trip = %q, want %q", got, tt.notation)
+			}
+			if got := relation.Chain(); got != tt.chain {
+				t.Errorf("chain = %q, want %q", got, tt.chain)
+			}
+		})
+	}
+}
+
+func TestDictionaryTerms(t *testing.T) {
+	tests := []struct {
+		locale   kinship.Locale
+		notation string
+		want     string
+	}{
+		{kinship.Hindi, "MBS", "mama ka beta"},
+		{kinship.Hindi, "FZD", "bua ki beti"},
+		{kinship.Tamil, "MBD", "mama magal"},
+		{kinship.Tamil, "FyB", "chithappa"},
+		{kinship.Tamil, "FB", "periyappa/chithappa"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.locale.Code()+" "+tt.notation, func(t *testing.T) {
+			relation, _ := kinship.ParseNotation(tt.notation)
+			if got, ok := tt.locale.Term(relation); !ok || got != tt.want {
+				t.Errorf("Term(%s) = %q, %v; want %q", tt.notation, got, ok, tt.want)
+			}
+		})
+	}
+
+	for _, code := range []string{"en", "hi", "ta"} {
+		if _, ok := kinship.Lookup(code); !ok {
+			t.Errorf("locale %q is not registered", code)
+		}
+	}
+}
+
+func TestPathRelation(t *testing.T) {
+	tests := []struct {
+		name      string
+		relations []string
+		want      string // Empty when the path names no kinship
+	}{
+		{"marriage then sibling", []string{"SPOUSE", "SIBLING"}, "SIBLING_IN_LAW"},
+		{"labels in any case", []string{"marriage"}, "SPOUSE"},
+		{"cousin", []string{"COUSIN"}, "FIRST_COUSIN"},
+		{"brothers", []string{"BROTHERS"}, "BROTHER"},
+		{"sibling's cousin has no term", []string{"SIBLING", "COUSIN"}, ""},
+		{"directional label", []string{"UNCLE"}, ""},
+		{"no edges", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			edges := make([]models.PathEdge, len(tt.relations))
+			for i, relation := range tt.relations {
+				edges[i] = models.PathEdge{SpecificRelation: relation}
+			}
+
+			got, ok := kinship.PathRelation(edges)
+			if ok != (tt.want != "") || got != tt.want {
+				t.Errorf("PathRelation = %q, %v; want %q", got, ok, tt.want)
+			}
+		})
+	}
+}
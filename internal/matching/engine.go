@@ -0,0 +1,180 @@
+package matching
+
+import (
+	"families-linkedin/internal/kinship"
+	"families-linkedin/internal/models"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Lines of ascent, named for the parent they pass through
+const (
+	LinePaternal = "PATERNAL"
+	LineMaternal = "MATERNAL"
+)
+
+// CommonAncestor is an ancestor shared by the seeker and a candidate. A person is their own
+// ancestor at zero generations, with no line, so a candidate descended from the seeker shares
+// the seeker as an ancestor.
+type CommonAncestor struct {
+	AncestorID           string
+	SeekerGenerations    int
+	CandidateGenerations int
+	SeekerLine           string // LinePaternal, LineMaternal or empty when unknown
+	CandidateLine        string
+}
+
+// Relatedness is what is known about how the seeker and a candidate are related
+type Relatedness struct {
+	CommonAncestors []CommonAncestor
+	// RelativeFamilyHops is the fewest connections labelled as blood relatives joining the two
+	// families, or 0 when they are not joined within the rule set's limit
+	RelativeFamilyHops int
+}
+
+// Evaluate checks a candidate against the rule set, with one violation per rule broken.
+// Blood relation rules are judged by the nearest common ancestor breaking them; ancestry that
+// is not recorded breaks none.
+func (rs *RuleSet) Evaluate(candidate *models.Person, seekerFamily, candidateFamily *models.Family, relatedness Relatedness) []models.RuleViolation {
+	var violations []models.RuleViolation
+
+	var sapinda, degree *CommonAncestor
+	for i := range relatedness.CommonAncestors {
+		ancestor := &relatedness.CommonAncestors[i]
+		if rs.AllowCrossCousins && isCrossCousin(ancestor) {
+			continue
+		}
+		if rs.withinSapinda(ancestor) && (sapinda == nil || distance(ancestor) < distance(sapinda)) {
+			sapinda = ancestor
+		}
+		if rs.MaxBloodDegree > 0 && distance(ancestor) <= rs.MaxBloodDegree && (degree == nil || distance(ancestor) < distance(degree)) {
+			degree = ancestor
+		}
+	}
+
+	if sapinda != nil {
+		violations = append(violations, models.RuleViolation{
+			RuleSet: rs.Name,
+			Rule:    RuleSapinda,
+			Reason: fmt.Sprintf("candidate is the seeker's %s, sharing an ancestor in generation %d of the seeker's %s and %d of the candidate's %s, within the %s sapinda limits of %d paternal and %d maternal generations",
+				relationshipTerm(sapinda, candidate.Gender), sapinda.SeekerGenerations+1, lineName(sapinda.SeekerLine),
+				sapinda.CandidateGenerations+1, lineName(sapinda.CandidateLine), rs.Name, rs.SapindaPaternal, rs.SapindaMaternal),
+		})
+	}
+	if degree != nil {
+		violations = append(violations, models.RuleViolation{
+			RuleSet: rs.Name,
+			Rule:    RuleBloodDegree,
+			Reason: fmt.Sprintf("candidate is the seeker's %s, a blood relative in the %s degree where %s rules exclude up to the %s",
+				relationshipTerm(degree, candidate.Gender), ordinalDegree(distance(degree)), rs.Name, ordinalDegree(rs.MaxBloodDegree)),
+		})
+	}
+
+	if rs.RelativeFamilyHops > 0 && relatedness.RelativeFamilyHops > 0 && relatedness.RelativeFamilyHops <= rs.RelativeFamilyHops {
+		violations = append(violations, models.RuleViolation{
+			RuleSet: rs.Name,
+			Rule:    RuleRelativeFamily,
+			Reason: fmt.Sprintf("the families are related through %d relative connection(s), where %s rules exclude families within %d",
+				relatedness.RelativeFamilyHops, rs.Name, rs.RelativeFamilyHops),
+		})
+	}
+
+	seeker, other := seekerFamily.Community, candidateFamily.Community
+	if rs.ExcludeSameGotra && sameValue(seeker.Gotra, other.Gotra) {
+		violations = append(violations, models.RuleViolation{
+			RuleSet: rs.Name,
+			Rule:    RuleSameGotra,
+			Reason:  fmt.Sprintf("both families belong to the %s gotra, and %s rules require marrying outside it", seeker.Gotra, rs.Name),
+		})
+	}
+	if rs.ExcludeSameSubCaste && sameValue(seeker.SubCaste, other.SubCaste) {
+		violations = append(violations, models.RuleViolation{
+			RuleSet: rs.Name,
+			Rule:    RuleSameSubCaste,
+			Reason:  fmt.Sprintf("both families belong to the %s sub-caste, and %s rules require marrying outside it", seeker.SubCaste, rs.Name),
+		})
+	}
+
+	return violations
+}
+
+// withinSapinda reports whether the ancestor is within the sapinda limit of both persons,
+// each counted as the first generation of their own line. An unknown line takes the wider limit.
+func (rs *RuleSet) withinSapinda(ancestor *CommonAncestor) bool {
+	seekerLimit := rs.sapindaLimit(ancestor.SeekerLine)
+	candidateLimit := rs.sapindaLimit(ancestor.CandidateLine)
+	return seekerLimit > 0 && candidateLimit > 0 &&
+		ancestor.SeekerGenerations+1 <= seekerLimit && ancestor.CandidateGenerations+1 <= candidateLimit
+}
+
+func (rs *RuleSet) sapindaLimit(line string) int {
+	switch line {
+	case LinePaternal:
+		return rs.SapindaPaternal
+	case LineMaternal:
+		return rs.SapindaMaternal
+	default:
+		return max(rs.SapindaPaternal, rs.SapindaMaternal)
+	}
+}
+
+// isCrossCousin reports whether the ancestor is a grandparent reached through the father on
+// one side and the mother on the other, making the linking parents a brother and a sister
+func isCrossCousin(ancestor *CommonAncestor) bool {
+	return ancestor.SeekerGenerations == 2 && ancestor.CandidateGenerations == 2 &&
+		ancestor.SeekerLine != "" && ancestor.CandidateLine != "" && ancestor.SeekerLine != ancestor.CandidateLine
+}
+
+// distance is the civil-law degree of relationship through the ancestor
+func distance(ancestor *CommonAncestor) int {
+	return ancestor.SeekerGenerations + ancestor.CandidateGenerations
+}
+
+// relationshipTerm names the candidate's relationship to the seeker in English, e.g. "first cousin"
+func relationshipTerm(ancestor *CommonAncestor, gender string) string {
+	hops := make([]kinship.Hop, 0, distance(ancestor))
+	for i := 0; i < ancestor.SeekerGenerations; i++ {
+		hops = append(hops, kinship.Hop{Kin: models.KinParent})
+	}
+	for i := 0; i < ancestor.CandidateGenerations; i++ {
+		hops = append(hops, kinship.Hop{Kin: models.KinChild})
+	}
+	if len(hops) > 0 {
+		hops[len(hops)-1].Gender = gender
+	}
+
+	relation := kinship.Reduce(time.Time{}, hops)
+	if term, ok := kinship.English.Term(relation); ok {
+		return term
+	}
+	return relation.Chain()
+}
+
+func lineName(line string) string {
+	switch line {
+	case LinePaternal:
+		return "paternal line"
+	case LineMaternal:
+		return "maternal line"
+	default:
+		return "line"
+	}
+}
+
+func ordinalDegree(degree int) string {
+	switch degree {
+	case 1:
+		return "1st"
+	case 2:
+		return "2nd"
+	case 3:
+		return "3rd"
+	default:
+		return fmt.Sprintf("%dth", degree)
+	}
+}
+
+func sameValue(a, b string) bool {
+	return a != "" && b != "" && strings.EqualFold(a, b)
+}
@@ -0,0 +1,81 @@
+package matching_test
+
+import (
+	"families-linkedin/internal/matching"
+	"families-linkedin/internal/models"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func ancestor(seekerGenerations, candidateGenerations int, seekerLine, candidateLine string) matching.CommonAncestor {
+	return matching.CommonAncestor{
+		AncestorID:           "ancestor",
+		SeekerGenerations:    seekerGenerations,
+		CandidateGenerations: candidateGenerations,
+		SeekerLine:           seekerLine,
+		CandidateLine:        candidateLine,
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	rules := matching.DefaultRules()
+	crossCousins := &matching.RuleSet{Name: "Dravidian", MaxBloodDegree: 4, AllowCrossCousins: true, ExcludeSameSubCaste: true}
+	paternal, maternal := matching.LinePaternal, matching.LineMaternal
+
+	tests := []struct {
+		name              string
+		rules             *matching.RuleSet
+		relatedness       matching.Relatedness
+		candidateGotra    string
+		candidateSubCaste string
+		want              []string
+		wantReason        string // A phrase the first violation's reason must contain
+	}{
+		{"Hindu paternal first cousins", rules.For("Hindu", ""), matching.Relatedness{CommonAncestors: []matching.CommonAncestor{ancestor(2, 2, paternal, paternal)}}, "", "", []string{matching.RuleSapinda}, "first cousin"},
+		{"Hindu paternal second cousins", rules.For("Hindu", ""), matching.Relatedness{CommonAncestors: []matching.CommonAncestor{ancestor(3, 3, paternal, paternal)}}, "", "", []string{matching.RuleSapinda}, "second cousin"},
+		{"Hindu maternal second cousins beyond the limit", rules.For("Hindu", ""), matching.Relatedness{CommonAncestors: []matching.CommonAncestor{ancestor(3, 3, maternal, maternal)}}, "", "", nil, ""},
+		{"Hindu limit of the narrower line", rules.For("Hindu", ""), matching.Relatedness{CommonAncestors: []matching.CommonAncestor{ancestor(3, 2, paternal, maternal)}}, "", "", []string{matching.RuleSapinda}, ""},
+		{"Hindu same gotra in any case", rules.For("Hindu", ""), matching.Relatedness{}, "kashyap", "", []string{matching.RuleSameGotra}, "Kashyap gotra"},
+		{"Hindu relative families within hops", rules.For("Hindu", ""), matching.Relatedness{RelativeFamilyHops: 2}, "", "", []string{matching.RuleRelativeFamily}, ""},
+		{"Hindu relative families beyond hops", rules.For("Hindu", ""), matching.Relatedness{RelativeFamilyHops: 3}, "", "", nil, ""},
+		{"default first cousins", rules.For("", ""), matching.Relatedness{CommonAncestors: []matching.CommonAncestor{ancestor(2, 2, "", "")}}, "", "", []string{matching.RuleBloodDegree}, "4th degree"},
+		{"default second cousins", rules.For("", ""), matching.Relatedness{CommonAncestors: []matching.CommonAncestor{ancestor(3, 3, "", "")}}, "", "", nil, ""},
+		{"default judged by the nearest ancestor", rules.For("", ""), matching.Relatedness{CommonAncestors: []matching.CommonAncestor{ancestor(2, 2, "", ""), ancestor(1, 1, "", "")}}, "", "", []string{matching.RuleBloodDegree}, "sister"},
+		{"Muslim first cousins", rules.For("Muslim", ""), matching.Relatedness{CommonAncestors: []matching.CommonAncestor{ancestor(2, 2, paternal, paternal)}}, "", "", nil, ""},
+		{"Muslim niece", rules.For("Muslim", ""), matching.Relatedness{CommonAncestors: []matching.CommonAncestor{ancestor(1, 2, "", "")}}, "", "", []string{matching.RuleBloodDegree}, "niece"},
+		{"cross cousins allowed", crossCousins, matching.Relatedness{CommonAncestors: []matching.CommonAncestor{ancestor(2, 2, paternal, maternal)}}, "", "", nil, ""},
+		{"parallel cousins excluded", crossCousins, matching.Relatedness{CommonAncestors: []matching.CommonAncestor{ancestor(2, 2, maternal, maternal)}}, "", "", []string{matching.RuleBloodDegree}, ""},
+		{"cross cousins with unknown lines excluded", crossCousins, matching.Relatedness{CommonAncestors: []matching.CommonAncestor{ancestor(2, 2, paternal, "")}}, "", "", []string{matching.RuleBloodDegree}, ""},
+		{"same sub-caste", crossCousins, matching.Relatedness{}, "", "Iyer", []string{matching.RuleSameSubCaste}, ""},
+		{"unrelated", rules.For("Hindu", ""), matching.Relatedness{}, "Bharadwaj", "Iyer", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seekerFamily := models.NewFamily("Seeker", "Seeker")
+			seekerFamily.Community.Gotra = "Kashyap"
+			seekerFamily.Community.SubCaste = "Iyer"
+			candidateFamily := models.NewFamily("Candidate", "Candidate")
+			candidateFamily.Community.Gotra = tt.candidateGotra
+			candidateFamily.Community.SubCaste = tt.candidateSubCaste
+			candidate := &models.Person{ID: "candidate", Gender: "Female"}
+
+			violations := tt.rules.Evaluate(candidate, seekerFamily, candidateFamily, tt.relatedness)
+
+			var got []string
+			for _, violation := range violations {
+				got = append(got, violation.Rule)
+				if violation.RuleSet != tt.rules.Name {
+					t.Errorf("violation of %s names rule set %q, want %q", violation.Rule, violation.RuleSet, tt.rules.Name)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("violations = %v, want %v", got, tt.want)
+			}
+			if tt.wantReason != "" && !strings.Contains(violations[0].Reason, tt.wantReason) {
+				t.Errorf("reason = %q, want it to mention %q", violations[0].Reason, tt.wantReason)
+			}
+		})
+	}
+}
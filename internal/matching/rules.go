@@ -0,0 +1,153 @@
+// Package matching holds the consanguinity and exogamy rules marriage matches must satisfy.
+// Each community, selected by religion and caste, has its own rule set.
+package matching
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Rules reported with every rejection
+const (
+	RuleSapinda        = "SAPINDA"         // A common ancestor within the sapinda limits of both persons
+	RuleBloodDegree    = "BLOOD_DEGREE"    // Blood relatives within a civil-law degree
+	RuleRelativeFamily = "RELATIVE_FAMILY" // Families joined by connections labelled as blood relatives
+	RuleSameGotra      = "SAME_GOTRA"
+	RuleSameSubCaste   = "SAME_SUB_CASTE"
+)
+
+// DefaultRelativeLabels are the connection labels treated as blood relationships between families
+var DefaultRelativeLabels = []string{"RELATIVE", "SIBLING", "SIBLINGS", "BROTHERS", "SISTERS", "COUSIN", "COUSINS"}
+
+// RuleSet is the consanguinity and exogamy policy of one community. Religion and Caste select
+// the families it applies to; empty matches any. Zero limits disable their rule.
+type RuleSet struct {
+	Name     string `json:"name"`
+	Religion string `json:"religion,omitempty"`
+	Caste    string `json:"caste,omitempty"`
+
+	// Sapinda limits in generations, counting the person as the first: two persons sharing an
+	// ancestor within the limit of each of them, along their own line of ascent through the
+	// father or the mother, are excluded. The Hindu Marriage Act sets 5 and 3.
+	SapindaPaternal int `json:"sapinda_paternal,omitempty"`
+	SapindaMaternal int `json:"sapinda_maternal,omitempty"`
+	// MaxBloodDegree excludes blood relatives within this civil-law degree, the parent-child
+	// links from one to the other through their nearest common ancestor: 1 for a parent, 2 for
+	// siblings, 3 for an uncle and niece, 4 for first cousins
+	MaxBloodDegree int `json:"max_blood_degree,omitempty"`
+	// AllowCrossCousins exempts first cousins whose linking parents are a brother and a sister,
+	// e.g. a mother's brother's daughter, from the blood relation rules
+	AllowCrossCousins bool `json:"allow_cross_cousins,omitempty"`
+
+	// RelativeFamilyHops excludes families joined to the seeker's by a chain of at most this
+	// many connections whose relation type or specific relation is in RelativeLabels
+	RelativeFamilyHops int      `json:"relative_family_hops,omitempty"`
+	RelativeLabels     []string `json:"relative_labels,omitempty"` // DefaultRelativeLabels when empty
+
+	ExcludeSameGotra    bool `json:"exclude_same_gotra,omitempty"`
+	ExcludeSameSubCaste bool `json:"exclude_same_sub_caste,omitempty"`
+}
+
+// AncestorGenerations is how many generations of ancestry the blood relation rules look at
+func (rs *RuleSet) AncestorGenerations() int {
+	return max(rs.SapindaPaternal-1, rs.SapindaMaternal-1, rs.MaxBloodDegree, 0)
+}
+
+// IsRelativeLabel reports whether a connection label marks a blood relationship
+func (rs *RuleSet) IsRelativeLabel(label string) bool {
+	labels := rs.RelativeLabels
+	if len(labels) == 0 {
+		labels = DefaultRelativeLabels
+	}
+	for _, relative := range labels {
+		if strings.EqualFold(relative, label) {
+			return true
+		}
+	}
+	return false
+}
+
+// matches reports how specifically the rule set applies to a community: 3 for its religion
+// and caste, 2 for its religion, 1 for its caste, 0 for a catch-all, and -1 when it does not
+func (rs *RuleSet) matches(religion, caste string) int {
+	if rs.Religion != "" && !strings.EqualFold(rs.Religion, religion) {
+		return -1
+	}
+	if rs.Caste != "" && !strings.EqualFold(rs.Caste, caste) {
+		return -1
+	}
+
+	specificity := 0
+	if rs.Religion != "" {
+		specificity += 2
+	}
+	if rs.Caste != "" {
+		specificity++
+	}
+	return specificity
+}
+
+func (rs *RuleSet) validate() error {
+	if rs.Name == "" {
+		return fmt.Errorf("rule set name is required")
+	}
+	if rs.SapindaPaternal < 0 || rs.SapindaMaternal < 0 || rs.MaxBloodDegree < 0 || rs.RelativeFamilyHops < 0 {
+		return fmt.Errorf("rule set %s: limits cannot be negative", rs.Name)
+	}
+	return nil
+}
+
+// Rules picks the rule set for a community
+type Rules struct {
+	sets []RuleSet
+}
+
+// NewRules creates rules from rule sets, checking each
+func NewRules(sets []RuleSet) (*Rules, error) {
+	for i := range sets {
+		if err := sets[i].validate(); err != nil {
+			return nil, err
+		}
+	}
+	return &Rules{sets: sets}, nil
+}
+
+// DefaultRules excludes blood relatives up to first cousins and families directly connected as
+// relatives, with stricter Hindu rules (sapinda limits of the Hindu Marriage Act and gotra
+// exogamy) and Muslim rules that permit cousin marriage.
+func DefaultRules() *Rules {
+	return &Rules{sets: []RuleSet{
+		{Name: "Default", MaxBloodDegree: 4, RelativeFamilyHops: 1},
+		{Name: "Hindu", Religion: "Hindu", SapindaPaternal: 5, SapindaMaternal: 3, RelativeFamilyHops: 2, ExcludeSameGotra: true},
+		{Name: "Muslim", Religion: "Muslim", MaxBloodDegree: 3},
+		{Name: "Christian", Religion: "Christian", MaxBloodDegree: 4, RelativeFamilyHops: 1},
+	}}
+}
+
+// LoadRules reads rule sets from a JSON file holding an array of them
+func LoadRules(path string) (*Rules, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read matching rules: %w", err)
+	}
+
+	var sets []RuleSet
+	if err := json.Unmarshal(data, &sets); err != nil {
+		return nil, fmt.Errorf("failed to parse matching rules: %w", err)
+	}
+	return NewRules(sets)
+}
+
+// For returns the most specific rule set for a religion and caste, or an empty rule set
+// excluding nobody when none applies
+func (r *Rules) For(religion, caste string) *RuleSet {
+	best, bestSpecificity := &RuleSet{Name: "None"}, -1
+	for i := range r.sets {
+		if specificity := r.sets[i].matches(religion, caste); specificity > bestSpecificity {
+			best, bestSpecificity = &r.sets[i], specificity
+		}
+	}
+	return best
+}
@@ -0,0 +1,92 @@
+package matching_test
+
+import (
+	"families-linkedin/internal/matching"
+	"testing"
+)
+
+func TestRulesFor(t *testing.T) {
+	rules, err := matching.NewRules([]matching.RuleSet{
+		{Name: "Default", MaxBloodDegree: 4},
+		{Name: "Brahmin", Caste: "Brahmin"},
+		{Name: "Hindu", Religion: "Hindu"},
+		{Name: "Hindu Brahmin", Religion: "Hindu", Caste: "Brahmin"},
+	})
+	if err != nil {
+		t.Fatalf("NewRules: %v", err)
+	}
+	noCatchAll, err := matching.NewRules([]matching.RuleSet{{Name: "Hindu", Religion: "Hindu"}})
+	if err != nil {
+		t.Fatalf("NewRules: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		rules    *matching.Rules
+		religion string
+		caste    string
+		want     string
+	}{
+		{"religion and caste", rules, "Hindu", "Brahmin", "Hindu Brahmin"},
+		{"religion over caste", rules, "Hindu", "Maratha", "Hindu"},
+		{"caste alone", rules, "Jain", "Brahmin", "Brahmin"},
+		{"catch-all", rules, "Jain", "", "Default"},
+		{"any case", rules, "hindu", "BRAHMIN", "Hindu Brahmin"},
+		{"nothing applies", noCatchAll, "Jain", "", "None"},
+		{"default rules catch-all", matching.DefaultRules(), "Sikh", "Jat", "Default"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rules.For(tt.religion, tt.caste).Name; got != tt.want {
+				t.Errorf("For(%q, %q) = %q, want %q", tt.religion, tt.caste, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewRulesValidates(t *testing.T) {
+	tests := []struct {
+		name    string
+		set     matching.RuleSet
+		wantErr bool
+	}{
+		{"valid", matching.RuleSet{Name: "Custom", SapindaPaternal: 7, SapindaMaternal: 5}, false},
+		{"no name", matching.RuleSet{MaxBloodDegree: 4}, true},
+		{"negative sapinda", matching.RuleSet{Name: "Custom", SapindaMaternal: -1}, true},
+		{"negative hops", matching.RuleSet{Name: "Custom", RelativeFamilyHops: -2}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := matching.NewRules([]matching.RuleSet{tt.set}); (err != nil) != tt.wantErr {
+				t.Errorf("NewRules error = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRuleSetLimits(t *testing.T) {
+	rules := matching.DefaultRules()
+
+	tests := []struct {
+		religion        string
+		wantGenerations int
+	}{
+		{"Hindu", 4}, // Sapinda limit of 5 counts the person as the first generation
+		{"Muslim", 3},
+		{"", 4},
+	}
+	for _, tt := range tests {
+		if got := rules.For(tt.religion, "").AncestorGenerations(); got != tt.wantGenerations {
+			t.Errorf("%q AncestorGenerations = %d, want %d", tt.religion, got, tt.wantGenerations)
+		}
+	}
+
+	hindu := rules.For("Hindu", "")
+	for label, want := range map[string]bool{"COUSIN": true, "siblings": true, "FRIEND": false, "BUSINESS": false} {
+		if got := hindu.IsRelativeLabel(label); got != want {
+			t.Errorf("IsRelativeLabel(%q) = %v, want %v", label, got, want)
+		}
+	}
+}
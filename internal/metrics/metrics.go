@@ -48,10 +48,22 @@ func RegisterMetrics(collector *Collector) {
 	collector.RegisterCounter("family_service_match_errors", "Number of match finding errors", nil)
 	collector.RegisterCounter("family_service_match_not_eligible", "Number of not eligible match requests", nil)
 	collector.RegisterCounter("family_service_match_success", "Number of successful match findings", nil)
+	collector.RegisterCounter("family_service_match_rejected", "Number of candidates rejected by consanguinity and exogamy rules", nil)
+	collector.RegisterCounter("family_service_match_unreciprocated", "Number of candidates whose preferences rule out the seeker in mutual matching", nil)
 	collector.RegisterCounter("family_service_trust_score_errors", "Number of trust score calculation errors", nil)
 	collector.RegisterCounter("family_service_trust_score_success", "Number of successful trust score calculations", nil)
 	collector.RegisterCounter("family_service_member_added", "Number of family members added", nil)
 	collector.RegisterCounter("family_service_connection_created", "Number of family connections created", nil)
+	collector.RegisterCounter("family_service_suggestion_success", "Number of successful family suggestion requests", nil)
+	collector.RegisterCounter("family_service_suggestion_errors", "Number of failed family suggestion requests and dismissals", nil)
+	collector.RegisterCounter("family_service_suggestion_dismissed", "Number of family suggestions dismissed", nil)
+	collector.RegisterCounter("family_service_relation_added", "Number of person relations added", nil)
+	collector.RegisterCounter("family_service_relation_validation_errors", "Number of person relation validation errors", nil)
+	collector.RegisterCounter("family_service_add_relation_errors", "Number of failed person relation additions", nil)
+	collector.RegisterCounter("family_service_get_relations_errors", "Number of failed person relation retrievals", nil)
+	collector.RegisterCounter("family_service_kinship_found", "Number of kinship paths found", nil)
+	collector.RegisterCounter("family_service_kinship_not_found", "Number of kinship searches that found no path", nil)
+	collector.RegisterCounter("family_service_kinship_errors", "Number of failed kinship searches", nil)
 
 	collector.RegisterHistogram("family_service_create_family", "Time taken to create a family", nil)
 	collector.RegisterHistogram("family_service_get_family", "Time taken to get a family", nil)
@@ -60,6 +72,10 @@ func RegisterMetrics(collector *Collector) {
 	collector.RegisterHistogram("family_service_search_families", "Time taken to search families", nil)
 	collector.RegisterHistogram("family_service_get_eligible_matches", "Time taken to find eligible matches", nil)
 	collector.RegisterHistogram("family_service_calculate_trust_score", "Time taken to calculate trust score", nil)
+	collector.RegisterHistogram("family_service_explain_trust_score", "Time taken to explain a trust score", nil)
+	collector.RegisterHistogram("family_service_get_suggestions", "Time taken to rank family suggestions", nil)
+	collector.RegisterHistogram("family_service_add_relation", "Time taken to add a person relation", nil)
+	collector.RegisterHistogram("family_service_kinship_path", "Time taken to find a kinship path", nil)
 
 	collector.RegisterGauge("family_service_search_results", "Number of results in last search", nil)
 	collector.RegisterGauge("family_service_matches_found", "Number of matches found in last request", nil)
@@ -74,10 +90,14 @@ func RegisterMetrics(collector *Collector) {
 	collector.RegisterCounter("connection_service_get_network_errors", "Number of failed network retrievals", nil)
 	collector.RegisterCounter("connection_service_find_common_success", "Number of successful common connection findings", nil)
 	collector.RegisterCounter("connection_service_find_common_errors", "Number of failed common connection findings", nil)
+	collector.RegisterCounter("connection_service_mutual_errors", "Number of failed mutual connection lists and counts", nil)
 	collector.RegisterCounter("connection_service_created", "Number of connections created", nil)
 	collector.RegisterCounter("connection_service_create_errors", "Number of connection creation errors", nil)
 	collector.RegisterCounter("connection_service_create_validation_errors", "Number of connection validation errors", nil)
 	collector.RegisterCounter("connection_service_create_cycle_errors", "Number of cycle detection errors", nil)
+	collector.RegisterCounter("connection_service_reconfirmed", "Number of connections reconfirmed", nil)
+	collector.RegisterCounter("connection_service_reconfirm_errors", "Number of connection reconfirmation errors", nil)
+	collector.RegisterCounter("connection_service_steiner_errors", "Number of failed group connection plans", nil)
 	collector.RegisterCounter("connection_service_get_stats_success", "Number of successful stats retrievals", nil)
 	collector.RegisterCounter("connection_service_get_stats_errors", "Number of failed stats retrievals", nil)
 	collector.RegisterCounter("connection_service_analyze_success", "Number of successful connection analyses", nil)
@@ -87,15 +107,93 @@ func RegisterMetrics(collector *Collector) {
 	collector.RegisterHistogram("connection_service_find_multiple_paths", "Time taken to find multiple paths", nil)
 	collector.RegisterHistogram("connection_service_get_network", "Time taken to get family network", nil)
 	collector.RegisterHistogram("connection_service_find_common", "Time taken to find common connections", nil)
+	collector.RegisterHistogram("connection_service_mutual", "Time taken to list mutual connections", nil)
+	collector.RegisterHistogram("connection_service_mutual_counts", "Time taken to count mutual connections", nil)
 	collector.RegisterHistogram("connection_service_create", "Time taken to create a connection", nil)
+	collector.RegisterHistogram("connection_service_reconfirm", "Time taken to reconfirm a connection", nil)
+	collector.RegisterHistogram("connection_service_steiner", "Time taken to plan the connections joining a group of families", nil)
 	collector.RegisterHistogram("connection_service_get_stats", "Time taken to get network stats", nil)
 	collector.RegisterHistogram("connection_service_analyze_strength", "Time taken to analyze connection strength", nil)
+	collector.RegisterHistogram("connection_service_find_path_round_trips", "Repository round trips per path search", nil)
+	collector.RegisterHistogram("connection_service_find_multiple_paths_round_trips", "Repository round trips per multiple path search", nil)
+	collector.RegisterHistogram("connection_service_steiner_round_trips", "Repository round trips per group connection plan", nil)
 
 	collector.RegisterGauge("connection_service_path_degree", "Degree of last found path", nil)
 	collector.RegisterGauge("connection_service_path_strength", "Strength of last found path", nil)
 	collector.RegisterGauge("connection_service_paths_count", "Number of paths in last multiple path request", nil)
 	collector.RegisterGauge("connection_service_network_size", "Size of last retrieved network", nil)
 	collector.RegisterGauge("connection_service_common_connections", "Number of common connections found", nil)
+	collector.RegisterGauge("connection_service_mutual_count", "Number of mutual connections in the last list request", nil)
+	collector.RegisterGauge("connection_service_steiner_edges", "Number of connections in the last group connection plan", nil)
+	collector.RegisterGauge("connection_service_steiner_intermediaries", "Number of intermediary families in the last group connection plan", nil)
+
+	// Graph snapshot metrics
+	collector.RegisterCounter("connection_service_graph_snapshot_refreshed", "Number of graph snapshot reloads", nil)
+	collector.RegisterCounter("connection_service_graph_snapshot_refresh_errors", "Number of failed graph snapshot reloads", nil)
+	collector.RegisterHistogram("connection_service_graph_snapshot_refresh", "Time taken to reload the graph snapshot", nil)
+	collector.RegisterGauge("connection_service_graph_snapshot_families", "Number of families in the graph snapshot", nil)
+	collector.RegisterGauge("connection_service_graph_snapshot_connections", "Number of connections in the graph snapshot", nil)
+	collector.RegisterGauge("connection_service_graph_snapshot_memory_bytes", "Approximate memory footprint of the graph snapshot", nil)
+	collector.RegisterGauge("connection_service_graph_snapshot_staleness_seconds", "Seconds since the graph snapshot was last reloaded", nil)
+
+	// Cache metrics
+	collector.RegisterCounter("path_cache_hits", "Number of path searches served from the cache", nil)
+	collector.RegisterCounter("path_cache_misses", "Number of path searches not found in the cache", nil)
+	collector.RegisterCounter("path_cache_coalesced", "Number of path searches that waited on an identical search in flight", nil)
+	collector.RegisterCounter("path_cache_invalidations", "Number of cached path searches dropped after a connection change", nil)
+	collector.RegisterCounter("path_cache_remote_invalidations", "Number of connection changes received from other replicas", nil)
+	collector.RegisterCounter("path_cache_errors", "Number of path cache reads, writes and invalidations that failed", nil)
+	collector.RegisterCounter("family_cache_hits", "Number of family lookups served from the cache", nil)
+	collector.RegisterCounter("family_cache_misses", "Number of family lookups not found in the cache", nil)
+	collector.RegisterCounter("family_cache_errors", "Number of family cache reads, writes and invalidations that failed", nil)
+	collector.RegisterCounter("cache_evictions", "Number of values evicted from the memory cache to stay within capacity", nil)
+	collector.RegisterCounter("cache_expirations", "Number of values dropped from the memory cache after their TTL", nil)
+	collector.RegisterCounter("cache_backend_errors", "Number of failed commands sent to the Redis cache", nil)
+	collector.RegisterCounter("cache_subscription_errors", "Number of dropped Redis cache subscriptions", nil)
+	collector.RegisterGauge("cache_entries", "Number of values in the memory cache", nil)
+	collector.RegisterCounter("connection_service_cache_invalidation_errors", "Number of path cache invalidations that failed after creating a connection", nil)
+
+	// Graph analytics metrics
+	collector.RegisterHistogram("connection_service_centrality", "Time taken to recompute graph centrality", nil)
+	collector.RegisterCounter("connection_service_centrality_success", "Number of successful centrality recomputations", nil)
+	collector.RegisterCounter("connection_service_centrality_errors", "Number of failed centrality recomputations", nil)
+	collector.RegisterGauge("connection_service_centrality_families", "Number of families scored in the last centrality recomputation", nil)
+	collector.RegisterHistogram("connection_service_communities", "Time taken to detect communities", nil)
+	collector.RegisterCounter("connection_service_communities_success", "Number of successful community detection runs", nil)
+	collector.RegisterCounter("connection_service_communities_errors", "Number of failed community detection runs", nil)
+	collector.RegisterGauge("connection_service_communities_count", "Number of communities found by the last detection run", nil)
+	collector.RegisterGauge("connection_service_communities_modularity", "Strength-weighted modularity of the last community partition", nil)
+	collector.RegisterGauge("connection_service_communities_largest", "Number of families in the largest community", nil)
+	collector.RegisterGauge("connection_service_communities_singletons", "Number of families left in a community of their own", nil)
+	collector.RegisterHistogram("connection_service_get_communities", "Time taken to list communities", nil)
+	collector.RegisterHistogram("connection_service_trust", "Time taken to recompute propagated trust scores", nil)
+	collector.RegisterCounter("connection_service_trust_success", "Number of successful propagated trust recomputations", nil)
+	collector.RegisterCounter("connection_service_trust_errors", "Number of failed propagated trust recomputations", nil)
+	collector.RegisterCounter("connection_service_trust_skipped", "Number of trust recomputations skipped for lack of staff-verified families", nil)
+	collector.RegisterGauge("connection_service_trust_seeds", "Number of staff-verified families seeding the last trust recomputation", nil)
+	collector.RegisterCounter("connection_service_get_communities_errors", "Number of failed community list requests", nil)
+	collector.RegisterHistogram("connection_service_get_connectors", "Time taken to list top connectors", nil)
+	collector.RegisterHistogram("connection_service_fragility", "Time taken to analyze network fragility", nil)
+	collector.RegisterCounter("connection_service_fragility_errors", "Number of failed network fragility analyses", nil)
+	collector.RegisterGauge("connection_service_components", "Number of connected components among active families", nil)
+	collector.RegisterGauge("connection_service_largest_component_coverage", "Share of active families in the largest connected component", nil)
+	collector.RegisterGauge("connection_service_articulation_points", "Number of families whose departure would split the network", nil)
+	collector.RegisterGauge("connection_service_bridges", "Number of connections whose removal would split the network", nil)
+	collector.RegisterCounter("connection_service_get_connectors_errors", "Number of failed top connector requests", nil)
+	collector.RegisterHistogram("connection_service_landmarks", "Time taken to build the landmark distance oracle", nil)
+	collector.RegisterCounter("connection_service_landmarks_success", "Number of successful landmark distance oracle builds", nil)
+	collector.RegisterCounter("connection_service_landmarks_errors", "Number of failed landmark distance oracle builds", nil)
+	collector.RegisterGauge("connection_service_landmarks_count", "Number of landmarks in the distance oracle", nil)
+	collector.RegisterHistogram("connection_service_degrees", "Time taken to look up degrees of separation", nil)
+	collector.RegisterCounter("connection_service_degrees_errors", "Number of failed degree of separation lookups", nil)
+	collector.RegisterCounter("connection_service_degrees_oracle_hits", "Number of degrees of separation settled by landmark bounds alone", nil)
+	collector.RegisterCounter("connection_service_degrees_searches", "Number of degrees of separation that needed a path search", nil)
+
+	// Introduction service metrics
+	collector.RegisterHistogram("introduction_service_recommend", "Time taken to recommend introductions", nil)
+	collector.RegisterCounter("introduction_service_recommend_success", "Number of successful introduction recommendations", nil)
+	collector.RegisterCounter("introduction_service_recommend_errors", "Number of failed introduction recommendations", nil)
+	collector.RegisterGauge("introduction_service_candidates", "Number of families recommended in the last introduction request", nil)
 
 	// Neo4j database metrics
 	collector.RegisterGauge("neo4j_total_nodes", "Total number of nodes in Neo4j", nil)
@@ -210,6 +308,16 @@ func (c *Collector) RecordDuration(name string, start time.Time) {
 	}
 }
 
+// ObserveValue records a value in a histogram metric
+func (c *Collector) ObserveValue(name string, value float64) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	if histogram, exists := c.histograms[name]; exists {
+		histogram.Observe(value)
+	}
+}
+
 // RecordValue sets a value in a gauge metric
 func (c *Collector) RecordValue(name string, value float64) {
 	c.mutex.RLock()
@@ -0,0 +1,85 @@
+package models
+
+import (
+	"math"
+	"time"
+)
+
+// Strength decay functions
+const (
+	DecayNone        = "none"
+	DecayExponential = "exponential" // Strength halves every HalfLife
+	DecayStep        = "step"        // Strength is multiplied by StepFactor every full StepInterval
+)
+
+// IsValidDecayFunction reports whether function names a supported decay function
+func IsValidDecayFunction(function string) bool {
+	return function == DecayNone || function == DecayExponential || function == DecayStep
+}
+
+// StrengthDecay discounts a connection's strength by the time since it was last confirmed.
+// Decay is applied when strengths are read; the stored strength never changes. A nil
+// decay leaves strengths unchanged.
+type StrengthDecay struct {
+	Function     string
+	HalfLife     time.Duration // Exponential decay
+	StepInterval time.Duration // Step decay
+	StepFactor   float64       // Step decay
+	MinFactor    float64       // Share of the strength no connection decays below
+}
+
+// Factor returns the share of strength left after age
+func (d *StrengthDecay) Factor(age time.Duration) float64 {
+	if d == nil || age <= 0 {
+		return 1
+	}
+
+	factor := 1.0
+	switch d.Function {
+	case DecayExponential:
+		if d.HalfLife > 0 {
+			factor = math.Exp2(-float64(age) / float64(d.HalfLife))
+		}
+	case DecayStep:
+		if d.StepInterval > 0 {
+			factor = math.Pow(d.StepFactor, math.Floor(float64(age)/float64(d.StepInterval)))
+		}
+	}
+
+	return math.Min(1, math.Max(factor, d.MinFactor))
+}
+
+// LastConfirmedAt returns when the connection was created or last reconfirmed, falling
+// back to the established date for records without a creation time
+func (fc *FamilyConnection) LastConfirmedAt() time.Time {
+	confirmed := fc.CreatedAt
+	if confirmed.IsZero() {
+		confirmed = fc.EstablishedDate
+	}
+	if fc.ReconfirmedAt.After(confirmed) {
+		confirmed = fc.ReconfirmedAt
+	}
+	return confirmed
+}
+
+// DecayedStrength returns the connection's strength discounted by the time since it was last confirmed
+func (fc *FamilyConnection) DecayedStrength(decay *StrengthDecay, now time.Time) float64 {
+	confirmed := fc.LastConfirmedAt()
+	if confirmed.IsZero() {
+		return fc.Strength
+	}
+	return fc.Strength * decay.Factor(now.Sub(confirmed))
+}
+
+// AverageDecayedStrength returns the mean decayed strength of connections, or 0 without any
+func AverageDecayedStrength(connections []*FamilyConnection, decay *StrengthDecay, now time.Time) float64 {
+	if len(connections) == 0 {
+		return 0
+	}
+
+	total := 0.0
+	for _, connection := range connections {
+		total += connection.DecayedStrength(decay, now)
+	}
+	return total / float64(len(connections))
+}
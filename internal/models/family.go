@@ -16,6 +16,7 @@ type Family struct {
 	ContactInfo  ContactInfo `json:"contact_info"`
 	Verification Verification `json:"verification"`
 	TrustScore   float64   `json:"trust_score" neo4j:"trust_score"`
+	Centrality   Centrality `json:"centrality"`
 	PrivacySettings PrivacySettings `json:"privacy_settings"`
 	CreatedAt    time.Time `json:"created_at" neo4j:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at" neo4j:"updated_at"`
@@ -26,16 +27,17 @@ type Location struct {
 	City        string    `json:"city" neo4j:"city"`
 	State       string    `json:"state" neo4j:"state"`
 	Country     string    `json:"country" neo4j:"country"`
-	Coordinates []float64 `json:"coordinates" neo4j:"coordinates"`
+	Coordinates []float64 `json:"coordinates" neo4j:"coordinates"` // [latitude, longitude]
 	Region      string    `json:"region" neo4j:"region"`
 }
 
 type Community struct {
 	Caste      string   `json:"caste" neo4j:"caste"`
 	SubCaste   string   `json:"sub_caste" neo4j:"sub_caste"`
+	Gotra      string   `json:"gotra,omitempty" neo4j:"gotra"` // Exogamous clan of Hindu families
 	Religion   string   `json:"religion" neo4j:"religion"`
 	Languages  []string `json:"languages" neo4j:"languages"`
-	CommunityGroup int  `json:"community_group" neo4j:"community_group"`
+	CommunityGroup int  `json:"community_group" neo4j:"community_id"` // Detected community, 0 until the analytics batch runs
 }
 
 type ContactInfo struct {
@@ -44,12 +46,25 @@ type ContactInfo struct {
 	Address      string `json:"address" neo4j:"address"`
 }
 
+// VerifiedByStaff marks families verified by staff rather than the community. They seed
+// the propagated trust score.
+const VerifiedByStaff = "staff"
+
 type Verification struct {
 	Status       string    `json:"status" neo4j:"verification_status"`
 	VerifiedBy   string    `json:"verified_by" neo4j:"verified_by"`
 	VerificationDate time.Time `json:"verification_date" neo4j:"verification_date"`
 }
 
+// Centrality holds the family's position in the FAMILY_RELATION graph, recomputed in batch
+type Centrality struct {
+	PageRank         float64   `json:"pagerank" neo4j:"pagerank"`
+	Betweenness      float64   `json:"betweenness" neo4j:"betweenness"` // Share of shortest paths between other families passing through this one
+	DegreeCentrality float64   `json:"degree_centrality" neo4j:"degree_centrality"`
+	Degree           int       `json:"degree" neo4j:"degree"`
+	CalculatedAt     time.Time `json:"calculated_at" neo4j:"centrality_calculated_at"`
+}
+
 type PrivacySettings struct {
 	ProfileVisibility string `json:"profile_visibility" neo4j:"profile_visibility"`
 	ContactSharing    string `json:"contact_sharing" neo4j:"contact_sharing"`
@@ -102,6 +117,33 @@ type FamilySearchCriteria struct {
 	Offset       int      `json:"offset,omitempty"`
 }
 
+// ConnectorCriteria filters the families ranked as key connectors
+type ConnectorCriteria struct {
+	Region string `json:"region,omitempty"`
+	Caste  string `json:"caste,omitempty"`
+	Limit  int    `json:"limit,omitempty"`
+}
+
+// FamilySuggestion is a family ranked as a likely new connection for another family
+type FamilySuggestion struct {
+	Family            *Family  `json:"family"`
+	Score             float64  `json:"score"`
+	CommonConnections int      `json:"common_connections"`
+	AdamicAdar        float64  `json:"adamic_adar"` // Common connections weighted towards those with few connections
+	Jaccard           float64  `json:"jaccard"`     // Share of the two families' combined connections they have in common
+	MutualFamilyIDs   []string `json:"mutual_family_ids"`
+	Reasons           []string `json:"reasons"`
+}
+
+// MutualConnection is a family directly connected to both of two families
+type MutualConnection struct {
+	Family           *Family           `json:"family,omitempty"`
+	FamilyID         string            `json:"family_id"`
+	ToFamily1        *FamilyConnection `json:"connection_to_family1"` // Oriented from the first family
+	ToFamily2        *FamilyConnection `json:"connection_to_family2"` // Oriented from the second family
+	CombinedStrength float64           `json:"combined_strength"`     // Decayed strength of the two-hop path through the family
+}
+
 // FamilyConnection represents a connection between families with metadata
 type FamilyConnection struct {
 	FromFamilyID    string            `json:"from_family_id"`
@@ -113,6 +155,7 @@ type FamilyConnection struct {
 	EstablishedDate time.Time         `json:"established_date"`
 	Metadata        map[string]interface{} `json:"metadata,omitempty"`
 	CreatedAt       time.Time         `json:"created_at"`
+	ReconfirmedAt   time.Time         `json:"reconfirmed_at,omitempty"` // Last time the families reconfirmed the connection, resetting its decay
 }
 
 // NewFamilyConnection creates a new family connection
@@ -138,14 +181,56 @@ func (fc *FamilyConnection) IsStrong() bool {
 
 // ConnectionPath represents a path between two families through intermediary connections
 type ConnectionPath struct {
-	SourceFamilyID string    `json:"source_family_id"`
-	TargetFamilyID string    `json:"target_family_id"`
-	Path           []string  `json:"path"` // Family IDs in order
-	Degree         int       `json:"degree"`
-	PathStrength   float64   `json:"path_strength"`
-	RelationTypes  []string  `json:"relation_types"`
-	Verified       bool      `json:"verified"` // True if all connections in path are verified
-	CalculatedAt   time.Time `json:"calculated_at"`
+	SourceFamilyID   string     `json:"source_family_id"`
+	TargetFamilyID   string     `json:"target_family_id"`
+	Path             []string   `json:"path"` // Family IDs in order
+	Degree           int        `json:"degree"`
+	PathStrength     float64    `json:"path_strength"`
+	RelationTypes    []string   `json:"relation_types"`
+	SpecificRelation string     `json:"specific_relation,omitempty"` // Kinship the path amounts to when every connection names one, e.g. "SIBLING_IN_LAW"
+	Edges            []PathEdge `json:"edges"`                       // One entry per hop, in path order
+	Verified         bool       `json:"verified"`                    // True if all connections in path are verified
+	CalculatedAt     time.Time  `json:"calculated_at"`
+}
+
+// PathEdge describes a single hop of a connection path
+type PathEdge struct {
+	FromFamilyID     string    `json:"from_family_id"`
+	ToFamilyID       string    `json:"to_family_id"`
+	RelationType     string    `json:"relation_type"`
+	SpecificRelation string    `json:"specific_relation"`
+	Strength         float64   `json:"strength"`
+	DecayedStrength  float64   `json:"decayed_strength"` // Strength after time decay, used for the path strength
+	Verified         bool      `json:"verified"`
+	EstablishedDate  time.Time `json:"established_date"`
+	LastConfirmedAt  time.Time `json:"last_confirmed_at"`
+}
+
+// SteinerTree is a small tree of connections joining a group of families
+type SteinerTree struct {
+	FamilyIDs            []string   `json:"family_ids"`             // The families to connect
+	IntermediaryIDs      []string   `json:"intermediary_ids"`       // Other families the tree passes through
+	UnreachableFamilyIDs []string   `json:"unreachable_family_ids"` // Families no path within the search depth reached
+	Edges                []PathEdge `json:"edges"`
+	TotalStrength        float64    `json:"total_strength"` // Product of the decayed edge strengths
+	Verified             bool       `json:"verified"`       // True if all connections in the tree are verified
+	CalculatedAt         time.Time  `json:"calculated_at"`
+}
+
+// NewPathEdge creates a path edge from a family connection. The decayed strength
+// starts out equal to the stored strength.
+func NewPathEdge(connection *FamilyConnection) PathEdge {
+	return PathEdge{
+		FromFamilyID:     connection.FromFamilyID,
+		ToFamilyID:       connection.ToFamilyID,
+		RelationType:     connection.RelationType,
+		SpecificRelation: connection.SpecificRelation,
+		Strength:         connection.Strength,
+		DecayedStrength:  connection.Strength,
+		Verified:         connection.Verified,
+		EstablishedDate:  connection.EstablishedDate,
+		LastConfirmedAt:  connection.LastConfirmedAt(),
+	}
 }
 
 // NewConnectionPath creates a new connection path
@@ -160,25 +245,41 @@ func NewConnectionPath(sourceID, targetID string, path []string, relationTypes [
 	}
 }
 
-// CalculatePathStrength calculates the overall strength of the path
-func (cp *ConnectionPath) CalculatePathStrength(connections []FamilyConnection) {
+// CalculatePathStrength calculates the overall strength of the path and records
+// the per-hop edges and relation types from the connections along it. Each connection's
+// strength is discounted by decay as of the path's calculation time; nil applies none.
+func (cp *ConnectionPath) CalculatePathStrength(connections []FamilyConnection, decay *StrengthDecay) {
 	if len(connections) == 0 {
 		cp.PathStrength = 0
 		return
 	}
 
+	now := cp.CalculatedAt
+	if now.IsZero() {
+		now = time.Now()
+	}
+
 	strength := 1.0
 	allVerified := true
+	edges := make([]PathEdge, 0, len(connections))
+	relationTypes := make([]string, 0, len(connections))
 
-	for _, conn := range connections {
-		strength *= conn.Strength
+	for i := range connections {
+		conn := &connections[i]
+		edge := NewPathEdge(conn)
+		edge.DecayedStrength = conn.DecayedStrength(decay, now)
+		strength *= edge.DecayedStrength
 		if !conn.Verified {
 			allVerified = false
 		}
+		edges = append(edges, edge)
+		relationTypes = append(relationTypes, conn.RelationType)
 	}
 
 	cp.PathStrength = strength
 	cp.Verified = allVerified
+	cp.Edges = edges
+	cp.RelationTypes = relationTypes
 }
 
 // IsValidPath checks if the path has no cycles and meets basic criteria
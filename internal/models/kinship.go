@@ -0,0 +1,120 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// Person-to-person relationship types
+const (
+	RelationParentOf = "PARENT_OF" // From the parent to the child
+	RelationSpouseOf = "SPOUSE_OF" // Symmetric; stored once in either direction
+)
+
+// IsValidPersonRelation reports whether relationType names a person-to-person relationship
+func IsValidPersonRelation(relationType string) bool {
+	return relationType == RelationParentOf || relationType == RelationSpouseOf
+}
+
+// Kinship steps, each seen from the person the step starts at
+const (
+	KinParent = "PARENT"
+	KinChild  = "CHILD"
+	KinSpouse = "SPOUSE"
+)
+
+// PersonRelation is a PARENT_OF or SPOUSE_OF edge between two persons
+type PersonRelation struct {
+	FromPersonID string    `json:"from_person_id"` // The parent, for PARENT_OF
+	ToPersonID   string    `json:"to_person_id"`   // The child, for PARENT_OF
+	Type         string    `json:"type"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// NewPersonRelation creates a relationship between two persons
+func NewPersonRelation(fromPersonID, toPersonID, relationType string) *PersonRelation {
+	return &PersonRelation{
+		FromPersonID: fromPersonID,
+		ToPersonID:   toPersonID,
+		Type:         relationType,
+		CreatedAt:    time.Now(),
+	}
+}
+
+// Other returns the person at the other end of the relationship from personID
+func (pr *PersonRelation) Other(personID string) string {
+	if pr.FromPersonID == personID {
+		return pr.ToPersonID
+	}
+	return pr.FromPersonID
+}
+
+// KinFrom returns the step from personID across the relationship: KinChild when personID
+// is the parent, KinParent when personID is the child, KinSpouse for spouses
+func (pr *PersonRelation) KinFrom(personID string) string {
+	switch {
+	case pr.Type == RelationSpouseOf:
+		return KinSpouse
+	case pr.FromPersonID == personID:
+		return KinChild
+	default:
+		return KinParent
+	}
+}
+
+// Joins reports whether the relationship is between the two persons, in either direction
+func (pr *PersonRelation) Joins(person1ID, person2ID string) bool {
+	return (pr.FromPersonID == person1ID && pr.ToPersonID == person2ID) ||
+		(pr.FromPersonID == person2ID && pr.ToPersonID == person1ID)
+}
+
+// KinshipStep is one relationship along a kinship path
+type KinshipStep struct {
+	FromPersonID string `json:"from_person_id"`
+	ToPersonID   string `json:"to_person_id"`
+	Kin          string `json:"kin"`  // What ToPersonID is to FromPersonID: KinParent, KinChild or KinSpouse
+	Term         string `json:"term"` // Kin named for ToPersonID's gender, e.g. "mother"
+}
+
+// KinshipPath is the shortest chain of relationships from one person to another
+type KinshipPath struct {
+	FromPersonID string        `json:"from_person_id"`
+	ToPersonID   string        `json:"to_person_id"`
+	PersonIDs    []string      `json:"person_ids"` // Persons in order
+	Steps        []KinshipStep `json:"steps"`
+	Relationship string        `json:"relationship"` // The reduced chain, e.g. "mother's brother's son"
+	Notation     string        `json:"notation"`     // The reduced chain in genealogical notation, e.g. "MBS"
+	Term         string        `json:"term"`         // The relationship's name in Locale, e.g. "first cousin" or "mama ka beta"
+	Locale       string        `json:"locale"`
+	CalculatedAt time.Time     `json:"calculated_at"`
+}
+
+// kinTerms names each kinship step for a male, a female and an unknown gender
+var kinTerms = map[string][3]string{
+	KinParent: {"father", "mother", "parent"},
+	KinChild:  {"son", "daughter", "child"},
+	KinSpouse: {"husband", "wife", "spouse"},
+}
+
+// KinTerm names a kinship step for the gender of the person it leads to
+func KinTerm(kin, gender string) string {
+	terms, ok := kinTerms[kin]
+	if !ok {
+		return strings.ToLower(kin)
+	}
+	switch gender {
+	case "Male":
+		return terms[0]
+	case "Female":
+		return terms[1]
+	default:
+		return terms[2]
+	}
+}
+
+// Describe names every step for the gender of the person it leads to
+func (kp *KinshipPath) Describe(genders map[string]string) {
+	for i := range kp.Steps {
+		kp.Steps[i].Term = KinTerm(kp.Steps[i].Kin, genders[kp.Steps[i].ToPersonID])
+	}
+}
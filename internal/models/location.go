@@ -0,0 +1,84 @@
+package models
+
+import (
+	"math"
+	"strings"
+)
+
+// earthRadiusKm is the mean radius of the Earth used for great-circle distances
+const earthRadiusKm = 6371.0
+
+// cityCentroids locates families that have a city but no coordinates, as [latitude, longitude]
+var cityCentroids = map[string][2]float64{
+	"mumbai":             {19.0760, 72.8777},
+	"delhi":              {28.6139, 77.2090},
+	"new delhi":          {28.6139, 77.2090},
+	"bangalore":          {12.9716, 77.5946},
+	"bengaluru":          {12.9716, 77.5946},
+	"chennai":            {13.0827, 80.2707},
+	"kolkata":            {22.5726, 88.3639},
+	"hyderabad":          {17.3850, 78.4867},
+	"pune":               {18.5204, 73.8567},
+	"ahmedabad":          {23.0225, 72.5714},
+	"jaipur":             {26.9124, 75.7873},
+	"lucknow":            {26.8467, 80.9462},
+	"kochi":              {9.9312, 76.2673},
+	"indore":             {22.7196, 75.8577},
+	"surat":              {21.1702, 72.8311},
+	"nagpur":             {21.1458, 79.0882},
+	"bhopal":             {23.2599, 77.4126},
+	"patna":              {25.5941, 85.1376},
+	"chandigarh":         {30.7333, 76.7794},
+	"coimbatore":         {11.0168, 76.9558},
+	"madurai":            {9.9252, 78.1198},
+	"thiruvananthapuram": {8.5241, 76.9366},
+	"visakhapatnam":      {17.6868, 83.2185},
+	"vadodara":           {22.3072, 73.1812},
+	"varanasi":           {25.3176, 82.9739},
+	"kanpur":             {26.4499, 80.3319},
+	"guwahati":           {26.1445, 91.7362},
+	"bhubaneswar":        {20.2961, 85.8245},
+	"mysore":             {12.2958, 76.6394},
+	"mangalore":          {12.9141, 74.8560},
+}
+
+// Point returns the location's latitude and longitude: its coordinates when recorded as
+// [latitude, longitude], otherwise the centroid of its city. It returns false when neither is known.
+func (l Location) Point() (float64, float64, bool) {
+	if len(l.Coordinates) == 2 {
+		return l.Coordinates[0], l.Coordinates[1], true
+	}
+	if centroid, ok := cityCentroids[strings.ToLower(strings.TrimSpace(l.City))]; ok {
+		return centroid[0], centroid[1], true
+	}
+	return 0, 0, false
+}
+
+// DistanceKm is the great-circle distance between two locations by the haversine formula.
+// It returns false when either location cannot be placed.
+func (l Location) DistanceKm(other Location) (float64, bool) {
+	lat1, lon1, ok := l.Point()
+	if !ok {
+		return 0, false
+	}
+	lat2, lon2, ok := other.Point()
+	if !ok {
+		return 0, false
+	}
+
+	dLat := radians(lat2 - lat1)
+	dLon := radians(lon2 - lon1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(radians(lat1))*math.Cos(radians(lat2))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(a))), true
+}
+
+// Matches reports whether a preferred location names the location's city, state or region
+func (l Location) Matches(preferred string) bool {
+	return preferred != "" && (strings.EqualFold(preferred, l.City) ||
+		strings.EqualFold(preferred, l.State) || strings.EqualFold(preferred, l.Region))
+}
+
+func radians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
@@ -0,0 +1,100 @@
+package models
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestLocationDistanceKm(t *testing.T) {
+	tests := []struct {
+		name   string
+		from   Location
+		to     Location
+		wantKm float64 // Great-circle distance, within 1%
+		wantOK bool
+	}{
+		{"coordinates", Location{Coordinates: []float64{19.0760, 72.8777}}, Location{Coordinates: []float64{28.6139, 77.2090}}, 1148, true},
+		{"city centroids", Location{City: "Mumbai"}, Location{City: "Delhi"}, 1148, true},
+		{"centroid lookup ignores case", Location{City: " pune "}, Location{City: "MUMBAI"}, 120, true},
+		{"coordinates win over city", Location{City: "Delhi", Coordinates: []float64{19.0760, 72.8777}}, Location{City: "Mumbai"}, 0, true},
+		{"same place", Location{City: "Chennai"}, Location{City: "Chennai"}, 0, true},
+		{"unknown city", Location{City: "Atlantis"}, Location{City: "Mumbai"}, 0, false},
+		{"malformed coordinates", Location{Coordinates: []float64{19.0760}}, Location{City: "Mumbai"}, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.from.DistanceKm(tt.to)
+			if ok != tt.wantOK {
+				t.Fatalf("DistanceKm ok = %v, want %v", ok, tt.wantOK)
+			}
+			if math.Abs(got-tt.wantKm) > math.Max(1, tt.wantKm*0.01) {
+				t.Errorf("DistanceKm = %.1f, want %.0f", got, tt.wantKm)
+			}
+		})
+	}
+}
+
+func TestLocationMatches(t *testing.T) {
+	location := Location{City: "Pune", State: "Maharashtra", Region: "West"}
+	for preferred, want := range map[string]bool{"pune": true, "Maharashtra": true, "WEST": true, "Mumbai": false, "": false} {
+		if got := location.Matches(preferred); got != want {
+			t.Errorf("Matches(%q) = %v, want %v", preferred, got, want)
+		}
+	}
+}
+
+func TestMatchesPreferencesOnFamilies(t *testing.T) {
+	family := func(city, caste string) *Family {
+		return &Family{Location: Location{City: city}, Community: Community{Caste: caste}}
+	}
+	seekerFamily := family("Pune", "Brahmin")
+	candidate := NewPerson("candidate", "Meera", "", "Female", time.Now().AddDate(-27, 0, 0))
+
+	tests := []struct {
+		name            string
+		candidateFamily *Family
+		wantUnmet       []string
+	}{
+		{"all met", family("Mumbai", "brahmin"), nil},
+		{"other caste", family("Pune", "Maratha"), []string{PreferenceCaste}},
+		{"too far", family("Delhi", "Brahmin"), []string{PreferenceLocation, PreferenceDistance}},
+		{"cannot be placed, distance unchecked", family("Atlantis", "Brahmin"), []string{PreferenceLocation}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seeker := NewPerson("seeker", "Arjun", "", "Male", time.Now().AddDate(-28, 0, 0))
+			seeker.Preferences = MarriagePreferences{
+				PreferredAgeRange: [2]int{22, 32},
+				PreferredCaste:    []string{"Brahmin"},
+				PreferredLocation: []string{"Pune", "Mumbai"},
+				MaxDistance:       200,
+			}
+
+			if got := seeker.UnmetPreferences(candidate, seekerFamily, tt.candidateFamily); !reflect.DeepEqual(got, tt.wantUnmet) {
+				t.Errorf("UnmetPreferences = %v, want %v", got, tt.wantUnmet)
+			}
+
+			// Strict seekers drop candidates missing any preference
+			if got, want := seeker.MatchesPreferences(candidate, seekerFamily, tt.candidateFamily), len(tt.wantUnmet) == 0; got != want {
+				t.Errorf("strict MatchesPreferences = %v, want %v", got, want)
+			}
+
+			// Flexible seekers keep them, ranked lower
+			seeker.Preferences.FlexibleOnRequirements = true
+			if !seeker.MatchesPreferences(candidate, seekerFamily, tt.candidateFamily) {
+				t.Errorf("flexible MatchesPreferences = false, want true")
+			}
+			match := NewEligibleMatch(candidate, tt.candidateFamily, nil)
+			match.CalculateCompatibilityScore(seeker, seekerFamily)
+			perfect := NewEligibleMatch(candidate, family("Pune", "Brahmin"), nil)
+			perfect.CalculateCompatibilityScore(seeker, seekerFamily)
+			if len(tt.wantUnmet) > 0 && match.CompatibilityScore >= perfect.CompatibilityScore {
+				t.Errorf("score %.1f missing %v, want below %.1f", match.CompatibilityScore, tt.wantUnmet, perfect.CompatibilityScore)
+			}
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package models
 
 import (
+	"math"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -116,54 +118,134 @@ func (p *Person) GetFullName() string {
 	return p.FirstName + " " + p.LastName
 }
 
-// MatchesPreferences checks if another person matches this person's preferences
-func (p *Person) MatchesPreferences(other *Person) bool {
-	// Age preference check
-	if other.Age < p.Preferences.PreferredAgeRange[0] || other.Age > p.Preferences.PreferredAgeRange[1] {
-		if !p.Preferences.FlexibleOnRequirements {
-			return false
+// Preferences a candidate can leave unmet
+const (
+	PreferenceAge        = "age"
+	PreferenceEducation  = "education"
+	PreferenceProfession = "profession"
+	PreferenceIncome     = "income"
+	PreferenceCaste      = "caste"
+	PreferenceLocation   = "location"
+	PreferenceDistance   = "distance"
+)
+
+// MatchesPreferences checks if another person matches this person's preferences. Caste,
+// location and distance preferences are judged by the two persons' families and skipped when
+// either family is nil. Preferences are hard filters unless the person is flexible on them,
+// when a candidate missing them is still suggested, ranked by how many they meet.
+func (p *Person) MatchesPreferences(other *Person, family, otherFamily *Family) bool {
+	return p.Preferences.FlexibleOnRequirements || len(p.UnmetPreferences(other, family, otherFamily)) == 0
+}
+
+// UnmetPreferences lists this person's preferences another person does not meet, whether
+// or not this person is flexible on them
+func (p *Person) UnmetPreferences(other *Person, family, otherFamily *Family) []string {
+	_, unmet := p.checkPreferences(other, family, otherFamily)
+	return unmet
+}
+
+// PreferenceFit is the share of this person's preferences another person meets, 1 when
+// none are set
+func (p *Person) PreferenceFit(other *Person, family, otherFamily *Family) float64 {
+	checked, unmet := p.checkPreferences(other, family, otherFamily)
+	if checked == 0 {
+		return 1
+	}
+	return float64(checked-len(unmet)) / float64(checked)
+}
+
+// checkPreferences counts the preferences this person has set and lists those another person misses
+func (p *Person) checkPreferences(other *Person, family, otherFamily *Family) (int, []string) {
+	checked := 0
+	var unmet []string
+
+	// Age preference check, skipped when no range is set
+	if p.Preferences.PreferredAgeRange[1] > 0 {
+		checked++
+		if other.Age < p.Preferences.PreferredAgeRange[0] || other.Age > p.Preferences.PreferredAgeRange[1] {
+			unmet = append(unmet, PreferenceAge)
 		}
 	}
 
 	// Education preference check
 	if len(p.Preferences.PreferredEducation) > 0 {
+		checked++
+		if !containsString(p.Preferences.PreferredEducation, other.Education.HighestDegree) {
+			unmet = append(unmet, PreferenceEducation)
+		}
+	}
+
+	// Profession preference check
+	if len(p.Preferences.PreferredProfession) > 0 {
+		checked++
+		if !containsString(p.Preferences.PreferredProfession, other.Profession.Industry) {
+			unmet = append(unmet, PreferenceProfession)
+		}
+	}
+
+	// Income preference check
+	if p.Preferences.PreferredIncome[0] > 0 {
+		checked++
+		if other.Profession.AnnualIncome < p.Preferences.PreferredIncome[0] ||
+			other.Profession.AnnualIncome > p.Preferences.PreferredIncome[1] {
+			unmet = append(unmet, PreferenceIncome)
+		}
+	}
+
+	if otherFamily == nil {
+		return checked, unmet
+	}
+
+	// Caste preference check
+	if len(p.Preferences.PreferredCaste) > 0 {
+		checked++
 		found := false
-		for _, edu := range p.Preferences.PreferredEducation {
-			if edu == other.Education.HighestDegree {
+		for _, caste := range p.Preferences.PreferredCaste {
+			if strings.EqualFold(caste, otherFamily.Community.Caste) {
 				found = true
 				break
 			}
 		}
-		if !found && !p.Preferences.FlexibleOnRequirements {
-			return false
+		if !found {
+			unmet = append(unmet, PreferenceCaste)
 		}
 	}
 
-	// Profession preference check
-	if len(p.Preferences.PreferredProfession) > 0 {
+	// Location preference check, by city, state or region
+	if len(p.Preferences.PreferredLocation) > 0 {
+		checked++
 		found := false
-		for _, prof := range p.Preferences.PreferredProfession {
-			if prof == other.Profession.Industry {
+		for _, location := range p.Preferences.PreferredLocation {
+			if otherFamily.Location.Matches(location) {
 				found = true
 				break
 			}
 		}
-		if !found && !p.Preferences.FlexibleOnRequirements {
-			return false
+		if !found {
+			unmet = append(unmet, PreferenceLocation)
 		}
 	}
 
-	// Income preference check
-	if p.Preferences.PreferredIncome[0] > 0 {
-		if other.Profession.AnnualIncome < p.Preferences.PreferredIncome[0] || 
-		   other.Profession.AnnualIncome > p.Preferences.PreferredIncome[1] {
-			if !p.Preferences.FlexibleOnRequirements {
-				return false
+	// Distance preference check, skipped when either family cannot be placed
+	if p.Preferences.MaxDistance > 0 && family != nil {
+		if distance, ok := family.Location.DistanceKm(otherFamily.Location); ok {
+			checked++
+			if distance > float64(p.Preferences.MaxDistance) {
+				unmet = append(unmet, PreferenceDistance)
 			}
 		}
 	}
 
-	return true
+	return checked, unmet
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
 }
 
 // PersonSearchCriteria defines search parameters for persons
@@ -192,6 +274,30 @@ type EligibleMatch struct {
 	ConnectionPath    *ConnectionPath `json:"connection_path"`
 	MatchReasons      []string `json:"match_reasons"`
 	CreatedAt         time.Time `json:"created_at"`
+
+	SeekerUnmetPreferences []string `json:"seeker_unmet_preferences,omitempty"` // Seeker's preferences the candidate misses
+
+	// Set for reciprocal matches, where CompatibilityScore combines both sides
+	Mutual                    bool     `json:"mutual,omitempty"`
+	SeekerScore               float64  `json:"seeker_score,omitempty"`
+	CandidateScore            float64  `json:"candidate_score,omitempty"`
+	CandidateUnmetPreferences []string `json:"candidate_unmet_preferences,omitempty"` // Candidate's preferences the seeker misses
+}
+
+// RuleViolation is one matching rule a candidate breaks
+type RuleViolation struct {
+	RuleSet string `json:"rule_set"` // The community rules the rule belongs to
+	Rule    string `json:"rule"`
+	Reason  string `json:"reason"`
+}
+
+// MatchRejection explains why a candidate who is otherwise eligible was not suggested
+type MatchRejection struct {
+	Person           *Person         `json:"person"`
+	FamilyID         string          `json:"family_id"`
+	RuleSet          string          `json:"rule_set"`           // The seeker's community rules
+	CandidateRuleSet string          `json:"candidate_rule_set"` // The candidate's community rules, applied as well
+	Violations       []RuleViolation `json:"violations"`
 }
 
 // NewEligibleMatch creates a new eligible match
@@ -205,8 +311,17 @@ func NewEligibleMatch(person *Person, family *Family, path *ConnectionPath) *Eli
 	}
 }
 
-// CalculateCompatibilityScore calculates compatibility based on various factors
-func (em *EligibleMatch) CalculateCompatibilityScore(seeker *Person) {
+// Distance component of the compatibility score
+const (
+	nearbyDistanceKm       = 25.0  // Families this close are neighbours
+	defaultDistanceScaleKm = 500.0 // Scale for seekers with no maximum distance
+)
+
+// CalculateCompatibilityScore calculates compatibility based on various factors, scaled by the
+// share of the seeker's preferences the candidate meets so flexible seekers see candidates
+// missing some ranked lower. The distance between the families counts when the seeker's family
+// is given, earning nothing when either family cannot be placed.
+func (em *EligibleMatch) CalculateCompatibilityScore(seeker *Person, seekerFamily *Family) {
 	score := 0.0
 	maxScore := 0.0
 
@@ -268,8 +383,54 @@ func (em *EligibleMatch) CalculateCompatibilityScore(seeker *Person) {
 		}
 	}
 
-	// Convert to percentage
-	em.CompatibilityScore = (score / maxScore) * 100
+	// Distance between the families (weight: 15%), halved at the seeker's maximum distance
+	if seekerFamily != nil {
+		maxScore += 15
+		distanceWeight := 15.0
+		if distance, ok := seekerFamily.Location.DistanceKm(em.Family.Location); ok {
+			scale := defaultDistanceScaleKm
+			if seeker.Preferences.MaxDistance > 0 {
+				scale = float64(seeker.Preferences.MaxDistance)
+			}
+			score += distanceWeight * math.Max(0, 1-distance/(2*scale))
+			if distance <= nearbyDistanceKm {
+				em.MatchReasons = append(em.MatchReasons, "Lives nearby")
+			} else if seeker.Preferences.MaxDistance > 0 && distance <= scale {
+				em.MatchReasons = append(em.MatchReasons, "Within preferred distance")
+			}
+		}
+	}
+
+	// Preferences a flexible seeker lets the candidate miss
+	em.SeekerUnmetPreferences = seeker.UnmetPreferences(em.Person, seekerFamily, em.Family)
+
+	// Convert to percentage, scaled by the share of the seeker's preferences met
+	em.CompatibilityScore = (score / maxScore) * 100 * seeker.PreferenceFit(em.Person, seekerFamily, em.Family)
+}
+
+// CalculateMutualScore scores the match from both sides: each side's compatibility, with the
+// other's family and the connection path, scaled by the share of its preferences the other meets.
+// The compatibility score is the harmonic mean of the two, so a match only scores well when
+// both sides would welcome it.
+func (em *EligibleMatch) CalculateMutualScore(seeker *Person, seekerFamily *Family) {
+	em.CalculateCompatibilityScore(seeker, seekerFamily)
+	em.SeekerScore = em.CompatibilityScore
+
+	// The same match seen by the candidate
+	mirror := &EligibleMatch{Person: seeker, Family: seekerFamily, ConnectionPath: em.ConnectionPath}
+	mirror.CalculateCompatibilityScore(em.Person, em.Family)
+	em.CandidateScore = mirror.CompatibilityScore
+
+	em.Mutual = true
+	em.CandidateUnmetPreferences = mirror.SeekerUnmetPreferences
+	if len(em.SeekerUnmetPreferences) == 0 && len(em.CandidateUnmetPreferences) == 0 {
+		em.MatchReasons = append(em.MatchReasons, "Preferences met on both sides")
+	}
+
+	em.CompatibilityScore = 0
+	if em.SeekerScore+em.CandidateScore > 0 {
+		em.CompatibilityScore = 2 * em.SeekerScore * em.CandidateScore / (em.SeekerScore + em.CandidateScore)
+	}
 }
 
 // Helper functions
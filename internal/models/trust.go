@@ -0,0 +1,169 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Trust score sources recorded in a family's history
+const (
+	TrustSourceConnections = "connections" // The family's own connections and profile
+	TrustSourcePropagated  = "propagated"  // Trust flowing from staff-verified families
+	TrustSourceDefault     = "default"     // Given at creation, before any score was calculated
+	TrustSourceUnrecorded  = "unrecorded"  // Set without a history entry, such as through a profile update
+)
+
+// TrustScoreTolerance is the smallest change in a recomputed trust score that is stored and
+// recorded in the history. Decaying connection strengths shift scores slightly on every run.
+const TrustScoreTolerance = 0.01
+
+// Weights of the connection-based trust score
+const (
+	trustWeightVerifiedConnection  = 0.3
+	trustWeightAverageStrength     = 0.4
+	trustWeightRelativeConnection  = 0.6
+	trustWeightCommunityConnection = 0.3
+	trustWeightVerification        = 1.0
+	trustWeightAccountAge          = 0.5 // Per year
+	trustMaxAccountAge             = 1.5
+	trustWeightUnverified          = -0.1 // Per unverified connection
+	trustMaxUnverifiedPenalty      = -1.0
+	trustInactivePenalty           = -2.0
+
+	// trustDefaultStrength stands in for the average strength of a family without verified connections
+	trustDefaultStrength = 0.5
+)
+
+// TrustFactors are the inputs to a family's connection-based trust score. Each
+// connection counts once, however many directions it is stored in.
+type TrustFactors struct {
+	VerifiedConnections   int       `json:"verified_connections"`
+	UnverifiedConnections int       `json:"unverified_connections"`
+	AverageStrength       float64   `json:"average_strength"` // Decayed strength of verified connections
+	RelativeConnections   int       `json:"relative_connections"`
+	CommunityConnections  int       `json:"community_connections"`
+	VerificationStatus    string    `json:"verification_status"`
+	ActiveStatus          string    `json:"active_status"`
+	CreatedAt             time.Time `json:"created_at"`
+}
+
+// TrustComponent is one weighted term of a trust score
+type TrustComponent struct {
+	Name         string  `json:"name"`
+	Value        float64 `json:"value"`
+	Weight       float64 `json:"weight"`
+	Contribution float64 `json:"contribution"` // Value times weight, after any cap
+	Description  string  `json:"description"`
+}
+
+// TrustBreakdown explains how a trust score was computed
+type TrustBreakdown struct {
+	Score        float64          `json:"score"`     // RawScore clamped to 0-10
+	RawScore     float64          `json:"raw_score"` // Sum of all contributions
+	Components   []TrustComponent `json:"components"`
+	Penalties    []TrustComponent `json:"penalties"` // Only those that apply
+	CalculatedAt time.Time        `json:"calculated_at"`
+}
+
+// TrustScoreRecord is one entry of a family's trust score history
+type TrustScoreRecord struct {
+	Score        float64   `json:"score"`
+	Source       string    `json:"source"`
+	CalculatedAt time.Time `json:"calculated_at"`
+}
+
+// ExplainTrust computes a connection-based trust score and the contribution of each term
+func ExplainTrust(factors *TrustFactors, now time.Time) *TrustBreakdown {
+	breakdown := &TrustBreakdown{
+		Components:   []TrustComponent{},
+		Penalties:    []TrustComponent{},
+		CalculatedAt: now,
+	}
+
+	averageStrength := factors.AverageStrength
+	strengthDescription := "Average strength of verified connections, decayed by the time since each was last confirmed"
+	if factors.VerifiedConnections == 0 {
+		averageStrength = trustDefaultStrength
+		strengthDescription = "No verified connections, so a neutral strength is assumed"
+	}
+
+	verification := 0.0
+	switch factors.VerificationStatus {
+	case "VERIFIED":
+		verification = 1
+	case "PENDING":
+		verification = 0.5
+	}
+
+	ageYears := 0.0
+	if !factors.CreatedAt.IsZero() && now.After(factors.CreatedAt) {
+		ageYears = now.Sub(factors.CreatedAt).Hours() / (24 * 365)
+	}
+
+	breakdown.Components = append(breakdown.Components,
+		trustComponent("verified_connections", float64(factors.VerifiedConnections), trustWeightVerifiedConnection, math.Inf(1),
+			"Connections confirmed by both families"),
+		trustComponent("average_strength", averageStrength, trustWeightAverageStrength, math.Inf(1),
+			strengthDescription),
+		trustComponent("relative_connections", float64(factors.RelativeConnections), trustWeightRelativeConnection, math.Inf(1),
+			"Verified connections to relatives"),
+		trustComponent("community_connections", float64(factors.CommunityConnections), trustWeightCommunityConnection, math.Inf(1),
+			"Verified connections within the community"),
+		trustComponent("verification_status", verification, trustWeightVerification, math.Inf(1),
+			fmt.Sprintf("Profile verification is %s", verificationLabel(factors.VerificationStatus))),
+		trustComponent("account_age", ageYears, trustWeightAccountAge, trustMaxAccountAge,
+			fmt.Sprintf("Years on the network, capped at %.1f points", trustMaxAccountAge)),
+	)
+
+	if factors.UnverifiedConnections > 0 {
+		breakdown.Penalties = append(breakdown.Penalties,
+			trustComponent("unverified_connections", float64(factors.UnverifiedConnections), trustWeightUnverified, trustMaxUnverifiedPenalty,
+				fmt.Sprintf("Connections not yet verified, capped at %.1f points", trustMaxUnverifiedPenalty)))
+	}
+	if factors.ActiveStatus != "" && factors.ActiveStatus != "ACTIVE" {
+		breakdown.Penalties = append(breakdown.Penalties,
+			trustComponent("inactive", 1, trustInactivePenalty, math.Inf(-1),
+				"The family profile is inactive"))
+	}
+
+	for _, component := range breakdown.Components {
+		breakdown.RawScore += component.Contribution
+	}
+	for _, penalty := range breakdown.Penalties {
+		breakdown.RawScore += penalty.Contribution
+	}
+	breakdown.RawScore = roundTrust(breakdown.RawScore)
+	breakdown.Score = math.Max(0, math.Min(breakdown.RawScore, 10))
+
+	return breakdown
+}
+
+// trustComponent weighs a value, capping the contribution at limit (a floor for negative weights)
+func trustComponent(name string, value, weight, limit float64, description string) TrustComponent {
+	contribution := value * weight
+	if weight >= 0 {
+		contribution = math.Min(contribution, limit)
+	} else {
+		contribution = math.Max(contribution, limit)
+	}
+
+	return TrustComponent{
+		Name:         name,
+		Value:        roundTrust(value),
+		Weight:       weight,
+		Contribution: roundTrust(contribution),
+		Description:  description,
+	}
+}
+
+func verificationLabel(status string) string {
+	if status == "" {
+		return "missing"
+	}
+	return status
+}
+
+func roundTrust(value float64) float64 {
+	return math.Round(value*100) / 100
+}
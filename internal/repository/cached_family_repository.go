@@ -0,0 +1,189 @@
+package repository
+
+import (
+	"context"
+	"families-linkedin/internal/cache"
+	"families-linkedin/internal/models"
+	"sort"
+	"time"
+)
+
+// CachedFamilyRepository serves family lookups from a cache backend and passes every
+// other operation through to the wrapped store. Writes drop the cached family.
+type CachedFamilyRepository struct {
+	FamilyStore
+	backend cache.Backend
+	ttl     time.Duration
+	metrics cache.MetricsRecorder
+}
+
+// NewCachedFamilyRepository wraps a family store with a cache. metrics may be nil.
+func NewCachedFamilyRepository(store FamilyStore, backend cache.Backend, ttl time.Duration, metrics cache.MetricsRecorder) *CachedFamilyRepository {
+	return &CachedFamilyRepository{
+		FamilyStore: store,
+		backend:     backend,
+		ttl:         ttl,
+		metrics:     metrics,
+	}
+}
+
+// GetFamilyByID retrieves a family, from the cache when possible
+func (r *CachedFamilyRepository) GetFamilyByID(ctx context.Context, familyID string) (*models.Family, error) {
+	if family, found := r.get(ctx, familyID); found {
+		r.increment("family_cache_hits")
+		return family, nil
+	}
+	r.increment("family_cache_misses")
+
+	family, err := r.FamilyStore.GetFamilyByID(ctx, familyID)
+	if err != nil || family == nil {
+		return family, err
+	}
+
+	r.set(ctx, family)
+	return family, nil
+}
+
+// GetFamiliesByIDs retrieves multiple families, loading only the uncached ones from the store
+func (r *CachedFamilyRepository) GetFamiliesByIDs(ctx context.Context, familyIDs []string) ([]*models.Family, error) {
+	families := make([]*models.Family, 0, len(familyIDs))
+	var missing []string
+	for _, familyID := range familyIDs {
+		if family, found := r.get(ctx, familyID); found {
+			r.increment("family_cache_hits")
+			families = append(families, family)
+		} else {
+			r.increment("family_cache_misses")
+			missing = append(missing, familyID)
+		}
+	}
+
+	if len(missing) > 0 {
+		loaded, err := r.FamilyStore.GetFamiliesByIDs(ctx, missing)
+		if err != nil {
+			return nil, err
+		}
+		for _, family := range loaded {
+			r.set(ctx, family)
+		}
+		families = append(families, loaded...)
+	}
+
+	// Match the store's ordering
+	sort.SliceStable(families, func(i, j int) bool {
+		return families[i].TrustScore > families[j].TrustScore
+	})
+
+	return families, nil
+}
+
+// UpdateFamily updates a family and drops its cached copy
+func (r *CachedFamilyRepository) UpdateFamily(ctx context.Context, family *models.Family) error {
+	defer r.invalidate(ctx, family.ID)
+	return r.FamilyStore.UpdateFamily(ctx, family)
+}
+
+// DeleteFamily deletes a family and drops its cached copy
+func (r *CachedFamilyRepository) DeleteFamily(ctx context.Context, familyID string) error {
+	defer r.invalidate(ctx, familyID)
+	return r.FamilyStore.DeleteFamily(ctx, familyID)
+}
+
+// UpdateFamilyTrustScore updates a family's trust score and drops its cached copy
+func (r *CachedFamilyRepository) UpdateFamilyTrustScore(ctx context.Context, familyID string, score float64, source string) error {
+	defer r.invalidate(ctx, familyID)
+	return r.FamilyStore.UpdateFamilyTrustScore(ctx, familyID, score, source)
+}
+
+// UpdateFamilyTrustScores stores recomputed trust scores and drops the cached copies of the
+// families whose score changed
+func (r *CachedFamilyRepository) UpdateFamilyTrustScores(ctx context.Context, scores map[string]float64, source string) ([]string, error) {
+	changed, err := r.FamilyStore.UpdateFamilyTrustScores(ctx, scores, source)
+	if err != nil {
+		// Some batches may have been written before the failure
+		familyIDs := make([]string, 0, len(scores))
+		for familyID := range scores {
+			familyIDs = append(familyIDs, familyID)
+		}
+		r.invalidate(ctx, familyIDs...)
+		return nil, err
+	}
+	if len(changed) > 0 {
+		r.invalidate(ctx, changed...)
+	}
+	return changed, nil
+}
+
+// UpdateFamilyCentrality stores centrality scores and drops the cached copies of the updated families
+func (r *CachedFamilyRepository) UpdateFamilyCentrality(ctx context.Context, scores map[string]models.Centrality) error {
+	familyIDs := make([]string, 0, len(scores))
+	for familyID := range scores {
+		familyIDs = append(familyIDs, familyID)
+	}
+	defer r.invalidate(ctx, familyIDs...)
+	return r.FamilyStore.UpdateFamilyCentrality(ctx, scores)
+}
+
+// UpdateFamilyCommunities stores community IDs and drops the cached copies of the updated families
+func (r *CachedFamilyRepository) UpdateFamilyCommunities(ctx context.Context, assignments map[string]int) error {
+	familyIDs := make([]string, 0, len(assignments))
+	for familyID := range assignments {
+		familyIDs = append(familyIDs, familyID)
+	}
+	defer r.invalidate(ctx, familyIDs...)
+	return r.FamilyStore.UpdateFamilyCommunities(ctx, assignments)
+}
+
+// get reads and decodes a cached family. Backend failures count as misses.
+func (r *CachedFamilyRepository) get(ctx context.Context, familyID string) (*models.Family, bool) {
+	data, found, err := r.backend.Get(ctx, familyCacheKey(familyID))
+	if err != nil {
+		r.increment("family_cache_errors")
+		return nil, false
+	}
+	if !found {
+		return nil, false
+	}
+
+	family, err := cache.DecodeFamily(data)
+	if err != nil {
+		r.increment("family_cache_errors")
+		return nil, false
+	}
+	return family, true
+}
+
+func (r *CachedFamilyRepository) set(ctx context.Context, family *models.Family) {
+	data, err := cache.EncodeFamily(family)
+	if err != nil {
+		r.increment("family_cache_errors")
+		return
+	}
+
+	if err := r.backend.Set(ctx, familyCacheKey(family.ID), data, r.ttl); err != nil {
+		r.increment("family_cache_errors")
+	}
+}
+
+// invalidate drops cached families. It runs even if the write failed, since the write
+// may still have been applied.
+func (r *CachedFamilyRepository) invalidate(ctx context.Context, familyIDs ...string) {
+	keys := make([]string, len(familyIDs))
+	for i, familyID := range familyIDs {
+		keys[i] = familyCacheKey(familyID)
+	}
+
+	if err := r.backend.Delete(context.WithoutCancel(ctx), keys...); err != nil {
+		r.increment("family_cache_errors")
+	}
+}
+
+func (r *CachedFamilyRepository) increment(name string) {
+	if r.metrics != nil {
+		r.metrics.IncrementCounter(name)
+	}
+}
+
+func familyCacheKey(familyID string) string {
+	return "family:" + familyID
+}
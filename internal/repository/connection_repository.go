@@ -125,6 +125,7 @@ func (r *ConnectionRepository) FindShortestPath(ctx context.Context, fromFamilyI
 				   length(path) as degree,
 				   path_strength,
 				   relation_types,
+				   [r IN rels | properties(r)] as rel_props,
 				   ALL(r IN rels WHERE r.verified = true) as all_verified
 			ORDER BY degree ASC, path_strength DESC
 			LIMIT 1
@@ -148,6 +149,7 @@ func (r *ConnectionRepository) FindShortestPath(ctx context.Context, fromFamilyI
 			pathStrength, _ := record.Get("path_strength")
 			relationTypes, _ := record.Get("relation_types")
 			allVerified, _ := record.Get("all_verified")
+			relProps, _ := record.Get("rel_props")
 			
 			var familyPath []string
 			for _, node := range pathNodes.([]interface{}) {
@@ -166,6 +168,7 @@ func (r *ConnectionRepository) FindShortestPath(ctx context.Context, fromFamilyI
 				Degree:         int(degree.(int64)),
 				PathStrength:   pathStrength.(float64),
 				RelationTypes:  relTypes,
+				Edges:          r.mapPathEdges(familyPath, relProps),
 				Verified:       allVerified.(bool),
 				CalculatedAt:   time.Now(),
 			}
@@ -228,6 +231,7 @@ func (r *ConnectionRepository) FindBidirectionalPath(ctx context.Context, fromFa
 				   length(path) as degree,
 				   path_strength,
 				   relation_types,
+				   [r IN rels | properties(r)] as rel_props,
 				   all_verified
 		`
 		
@@ -249,6 +253,7 @@ func (r *ConnectionRepository) FindBidirectionalPath(ctx context.Context, fromFa
 			pathStrength, _ := record.Get("path_strength")
 			relationTypes, _ := record.Get("relation_types")
 			allVerified, _ := record.Get("all_verified")
+			relProps, _ := record.Get("rel_props")
 			
 			var familyPath []string
 			if pathNodes != nil {
@@ -271,6 +276,7 @@ func (r *ConnectionRepository) FindBidirectionalPath(ctx context.Context, fromFa
 				Degree:         int(degree.(int64)),
 				PathStrength:   pathStrength.(float64),
 				RelationTypes:  relTypes,
+				Edges:          r.mapPathEdges(familyPath, relProps),
 				Verified:       allVerified.(bool),
 				CalculatedAt:   time.Now(),
 			}
@@ -319,6 +325,7 @@ func (r *ConnectionRepository) FindMultiplePaths(ctx context.Context, fromFamily
 				   degree,
 				   path_strength,
 				   relation_types,
+				   [r IN rels | properties(r)] as rel_props,
 				   ALL(r IN rels WHERE r.verified = true) as all_verified
 			ORDER BY degree ASC, path_strength DESC
 			LIMIT $max_paths
@@ -344,6 +351,7 @@ func (r *ConnectionRepository) FindMultiplePaths(ctx context.Context, fromFamily
 			pathStrength, _ := record.Get("path_strength")
 			relationTypes, _ := record.Get("relation_types")
 			allVerified, _ := record.Get("all_verified")
+			relProps, _ := record.Get("rel_props")
 			
 			var familyPath []string
 			for _, node := range pathNodes.([]interface{}) {
@@ -362,6 +370,7 @@ func (r *ConnectionRepository) FindMultiplePaths(ctx context.Context, fromFamily
 				Degree:         int(degree.(int64)),
 				PathStrength:   pathStrength.(float64),
 				RelationTypes:  relTypes,
+				Edges:          r.mapPathEdges(familyPath, relProps),
 				Verified:       allVerified.(bool),
 				CalculatedAt:   time.Now(),
 			}
@@ -486,6 +495,281 @@ func (r *ConnectionRepository) GetConnectionStrength(ctx context.Context, family
 	return result.(float64), nil
 }
 
+// GetFamilyEdges retrieves the direct FAMILY_RELATION records of a family
+func (r *ConnectionRepository) GetFamilyEdges(ctx context.Context, familyID string) ([]*models.FamilyConnection, error) {
+	edges, err := r.GetFamilyEdgesBulk(ctx, []string{familyID})
+	if err != nil {
+		return nil, err
+	}
+
+	return edges[familyID], nil
+}
+
+// GetFamilyEdgesBulk retrieves the direct FAMILY_RELATION records of several families in one query
+func (r *ConnectionRepository) GetFamilyEdgesBulk(ctx context.Context, familyIDs []string) (map[string][]*models.FamilyConnection, error) {
+	if len(familyIDs) == 0 {
+		return map[string][]*models.FamilyConnection{}, nil
+	}
+
+	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	result, err := database.ExecuteReadTransaction(ctx, session, func(ctx context.Context, tx neo4j.ManagedTransaction) (interface{}, error) {
+		// Connections are stored in both directions, keep one record per neighbor
+		query := `
+			UNWIND $family_ids as family_id
+			MATCH (source:Family {family_id: family_id})-[r:FAMILY_RELATION]-(connected:Family)
+			WITH family_id, connected, head(collect(properties(r))) as props
+			RETURN family_id, connected.family_id as connected_id, props
+			ORDER BY family_id, connected.trust_score DESC
+		`
+		
+		result, err := tx.Run(ctx, query, map[string]interface{}{
+			"family_ids": familyIDs,
+		})
+		
+		if err != nil {
+			return nil, err
+		}
+
+		edges := make(map[string][]*models.FamilyConnection, len(familyIDs))
+		for result.Next(ctx) {
+			record := result.Record()
+			familyIDValue, _ := record.Get("family_id")
+			connectedID, _ := record.Get("connected_id")
+			props, _ := record.Get("props")
+
+			familyID := familyIDValue.(string)
+			edges[familyID] = append(edges[familyID],
+				r.mapPropsToConnection(familyID, connectedID.(string), props.(map[string]interface{})))
+		}
+
+		return edges, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result.(map[string][]*models.FamilyConnection), nil
+}
+
+// GetConnection returns the edge record between two families, or nil when they are not directly connected
+func (r *ConnectionRepository) GetConnection(ctx context.Context, fromFamilyID, toFamilyID string) (*models.FamilyConnection, error) {
+	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	result, err := database.ExecuteReadTransaction(ctx, session, func(ctx context.Context, tx neo4j.ManagedTransaction) (interface{}, error) {
+		query := `
+			MATCH (f1:Family {family_id: $from_family_id})-[r:FAMILY_RELATION]->(f2:Family {family_id: $to_family_id})
+			RETURN properties(r) as props
+			LIMIT 1
+		`
+		
+		result, err := tx.Run(ctx, query, map[string]interface{}{
+			"from_family_id": fromFamilyID,
+			"to_family_id":   toFamilyID,
+		})
+		
+		if err != nil {
+			return nil, err
+		}
+
+		if result.Next(ctx) {
+			record := result.Record()
+			props, _ := record.Get("props")
+			return r.mapPropsToConnection(fromFamilyID, toFamilyID, props.(map[string]interface{})), nil
+		}
+
+		return (*models.FamilyConnection)(nil), nil // No direct connection
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result.(*models.FamilyConnection), nil
+}
+
+// GetAllConnections retrieves every FAMILY_RELATION record, e.g. to build an in-memory graph snapshot
+func (r *ConnectionRepository) GetAllConnections(ctx context.Context) ([]*models.FamilyConnection, error) {
+	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	result, err := database.ExecuteReadTransaction(ctx, session, func(ctx context.Context, tx neo4j.ManagedTransaction) (interface{}, error) {
+		query := `
+			MATCH (f1:Family)-[r:FAMILY_RELATION]->(f2:Family)
+			RETURN f1.family_id as from_family_id, f2.family_id as to_family_id, properties(r) as props
+		`
+		
+		result, err := tx.Run(ctx, query, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var connections []*models.FamilyConnection
+		for result.Next(ctx) {
+			record := result.Record()
+			fromFamilyID, _ := record.Get("from_family_id")
+			toFamilyID, _ := record.Get("to_family_id")
+			props, _ := record.Get("props")
+			connections = append(connections,
+				r.mapPropsToConnection(fromFamilyID.(string), toFamilyID.(string), props.(map[string]interface{})))
+		}
+
+		return connections, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result.([]*models.FamilyConnection), nil
+}
+
+// GetMutualConnections returns the active families directly connected to both families,
+// with the connection records joining them, in one query. Families and strengths are left
+// to the caller.
+func (r *ConnectionRepository) GetMutualConnections(ctx context.Context, family1ID, family2ID string) ([]*models.MutualConnection, error) {
+	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	result, err := database.ExecuteReadTransaction(ctx, session, func(ctx context.Context, tx neo4j.ManagedTransaction) (interface{}, error) {
+		// Connections are stored in both directions, keep one record per side
+		query := `
+			MATCH (f1:Family {family_id: $family1_id})-[r1:FAMILY_RELATION]->(mutual:Family)<-[r2:FAMILY_RELATION]-(f2:Family {family_id: $family2_id})
+			WHERE mutual.active_status = 'ACTIVE'
+			WITH mutual, head(collect(properties(r1))) as props1, head(collect(properties(r2))) as props2
+			RETURN mutual.family_id as mutual_id, props1, props2
+			ORDER BY mutual_id
+		`
+		
+		result, err := tx.Run(ctx, query, map[string]interface{}{
+			"family1_id": family1ID,
+			"family2_id": family2ID,
+		})
+		
+		if err != nil {
+			return nil, err
+		}
+
+		var mutuals []*models.MutualConnection
+		for result.Next(ctx) {
+			record := result.Record()
+			mutualIDValue, _ := record.Get("mutual_id")
+			props1, _ := record.Get("props1")
+			props2, _ := record.Get("props2")
+
+			mutualID := mutualIDValue.(string)
+			mutuals = append(mutuals, &models.MutualConnection{
+				FamilyID:  mutualID,
+				ToFamily1: r.mapPropsToConnection(family1ID, mutualID, props1.(map[string]interface{})),
+				ToFamily2: r.mapPropsToConnection(family2ID, mutualID, props2.(map[string]interface{})),
+			})
+		}
+
+		return mutuals, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result.([]*models.MutualConnection), nil
+}
+
+// CountMutualConnections counts the active families directly connected to both a family
+// and each of the other families, in one query. Families without any are left out.
+func (r *ConnectionRepository) CountMutualConnections(ctx context.Context, familyID string, otherFamilyIDs []string) (map[string]int, error) {
+	if len(otherFamilyIDs) == 0 {
+		return map[string]int{}, nil
+	}
+
+	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	result, err := database.ExecuteReadTransaction(ctx, session, func(ctx context.Context, tx neo4j.ManagedTransaction) (interface{}, error) {
+		query := `
+			MATCH (:Family {family_id: $family_id})-[:FAMILY_RELATION]->(mutual:Family)
+			WHERE mutual.active_status = 'ACTIVE'
+			WITH collect(DISTINCT mutual) as mutuals
+			UNWIND $other_family_ids as other_id
+			MATCH (other:Family {family_id: other_id})-[:FAMILY_RELATION]->(mutual:Family)
+			WHERE mutual IN mutuals
+			RETURN other_id, count(DISTINCT mutual) as mutual_count
+		`
+		
+		result, err := tx.Run(ctx, query, map[string]interface{}{
+			"family_id":        familyID,
+			"other_family_ids": otherFamilyIDs,
+		})
+		
+		if err != nil {
+			return nil, err
+		}
+
+		counts := make(map[string]int, len(otherFamilyIDs))
+		for result.Next(ctx) {
+			record := result.Record()
+			otherID, _ := record.Get("other_id")
+			mutualCount, _ := record.Get("mutual_count")
+			counts[otherID.(string)] = int(mutualCount.(int64))
+		}
+
+		return counts, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result.(map[string]int), nil
+}
+
+// ReconfirmConnection records that two families reconfirmed their connection, resetting its
+// strength decay. It returns the updated record oriented from fromFamilyID, or nil when the
+// families are not directly connected.
+func (r *ConnectionRepository) ReconfirmConnection(ctx context.Context, fromFamilyID, toFamilyID string, reconfirmedAt time.Time) (*models.FamilyConnection, error) {
+	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	result, err := database.ExecuteWithTransaction(ctx, session, func(ctx context.Context, tx neo4j.ManagedTransaction) (interface{}, error) {
+		// Both directed relationships carry the connection's properties
+		query := `
+			MATCH (f1:Family {family_id: $from_family_id})-[r:FAMILY_RELATION]-(f2:Family {family_id: $to_family_id})
+			SET r.reconfirmed_at = datetime($reconfirmed_at)
+			WITH f1, f2, r
+			WHERE startNode(r) = f1
+			RETURN properties(r) as props
+			LIMIT 1
+		`
+		
+		result, err := tx.Run(ctx, query, map[string]interface{}{
+			"from_family_id": fromFamilyID,
+			"to_family_id":   toFamilyID,
+			"reconfirmed_at": reconfirmedAt.Format(time.RFC3339),
+		})
+		
+		if err != nil {
+			return nil, err
+		}
+
+		if result.Next(ctx) {
+			record := result.Record()
+			props, _ := record.Get("props")
+			return r.mapPropsToConnection(fromFamilyID, toFamilyID, props.(map[string]interface{})), nil
+		}
+
+		return (*models.FamilyConnection)(nil), result.Err() // No direct connection
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result.(*models.FamilyConnection), nil
+}
+
 // ValidateNoCircularConnections ensures that adding a connection won't create invalid cycles
 func (r *ConnectionRepository) ValidateNoCircularConnections(ctx context.Context, fromFamilyID, toFamilyID string) error {
 	// Check if families are the same
@@ -589,4 +873,52 @@ func (r *ConnectionRepository) GetNetworkStats(ctx context.Context) (map[string]
 	}
 
 	return result.(map[string]interface{}), nil
-}
\ No newline at end of file
+}
+
+// mapPropsToConnection converts FAMILY_RELATION properties into a FamilyConnection
+func (r *ConnectionRepository) mapPropsToConnection(fromFamilyID, toFamilyID string, props map[string]interface{}) *models.FamilyConnection {
+	connection := &models.FamilyConnection{
+		FromFamilyID: fromFamilyID,
+		ToFamilyID:   toFamilyID,
+	}
+
+	if relationType, ok := props["relation_type"].(string); ok {
+		connection.RelationType = relationType
+	}
+	if specificRelation, ok := props["specific_relation"].(string); ok {
+		connection.SpecificRelation = specificRelation
+	}
+	if strength, ok := props["strength"].(float64); ok {
+		connection.Strength = strength
+	}
+	if verified, ok := props["verified"].(bool); ok {
+		connection.Verified = verified
+	}
+	if establishedDate, ok := props["established_date"].(neo4j.Date); ok {
+		connection.EstablishedDate = establishedDate.Time()
+	}
+	if createdAt, ok := props["created_at"].(time.Time); ok {
+		connection.CreatedAt = createdAt
+	}
+	if reconfirmedAt, ok := props["reconfirmed_at"].(time.Time); ok {
+		connection.ReconfirmedAt = reconfirmedAt
+	}
+
+	return connection
+}
+
+// mapPathEdges converts the relationship properties along a path into per-hop edges
+func (r *ConnectionRepository) mapPathEdges(familyPath []string, relProps interface{}) []models.PathEdge {
+	props, _ := relProps.([]interface{})
+	if len(props) != len(familyPath)-1 {
+		return nil
+	}
+
+	edges := make([]models.PathEdge, 0, len(props))
+	for i, prop := range props {
+		propMap, _ := prop.(map[string]interface{})
+		edges = append(edges, models.NewPathEdge(r.mapPropsToConnection(familyPath[i], familyPath[i+1], propMap)))
+	}
+
+	return edges
+}
@@ -33,8 +33,10 @@ func (r *FamilyRepository) CreateFamily(ctx context.Context, family *models.Fami
 				state: $state,
 				country: $country,
 				region: $region,
+				coordinates: $coordinates,
 				caste: $caste,
 				sub_caste: $sub_caste,
+				gotra: $gotra,
 				religion: $religion,
 				languages: $languages,
 				primary_phone: $primary_phone,
@@ -60,8 +62,10 @@ func (r *FamilyRepository) CreateFamily(ctx context.Context, family *models.Fami
 			"state":              family.Location.State,
 			"country":            family.Location.Country,
 			"region":             family.Location.Region,
+			"coordinates":        family.Location.Coordinates,
 			"caste":              family.Community.Caste,
 			"sub_caste":          family.Community.SubCaste,
+			"gotra":              family.Community.Gotra,
 			"religion":           family.Community.Religion,
 			"languages":          family.Community.Languages,
 			"primary_phone":      family.ContactInfo.PrimaryPhone,
@@ -116,7 +120,7 @@ func (r *FamilyRepository) GetFamilyByID(ctx context.Context, familyID string) (
 	}
 
 	if result == nil {
-		return nil, fmt.Errorf("family not found: %s", familyID)
+		return nil, fmt.Errorf("%w: %s", ErrFamilyNotFound, familyID)
 	}
 
 	return result.(*models.Family), nil
@@ -136,8 +140,10 @@ func (r *FamilyRepository) UpdateFamily(ctx context.Context, family *models.Fami
 				f.state = $state,
 				f.country = $country,
 				f.region = $region,
+				f.coordinates = $coordinates,
 				f.caste = $caste,
 				f.sub_caste = $sub_caste,
+				f.gotra = $gotra,
 				f.religion = $religion,
 				f.languages = $languages,
 				f.primary_phone = $primary_phone,
@@ -163,8 +169,10 @@ func (r *FamilyRepository) UpdateFamily(ctx context.Context, family *models.Fami
 			"state":              family.Location.State,
 			"country":            family.Location.Country,
 			"region":             family.Location.Region,
+			"coordinates":        family.Location.Coordinates,
 			"caste":              family.Community.Caste,
 			"sub_caste":          family.Community.SubCaste,
+			"gotra":              family.Community.Gotra,
 			"religion":           family.Community.Religion,
 			"languages":          family.Community.Languages,
 			"primary_phone":      family.ContactInfo.PrimaryPhone,
@@ -333,66 +341,208 @@ func (r *FamilyRepository) GetFamiliesByIDs(ctx context.Context, familyIDs []str
 	return result.([]*models.Family), nil
 }
 
-// GetFamilyTrustScore calculates and returns the trust score for a family
-func (r *FamilyRepository) GetFamilyTrustScore(ctx context.Context, familyID string) (float64, error) {
+// GetActiveFamilyIDs returns the IDs of all active families, in ID order
+func (r *FamilyRepository) GetActiveFamilyIDs(ctx context.Context) ([]string, error) {
 	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
 	defer session.Close(ctx)
 
 	result, err := database.ExecuteReadTransaction(ctx, session, func(ctx context.Context, tx neo4j.ManagedTransaction) (interface{}, error) {
+		query := `
+			MATCH (f:Family)
+			WHERE f.active_status = 'ACTIVE'
+			RETURN f.family_id as family_id
+			ORDER BY family_id
+		`
+
+		result, err := tx.Run(ctx, query, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var familyIDs []string
+		for result.Next(ctx) {
+			if familyID, ok := result.Record().Values[0].(string); ok {
+				familyIDs = append(familyIDs, familyID)
+			}
+		}
+
+		return familyIDs, result.Err()
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result.([]string), nil
+}
+
+// GetVerifiedFamilyIDs returns the IDs of active, verified families verified by the given verifier, in ID order
+func (r *FamilyRepository) GetVerifiedFamilyIDs(ctx context.Context, verifiedBy string) ([]string, error) {
+	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	result, err := database.ExecuteReadTransaction(ctx, session, func(ctx context.Context, tx neo4j.ManagedTransaction) (interface{}, error) {
+		query := `
+			MATCH (f:Family)
+			WHERE f.active_status = 'ACTIVE'
+			  AND f.verification_status = 'VERIFIED'
+			  AND f.verified_by = $verified_by
+			RETURN f.family_id as family_id
+			ORDER BY family_id
+		`
+
+		result, err := tx.Run(ctx, query, map[string]interface{}{"verified_by": verifiedBy})
+		if err != nil {
+			return nil, err
+		}
+
+		var familyIDs []string
+		for result.Next(ctx) {
+			if familyID, ok := result.Record().Values[0].(string); ok {
+				familyIDs = append(familyIDs, familyID)
+			}
+		}
+
+		return familyIDs, result.Err()
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result.([]string), nil
+}
+
+// GetFamilyTrustFactors gathers the inputs to a family's connection-based trust score,
+// averaging the strength of verified connections after decay. It returns nil when the
+// family does not exist.
+func (r *FamilyRepository) GetFamilyTrustFactors(ctx context.Context, familyID string, decay *models.StrengthDecay) (*models.TrustFactors, error) {
+	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	result, err := database.ExecuteReadTransaction(ctx, session, func(ctx context.Context, tx neo4j.ManagedTransaction) (interface{}, error) {
+		// Connections are stored in both directions, keep one record per neighbor
 		query := `
 			MATCH (f:Family {family_id: $family_id})
-			OPTIONAL MATCH (f)-[r:FAMILY_RELATION]-(connected:Family)
-			WHERE r.verified = true
-			WITH f, COUNT(connected) as total_connections, AVG(r.strength) as avg_strength,
-				 COUNT(CASE WHEN r.relation_type = 'RELATIVE' THEN 1 END) as relative_connections,
-				 COUNT(CASE WHEN r.relation_type = 'COMMUNITY_RELATION' THEN 1 END) as community_connections
-			RETURN f.trust_score as current_score,
-				   (total_connections * 0.3 + 
-					coalesce(avg_strength, 0.5) * 0.4 + 
-					(relative_connections * 2 + community_connections) * 0.3) as calculated_score,
-				   total_connections, avg_strength, relative_connections, community_connections
+			OPTIONAL MATCH (f)-[rel:FAMILY_RELATION]-(connected:Family)
+			WITH f, connected, head(collect(rel)) as r
+			WITH f,
+				 COUNT(CASE WHEN r.verified = true THEN 1 END) as verified_connections,
+				 COUNT(CASE WHEN r IS NOT NULL AND coalesce(r.verified, false) = false THEN 1 END) as unverified_connections,
+				 collect(CASE WHEN r.verified = true THEN {
+					 strength: r.strength,
+					 established_date: r.established_date,
+					 created_at: r.created_at,
+					 reconfirmed_at: r.reconfirmed_at
+				 } END) as verified_edges,
+				 COUNT(CASE WHEN r.verified = true AND r.relation_type = 'RELATIVE' THEN 1 END) as relative_connections,
+				 COUNT(CASE WHEN r.verified = true AND r.relation_type = 'COMMUNITY_RELATION' THEN 1 END) as community_connections
+			RETURN verified_connections, unverified_connections, verified_edges,
+				   relative_connections, community_connections,
+				   f.verification_status as verification_status,
+				   f.active_status as active_status,
+				   f.created_at as created_at
 		`
-		
+
 		result, err := tx.Run(ctx, query, map[string]interface{}{
 			"family_id": familyID,
 		})
-		
 		if err != nil {
 			return nil, err
 		}
 
-		if result.Next(ctx) {
-			record := result.Record()
-			calculatedScore, _ := record.Get("calculated_score")
-			return calculatedScore.(float64), nil
+		if !result.Next(ctx) {
+			return (*models.TrustFactors)(nil), result.Err()
 		}
 
-		return 0.0, nil
+		record := result.Record()
+		factors := &models.TrustFactors{}
+		if value, ok := record.Get("verified_connections"); ok {
+			factors.VerifiedConnections = int(value.(int64))
+		}
+		if value, ok := record.Get("unverified_connections"); ok {
+			factors.UnverifiedConnections = int(value.(int64))
+		}
+		if value, ok := record.Get("verified_edges"); ok && value != nil {
+			var verified []*models.FamilyConnection
+			for _, edge := range value.([]interface{}) {
+				verified = append(verified, mapTrustEdge(edge.(map[string]interface{})))
+			}
+			factors.AverageStrength = models.AverageDecayedStrength(verified, decay, time.Now())
+		}
+		if value, ok := record.Get("relative_connections"); ok {
+			factors.RelativeConnections = int(value.(int64))
+		}
+		if value, ok := record.Get("community_connections"); ok {
+			factors.CommunityConnections = int(value.(int64))
+		}
+		if value, ok := record.Get("verification_status"); ok && value != nil {
+			factors.VerificationStatus = value.(string)
+		}
+		if value, ok := record.Get("active_status"); ok && value != nil {
+			factors.ActiveStatus = value.(string)
+		}
+		if value, ok := record.Get("created_at"); ok {
+			if createdAt, ok := value.(time.Time); ok {
+				factors.CreatedAt = createdAt
+			}
+		}
+
+		return factors, nil
 	})
 
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
-	return result.(float64), nil
+	return result.(*models.TrustFactors), nil
 }
 
-// UpdateFamilyTrustScore updates the family's trust score
-func (r *FamilyRepository) UpdateFamilyTrustScore(ctx context.Context, familyID string, score float64) error {
+// mapTrustEdge converts the strength and timestamps of a verified connection into a
+// FamilyConnection so its decay can be applied
+func mapTrustEdge(props map[string]interface{}) *models.FamilyConnection {
+	connection := &models.FamilyConnection{}
+	if strength, ok := props["strength"].(float64); ok {
+		connection.Strength = strength
+	}
+	if establishedDate, ok := props["established_date"].(neo4j.Date); ok {
+		connection.EstablishedDate = establishedDate.Time()
+	}
+	if createdAt, ok := props["created_at"].(time.Time); ok {
+		connection.CreatedAt = createdAt
+	}
+	if reconfirmedAt, ok := props["reconfirmed_at"].(time.Time); ok {
+		connection.ReconfirmedAt = reconfirmedAt
+	}
+	return connection
+}
+
+// maxTrustHistory bounds the trust score history kept on each family
+const maxTrustHistory = 50
+
+// UpdateFamilyTrustScore updates the family's trust score and appends it to the family's
+// trust history, recording where the score came from
+func (r *FamilyRepository) UpdateFamilyTrustScore(ctx context.Context, familyID string, score float64, source string) error {
 	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
 	defer session.Close(ctx)
 
 	_, err := database.ExecuteWithTransaction(ctx, session, func(ctx context.Context, tx neo4j.ManagedTransaction) (interface{}, error) {
+		// History is kept as parallel lists, newest last, trimmed to the most recent entries
 		query := `
 			MATCH (f:Family {family_id: $family_id})
 			SET f.trust_score = $trust_score,
-				f.updated_at = datetime($updated_at)
+				f.updated_at = datetime($updated_at),
+				f.trust_history_scores = (coalesce(f.trust_history_scores, []) + $trust_score)[-$max_history..],
+				f.trust_history_sources = (coalesce(f.trust_history_sources, []) + $source)[-$max_history..],
+				f.trust_history_at = (coalesce(f.trust_history_at, []) + $updated_at)[-$max_history..]
 			RETURN f.family_id
 		`
 		
 		_, err := tx.Run(ctx, query, map[string]interface{}{
 			"family_id":   familyID,
 			"trust_score": score,
+			"source":      source,
+			"max_history": maxTrustHistory,
 			"updated_at":  time.Now().Format(time.RFC3339),
 		})
 		return nil, err
@@ -401,6 +551,300 @@ func (r *FamilyRepository) UpdateFamilyTrustScore(ctx context.Context, familyID
 	return err
 }
 
+// GetTrustScoreHistory returns a family's recorded trust scores, newest first
+func (r *FamilyRepository) GetTrustScoreHistory(ctx context.Context, familyID string) ([]models.TrustScoreRecord, error) {
+	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	result, err := database.ExecuteReadTransaction(ctx, session, func(ctx context.Context, tx neo4j.ManagedTransaction) (interface{}, error) {
+		query := `
+			MATCH (f:Family {family_id: $family_id})
+			RETURN coalesce(f.trust_history_scores, []) as scores,
+				   coalesce(f.trust_history_sources, []) as sources,
+				   coalesce(f.trust_history_at, []) as calculated_at
+		`
+
+		result, err := tx.Run(ctx, query, map[string]interface{}{
+			"family_id": familyID,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		history := []models.TrustScoreRecord{}
+		if !result.Next(ctx) {
+			return history, result.Err()
+		}
+
+		record := result.Record()
+		scores, _ := record.Values[0].([]interface{})
+		sources, _ := record.Values[1].([]interface{})
+		timestamps, _ := record.Values[2].([]interface{})
+
+		for i := len(scores) - 1; i >= 0; i-- {
+			entry := models.TrustScoreRecord{}
+			entry.Score, _ = scores[i].(float64)
+			if i < len(sources) {
+				entry.Source, _ = sources[i].(string)
+			}
+			if i < len(timestamps) {
+				if value, ok := timestamps[i].(string); ok {
+					entry.CalculatedAt, _ = time.Parse(time.RFC3339, value)
+				}
+			}
+			history = append(history, entry)
+		}
+
+		return history, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result.([]models.TrustScoreRecord), nil
+}
+
+// analyticsBatchSize bounds the number of families the analytics batch updates per transaction
+const analyticsBatchSize = 1000
+
+// UpdateFamilyCentrality stores centrality scores on family nodes in batches
+func (r *FamilyRepository) UpdateFamilyCentrality(ctx context.Context, scores map[string]models.Centrality) error {
+	rows := make([]map[string]interface{}, 0, len(scores))
+	for familyID, centrality := range scores {
+		rows = append(rows, map[string]interface{}{
+			"family_id":         familyID,
+			"pagerank":          centrality.PageRank,
+			"betweenness":       centrality.Betweenness,
+			"degree_centrality": centrality.DegreeCentrality,
+			"degree":            centrality.Degree,
+			"calculated_at":     centrality.CalculatedAt.Format(time.RFC3339),
+		})
+	}
+
+	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	for start := 0; start < len(rows); start += analyticsBatchSize {
+		batch := rows[start:min(start+analyticsBatchSize, len(rows))]
+
+		_, err := database.ExecuteWithTransaction(ctx, session, func(ctx context.Context, tx neo4j.ManagedTransaction) (interface{}, error) {
+			query := `
+				UNWIND $rows as row
+				MATCH (f:Family {family_id: row.family_id})
+				SET f.pagerank = row.pagerank,
+					f.betweenness = row.betweenness,
+					f.degree_centrality = row.degree_centrality,
+					f.degree = row.degree,
+					f.centrality_calculated_at = datetime(row.calculated_at)
+			`
+
+			_, err := tx.Run(ctx, query, map[string]interface{}{"rows": batch})
+			return nil, err
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update centrality batch at %d: %w", start, err)
+		}
+	}
+
+	return nil
+}
+
+// UpdateFamilyTrustScores stores recomputed trust scores on family nodes in batches. Only
+// scores that moved by at least models.TrustScoreTolerance, or last came from another
+// source, are written and appended to the history; it returns the IDs of those families.
+func (r *FamilyRepository) UpdateFamilyTrustScores(ctx context.Context, scores map[string]float64, source string) ([]string, error) {
+	rows := make([]map[string]interface{}, 0, len(scores))
+	for familyID, score := range scores {
+		rows = append(rows, map[string]interface{}{
+			"family_id":   familyID,
+			"trust_score": score,
+		})
+	}
+
+	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	updatedAt := time.Now().Format(time.RFC3339)
+	changed := []string{}
+	for start := 0; start < len(rows); start += analyticsBatchSize {
+		batch := rows[start:min(start+analyticsBatchSize, len(rows))]
+
+		result, err := database.ExecuteWithTransaction(ctx, session, func(ctx context.Context, tx neo4j.ManagedTransaction) (interface{}, error) {
+			query := `
+				UNWIND $rows as row
+				MATCH (f:Family {family_id: row.family_id})
+				WHERE f.trust_score IS NULL OR abs(f.trust_score - row.trust_score) >= $tolerance
+					OR coalesce(f.trust_history_sources[-1], '') <> $source
+				SET f.trust_score = row.trust_score,
+					f.updated_at = datetime($updated_at),
+					f.trust_history_scores = (coalesce(f.trust_history_scores, []) + row.trust_score)[-$max_history..],
+					f.trust_history_sources = (coalesce(f.trust_history_sources, []) + $source)[-$max_history..],
+					f.trust_history_at = (coalesce(f.trust_history_at, []) + $updated_at)[-$max_history..]
+				RETURN f.family_id as family_id
+			`
+
+			result, err := tx.Run(ctx, query, map[string]interface{}{
+				"rows":        batch,
+				"tolerance":   models.TrustScoreTolerance,
+				"source":      source,
+				"max_history": maxTrustHistory,
+				"updated_at":  updatedAt,
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			var familyIDs []string
+			for result.Next(ctx) {
+				familyIDs = append(familyIDs, result.Record().Values[0].(string))
+			}
+			return familyIDs, result.Err()
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to update trust score batch at %d: %w", start, err)
+		}
+		changed = append(changed, result.([]string)...)
+	}
+
+	return changed, nil
+}
+
+// UpdateFamilyCommunities stores detected community IDs on family nodes in batches
+func (r *FamilyRepository) UpdateFamilyCommunities(ctx context.Context, assignments map[string]int) error {
+	rows := make([]map[string]interface{}, 0, len(assignments))
+	for familyID, communityID := range assignments {
+		rows = append(rows, map[string]interface{}{
+			"family_id":    familyID,
+			"community_id": communityID,
+		})
+	}
+
+	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	for start := 0; start < len(rows); start += analyticsBatchSize {
+		batch := rows[start:min(start+analyticsBatchSize, len(rows))]
+
+		_, err := database.ExecuteWithTransaction(ctx, session, func(ctx context.Context, tx neo4j.ManagedTransaction) (interface{}, error) {
+			query := `
+				UNWIND $rows as row
+				MATCH (f:Family {family_id: row.family_id})
+				SET f.community_id = row.community_id
+			`
+
+			_, err := tx.Run(ctx, query, map[string]interface{}{"rows": batch})
+			return nil, err
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update community batch at %d: %w", start, err)
+		}
+	}
+
+	return nil
+}
+
+// GetTopConnectors returns the active families with the highest betweenness centrality
+func (r *FamilyRepository) GetTopConnectors(ctx context.Context, criteria *models.ConnectorCriteria) ([]*models.Family, error) {
+	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	result, err := database.ExecuteReadTransaction(ctx, session, func(ctx context.Context, tx neo4j.ManagedTransaction) (interface{}, error) {
+		query := `
+			MATCH (f:Family)
+			WHERE f.active_status = 'ACTIVE'
+			  AND f.betweenness IS NOT NULL
+			  AND ($region = '' OR f.region = $region)
+			  AND ($caste = '' OR f.caste = $caste)
+			RETURN f
+			ORDER BY f.betweenness DESC, f.pagerank DESC
+			LIMIT $limit
+		`
+
+		result, err := tx.Run(ctx, query, map[string]interface{}{
+			"region": criteria.Region,
+			"caste":  criteria.Caste,
+			"limit":  criteria.Limit,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		var families []*models.Family
+		for result.Next(ctx) {
+			family, err := r.mapRecordToFamily(result.Record())
+			if err != nil {
+				return nil, err
+			}
+			families = append(families, family)
+		}
+
+		return families, result.Err()
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result.([]*models.Family), nil
+}
+
+// DismissSuggestion records that a family does not want another family suggested again
+func (r *FamilyRepository) DismissSuggestion(ctx context.Context, familyID, suggestedFamilyID string) error {
+	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	_, err := database.ExecuteWithTransaction(ctx, session, func(ctx context.Context, tx neo4j.ManagedTransaction) (interface{}, error) {
+		query := `
+			MATCH (f:Family {family_id: $family_id}), (s:Family {family_id: $suggested_family_id})
+			MERGE (f)-[d:DISMISSED_SUGGESTION]->(s)
+			SET d.dismissed_at = datetime($dismissed_at)
+		`
+
+		_, err := tx.Run(ctx, query, map[string]interface{}{
+			"family_id":           familyID,
+			"suggested_family_id": suggestedFamilyID,
+			"dismissed_at":        time.Now().Format(time.RFC3339),
+		})
+		return nil, err
+	})
+
+	return err
+}
+
+// GetDismissedSuggestions returns the families a family has dismissed as suggestions
+func (r *FamilyRepository) GetDismissedSuggestions(ctx context.Context, familyID string) ([]string, error) {
+	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	result, err := database.ExecuteReadTransaction(ctx, session, func(ctx context.Context, tx neo4j.ManagedTransaction) (interface{}, error) {
+		query := `
+			MATCH (:Family {family_id: $family_id})-[:DISMISSED_SUGGESTION]->(s:Family)
+			RETURN s.family_id as family_id
+		`
+
+		result, err := tx.Run(ctx, query, map[string]interface{}{"family_id": familyID})
+		if err != nil {
+			return nil, err
+		}
+
+		var familyIDs []string
+		for result.Next(ctx) {
+			if dismissedID, ok := result.Record().Values[0].(string); ok {
+				familyIDs = append(familyIDs, dismissedID)
+			}
+		}
+
+		return familyIDs, result.Err()
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result.([]string), nil
+}
+
 // Helper function to map Neo4j record to Family model
 func (r *FamilyRepository) mapRecordToFamily(record *neo4j.Record) (*models.Family, error) {
 	node, ok := record.Get("f")
@@ -438,6 +882,13 @@ func (r *FamilyRepository) mapRecordToFamily(record *neo4j.Record) (*models.Fami
 	if region, ok := props["region"].(string); ok {
 		family.Location.Region = region
 	}
+	if coordinates, ok := props["coordinates"].([]interface{}); ok {
+		for _, coordinate := range coordinates {
+			if value, ok := coordinate.(float64); ok {
+				family.Location.Coordinates = append(family.Location.Coordinates, value)
+			}
+		}
+	}
 
 	// Community
 	if caste, ok := props["caste"].(string); ok {
@@ -446,6 +897,9 @@ func (r *FamilyRepository) mapRecordToFamily(record *neo4j.Record) (*models.Fami
 	if subCaste, ok := props["sub_caste"].(string); ok {
 		family.Community.SubCaste = subCaste
 	}
+	if gotra, ok := props["gotra"].(string); ok {
+		family.Community.Gotra = gotra
+	}
 	if religion, ok := props["religion"].(string); ok {
 		family.Community.Religion = religion
 	}
@@ -456,6 +910,9 @@ func (r *FamilyRepository) mapRecordToFamily(record *neo4j.Record) (*models.Fami
 			}
 		}
 	}
+	if communityID, ok := props["community_id"].(int64); ok {
+		family.Community.CommunityGroup = int(communityID)
+	}
 
 	// Contact Info
 	if phone, ok := props["primary_phone"].(string); ok {
@@ -481,6 +938,23 @@ func (r *FamilyRepository) mapRecordToFamily(record *neo4j.Record) (*models.Fami
 		family.TrustScore = score
 	}
 
+	// Centrality
+	if pageRank, ok := props["pagerank"].(float64); ok {
+		family.Centrality.PageRank = pageRank
+	}
+	if betweenness, ok := props["betweenness"].(float64); ok {
+		family.Centrality.Betweenness = betweenness
+	}
+	if degreeCentrality, ok := props["degree_centrality"].(float64); ok {
+		family.Centrality.DegreeCentrality = degreeCentrality
+	}
+	if degree, ok := props["degree"].(int64); ok {
+		family.Centrality.Degree = int(degree)
+	}
+	if calculatedAt, ok := props["centrality_calculated_at"].(time.Time); ok {
+		family.Centrality.CalculatedAt = calculatedAt
+	}
+
 	// Privacy Settings
 	if visibility, ok := props["profile_visibility"].(string); ok {
 		family.PrivacySettings.ProfileVisibility = visibility
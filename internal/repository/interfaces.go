@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"families-linkedin/internal/models"
+	"time"
+)
+
+// ErrFamilyNotFound is returned, wrapped, when no family has the requested ID
+var ErrFamilyNotFound = errors.New("family not found")
+
+// FamilyStore defines the persistence operations for family nodes
+type FamilyStore interface {
+	CreateFamily(ctx context.Context, family *models.Family) error
+	GetFamilyByID(ctx context.Context, familyID string) (*models.Family, error)
+	UpdateFamily(ctx context.Context, family *models.Family) error
+	DeleteFamily(ctx context.Context, familyID string) error
+	SearchFamilies(ctx context.Context, criteria *models.FamilySearchCriteria) ([]*models.Family, error)
+	GetFamiliesByIDs(ctx context.Context, familyIDs []string) ([]*models.Family, error)
+	GetActiveFamilyIDs(ctx context.Context) ([]string, error)
+	GetVerifiedFamilyIDs(ctx context.Context, verifiedBy string) ([]string, error)
+	GetFamilyTrustFactors(ctx context.Context, familyID string, decay *models.StrengthDecay) (*models.TrustFactors, error)
+	UpdateFamilyTrustScore(ctx context.Context, familyID string, score float64, source string) error
+	UpdateFamilyTrustScores(ctx context.Context, scores map[string]float64, source string) ([]string, error)
+	GetTrustScoreHistory(ctx context.Context, familyID string) ([]models.TrustScoreRecord, error)
+	UpdateFamilyCentrality(ctx context.Context, scores map[string]models.Centrality) error
+	GetTopConnectors(ctx context.Context, criteria *models.ConnectorCriteria) ([]*models.Family, error)
+	UpdateFamilyCommunities(ctx context.Context, assignments map[string]int) error
+	DismissSuggestion(ctx context.Context, familyID, suggestedFamilyID string) error
+	GetDismissedSuggestions(ctx context.Context, familyID string) ([]string, error)
+}
+
+// PersonStore defines the persistence operations for person nodes
+type PersonStore interface {
+	CreatePerson(ctx context.Context, person *models.Person) error
+	GetPersonByID(ctx context.Context, personID string) (*models.Person, error)
+	GetPersonsByIDs(ctx context.Context, personIDs []string) ([]*models.Person, error)
+	GetPersonsByFamilyID(ctx context.Context, familyID string) ([]*models.Person, error)
+	SearchEligiblePersons(ctx context.Context, criteria *models.PersonSearchCriteria) ([]*models.Person, error)
+	UpdatePerson(ctx context.Context, person *models.Person) error
+	CreatePersonRelation(ctx context.Context, relation *models.PersonRelation) error
+	GetPersonRelations(ctx context.Context, personID string) ([]*models.PersonRelation, error)
+	GetPersonRelationsBulk(ctx context.Context, personIDs []string) (map[string][]*models.PersonRelation, error)
+	IsAncestor(ctx context.Context, ancestorID, personID string) (bool, error)
+}
+
+// ConnectionStore defines the persistence and traversal operations for FAMILY_RELATION edges
+type ConnectionStore interface {
+	CreateConnection(ctx context.Context, connection *models.FamilyConnection) error
+	FindShortestPath(ctx context.Context, fromFamilyID, toFamilyID string, maxDepth int) (*models.ConnectionPath, error)
+	FindBidirectionalPath(ctx context.Context, fromFamilyID, toFamilyID string, maxDepth int) (*models.ConnectionPath, error)
+	FindMultiplePaths(ctx context.Context, fromFamilyID, toFamilyID string, maxDepth, maxPaths int) ([]*models.ConnectionPath, error)
+	GetFamilyConnections(ctx context.Context, familyID string, degree int) ([]string, error)
+	GetConnectionStrength(ctx context.Context, family1ID, family2ID string) (float64, error)
+	GetFamilyEdges(ctx context.Context, familyID string) ([]*models.FamilyConnection, error)
+	GetFamilyEdgesBulk(ctx context.Context, familyIDs []string) (map[string][]*models.FamilyConnection, error)
+	GetConnection(ctx context.Context, fromFamilyID, toFamilyID string) (*models.FamilyConnection, error)
+	GetAllConnections(ctx context.Context) ([]*models.FamilyConnection, error)
+	GetMutualConnections(ctx context.Context, family1ID, family2ID string) ([]*models.MutualConnection, error)
+	CountMutualConnections(ctx context.Context, familyID string, otherFamilyIDs []string) (map[string]int, error)
+	ReconfirmConnection(ctx context.Context, fromFamilyID, toFamilyID string, reconfirmedAt time.Time) (*models.FamilyConnection, error)
+	ValidateNoCircularConnections(ctx context.Context, fromFamilyID, toFamilyID string) error
+	GetNetworkStats(ctx context.Context) (map[string]interface{}, error)
+}
+
+// Compile-time checks that the Neo4j repositories satisfy the store interfaces
+var (
+	_ FamilyStore     = (*FamilyRepository)(nil)
+	_ PersonStore     = (*PersonRepository)(nil)
+	_ ConnectionStore = (*ConnectionRepository)(nil)
+)
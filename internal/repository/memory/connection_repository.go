@@ -0,0 +1,393 @@
+package memory
+
+import (
+	"context"
+	"families-linkedin/internal/algorithms"
+	"families-linkedin/internal/models"
+	"families-linkedin/internal/repository"
+	"fmt"
+	"sort"
+	"time"
+)
+
+var _ repository.ConnectionStore = (*ConnectionRepository)(nil)
+
+// ConnectionRepository is the in-memory counterpart of repository.ConnectionRepository
+type ConnectionRepository struct {
+	store *Store
+}
+
+func NewConnectionRepository(store *Store) *ConnectionRepository {
+	return &ConnectionRepository{store: store}
+}
+
+// CreateConnection creates a new connection between families
+func (r *ConnectionRepository) CreateConnection(ctx context.Context, connection *models.FamilyConnection) error {
+	r.store.mutex.Lock()
+	defer r.store.mutex.Unlock()
+
+	_, fromExists := r.store.families[connection.FromFamilyID]
+	_, toExists := r.store.families[connection.ToFamilyID]
+	if !fromExists || !toExists {
+		return nil // MATCH found no families to connect
+	}
+
+	if _, exists := r.store.edge(connection.FromFamilyID, connection.ToFamilyID); exists {
+		return fmt.Errorf("connection already exists between families %s and %s",
+			connection.FromFamilyID, connection.ToFamilyID)
+	}
+
+	// Create bidirectional connection
+	forward := cloneConnection(connection)
+	backward := cloneConnection(connection)
+	backward.FromFamilyID, backward.ToFamilyID = connection.ToFamilyID, connection.FromFamilyID
+
+	r.store.addEdge(forward)
+	r.store.addEdge(backward)
+	return nil
+}
+
+// FindShortestPath finds the shortest verified path between two families
+func (r *ConnectionRepository) FindShortestPath(ctx context.Context, fromFamilyID, toFamilyID string, maxDepth int) (*models.ConnectionPath, error) {
+	if fromFamilyID == toFamilyID {
+		return &models.ConnectionPath{
+			SourceFamilyID: fromFamilyID,
+			TargetFamilyID: toFamilyID,
+			Path:           []string{fromFamilyID},
+			Degree:         0,
+			PathStrength:   1.0,
+			Verified:       true,
+			CalculatedAt:   time.Now(),
+		}, nil
+	}
+
+	r.store.mutex.RLock()
+	defer r.store.mutex.RUnlock()
+
+	paths, err := r.rankedPaths(ctx, fromFamilyID, toFamilyID, maxDepth, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, nil // No path found
+	}
+
+	return paths[0], nil
+}
+
+// FindBidirectionalPath finds a shortest verified path between two families with the
+// algorithms package's bidirectional BFS. Unlike FindShortestPath it does not prefer the
+// strongest of several equally short paths.
+func (r *ConnectionRepository) FindBidirectionalPath(ctx context.Context, fromFamilyID, toFamilyID string, maxDepth int) (*models.ConnectionPath, error) {
+	r.store.mutex.RLock()
+	defer r.store.mutex.RUnlock()
+
+	return algorithms.NewBidirectionalBFS(verifiedGraph{store: r.store}, nil).FindPath(ctx, fromFamilyID, toFamilyID, maxDepth, nil)
+}
+
+// FindMultiplePaths finds multiple verified paths between two families, shortest and strongest first
+func (r *ConnectionRepository) FindMultiplePaths(ctx context.Context, fromFamilyID, toFamilyID string, maxDepth, maxPaths int) ([]*models.ConnectionPath, error) {
+	if fromFamilyID == toFamilyID {
+		return nil, nil
+	}
+
+	r.store.mutex.RLock()
+	defer r.store.mutex.RUnlock()
+
+	return r.rankedPaths(ctx, fromFamilyID, toFamilyID, maxDepth, maxPaths)
+}
+
+// GetFamilyConnections retrieves the families reachable from a family.
+// Degrees 1-3 return families exactly that many hops away, larger degrees
+// return everything within the range, matching the Cypher variable-length patterns.
+func (r *ConnectionRepository) GetFamilyConnections(ctx context.Context, familyID string, degree int) ([]string, error) {
+	if degree < 1 {
+		return nil, fmt.Errorf("invalid connection degree: %d", degree)
+	}
+
+	r.store.mutex.RLock()
+	defer r.store.mutex.RUnlock()
+
+	reached := make(map[string]bool)
+	frontier := map[string]bool{familyID: true}
+
+	for hop := 1; hop <= degree; hop++ {
+		next := make(map[string]bool)
+		for id := range frontier {
+			for neighborID := range r.store.edges[id] {
+				next[neighborID] = true
+			}
+		}
+		frontier = next
+
+		if degree > 3 || hop == degree {
+			for id := range frontier {
+				reached[id] = true
+			}
+		}
+	}
+
+	if degree != 1 {
+		delete(reached, familyID) // Exclude self
+	}
+
+	connections := make([]string, 0, len(reached))
+	for id := range reached {
+		connections = append(connections, id)
+	}
+
+	sort.Slice(connections, func(i, j int) bool {
+		iScore, jScore := r.store.trustScore(connections[i]), r.store.trustScore(connections[j])
+		if iScore != jScore {
+			return iScore > jScore
+		}
+		return connections[i] < connections[j]
+	})
+
+	return connections, nil
+}
+
+// GetConnectionStrength returns the connection strength between two families
+func (r *ConnectionRepository) GetConnectionStrength(ctx context.Context, family1ID, family2ID string) (float64, error) {
+	r.store.mutex.RLock()
+	defer r.store.mutex.RUnlock()
+
+	if connection, exists := r.store.edge(family1ID, family2ID); exists {
+		return connection.Strength, nil
+	}
+
+	return 0.0, nil // No direct connection
+}
+
+// GetFamilyEdges retrieves the direct connection records of a family
+func (r *ConnectionRepository) GetFamilyEdges(ctx context.Context, familyID string) ([]*models.FamilyConnection, error) {
+	edges, err := r.GetFamilyEdgesBulk(ctx, []string{familyID})
+	if err != nil {
+		return nil, err
+	}
+
+	return edges[familyID], nil
+}
+
+// GetFamilyEdgesBulk retrieves the direct connection records of several families
+func (r *ConnectionRepository) GetFamilyEdgesBulk(ctx context.Context, familyIDs []string) (map[string][]*models.FamilyConnection, error) {
+	r.store.mutex.RLock()
+	defer r.store.mutex.RUnlock()
+
+	result := make(map[string][]*models.FamilyConnection, len(familyIDs))
+	for _, familyID := range familyIDs {
+		adjacent := r.store.edges[familyID]
+		if len(adjacent) == 0 {
+			continue
+		}
+
+		edges := make([]*models.FamilyConnection, 0, len(adjacent))
+		for _, connection := range adjacent {
+			edges = append(edges, cloneConnection(connection))
+		}
+
+		sort.Slice(edges, func(i, j int) bool {
+			iScore, jScore := r.store.trustScore(edges[i].ToFamilyID), r.store.trustScore(edges[j].ToFamilyID)
+			if iScore != jScore {
+				return iScore > jScore
+			}
+			return edges[i].ToFamilyID < edges[j].ToFamilyID
+		})
+
+		result[familyID] = edges
+	}
+
+	return result, nil
+}
+
+// GetConnection returns the edge record between two families, or nil when they are not directly connected
+func (r *ConnectionRepository) GetConnection(ctx context.Context, fromFamilyID, toFamilyID string) (*models.FamilyConnection, error) {
+	r.store.mutex.RLock()
+	defer r.store.mutex.RUnlock()
+
+	if connection, exists := r.store.edge(fromFamilyID, toFamilyID); exists {
+		return cloneConnection(connection), nil
+	}
+
+	return nil, nil // No direct connection
+}
+
+// GetAllConnections retrieves every stored connection record, in both directions
+func (r *ConnectionRepository) GetAllConnections(ctx context.Context) ([]*models.FamilyConnection, error) {
+	r.store.mutex.RLock()
+	defer r.store.mutex.RUnlock()
+
+	var connections []*models.FamilyConnection
+	for _, adjacent := range r.store.edges {
+		for _, connection := range adjacent {
+			connections = append(connections, cloneConnection(connection))
+		}
+	}
+
+	return connections, nil
+}
+
+// GetMutualConnections returns the active families directly connected to both families,
+// with the connection records joining them. Families and strengths are left to the caller.
+func (r *ConnectionRepository) GetMutualConnections(ctx context.Context, family1ID, family2ID string) ([]*models.MutualConnection, error) {
+	r.store.mutex.RLock()
+	defer r.store.mutex.RUnlock()
+
+	var mutuals []*models.MutualConnection
+	for _, mutualID := range r.store.neighborIDs(family1ID) {
+		toFamily2, exists := r.store.edge(family2ID, mutualID)
+		if !exists || !r.store.isActive(mutualID) {
+			continue
+		}
+		toFamily1, _ := r.store.edge(family1ID, mutualID)
+
+		mutuals = append(mutuals, &models.MutualConnection{
+			FamilyID:  mutualID,
+			ToFamily1: cloneConnection(toFamily1),
+			ToFamily2: cloneConnection(toFamily2),
+		})
+	}
+
+	return mutuals, nil
+}
+
+// CountMutualConnections counts the active families directly connected to both a family
+// and each of the other families. Families without any are left out.
+func (r *ConnectionRepository) CountMutualConnections(ctx context.Context, familyID string, otherFamilyIDs []string) (map[string]int, error) {
+	r.store.mutex.RLock()
+	defer r.store.mutex.RUnlock()
+
+	counts := make(map[string]int, len(otherFamilyIDs))
+	adjacent := r.store.edges[familyID]
+	for _, otherID := range otherFamilyIDs {
+		for mutualID := range r.store.edges[otherID] {
+			if _, exists := adjacent[mutualID]; exists && r.store.isActive(mutualID) {
+				counts[otherID]++
+			}
+		}
+	}
+
+	return counts, nil
+}
+
+// ReconfirmConnection records that two families reconfirmed their connection, resetting its
+// strength decay. It returns the updated record oriented from fromFamilyID, or nil when the
+// families are not directly connected.
+func (r *ConnectionRepository) ReconfirmConnection(ctx context.Context, fromFamilyID, toFamilyID string, reconfirmedAt time.Time) (*models.FamilyConnection, error) {
+	r.store.mutex.Lock()
+	defer r.store.mutex.Unlock()
+
+	forward, exists := r.store.edge(fromFamilyID, toFamilyID)
+	if !exists {
+		return nil, nil // No direct connection
+	}
+
+	forward.ReconfirmedAt = reconfirmedAt
+	if backward, exists := r.store.edge(toFamilyID, fromFamilyID); exists {
+		backward.ReconfirmedAt = reconfirmedAt
+	}
+
+	return cloneConnection(forward), nil
+}
+
+// ValidateNoCircularConnections ensures that adding a connection won't create invalid cycles
+func (r *ConnectionRepository) ValidateNoCircularConnections(ctx context.Context, fromFamilyID, toFamilyID string) error {
+	if fromFamilyID == toFamilyID {
+		return fmt.Errorf("cannot create connection from family to itself")
+	}
+
+	r.store.mutex.RLock()
+	defer r.store.mutex.RUnlock()
+
+	if _, exists := r.store.edge(fromFamilyID, toFamilyID); exists {
+		return fmt.Errorf("connection already exists")
+	}
+
+	return nil
+}
+
+// GetNetworkStats provides statistics about the family network
+func (r *ConnectionRepository) GetNetworkStats(ctx context.Context) (map[string]interface{}, error) {
+	r.store.mutex.RLock()
+	defer r.store.mutex.RUnlock()
+
+	totalFamilies := int64(len(r.store.families))
+	var totalConnections, verifiedConnections int64
+	for fromID, adjacent := range r.store.edges {
+		for toID, connection := range adjacent {
+			if fromID > toID {
+				continue // Count each bidirectional connection once
+			}
+			totalConnections++
+			if connection.Verified {
+				verifiedConnections++
+			}
+		}
+	}
+
+	var avgTrustScore interface{}
+	if totalFamilies > 0 {
+		total := 0.0
+		for _, family := range r.store.families {
+			total += family.TrustScore
+		}
+		avgTrustScore = total / float64(totalFamilies)
+	}
+
+	density := 0.0
+	if totalFamilies > 1 {
+		density = float64(totalConnections) / float64(totalFamilies*(totalFamilies-1)/2) * 100
+	}
+
+	return map[string]interface{}{
+		"total_families":             totalFamilies,
+		"total_connections":          totalConnections,
+		"verified_connections":       verifiedConnections,
+		"average_trust_score":        avgTrustScore,
+		"network_density_percentage": density,
+		"calculated_at":              time.Now(),
+	}, nil
+}
+
+// rankedPaths finds verified paths fewest hops first and, among equal lengths, strongest first,
+// with the algorithms package's Yen's K-shortest loopless paths. Like the Neo4j path queries
+// it mirrors, it reports the stored strengths without decay. Callers must hold the store lock.
+func (r *ConnectionRepository) rankedPaths(ctx context.Context, fromID, toID string, maxDepth, maxPaths int) ([]*models.ConnectionPath, error) {
+	return algorithms.NewKShortestPaths(verifiedGraph{store: r.store}, algorithms.RankByHops, nil).
+		FindMultiplePaths(ctx, fromID, toID, maxDepth, maxPaths, nil)
+}
+
+// verifiedGraph exposes the store's verified connections to the path finders.
+// Callers must hold the store lock for as long as the search runs.
+type verifiedGraph struct {
+	store *Store
+}
+
+func (g verifiedGraph) GetNeighbors(ctx context.Context, familyID string) ([]*models.FamilyConnection, error) {
+	var neighbors []*models.FamilyConnection
+	for _, neighborID := range g.store.neighborIDs(familyID) {
+		if connection, _ := g.store.edge(familyID, neighborID); connection.Verified {
+			neighbors = append(neighbors, connection)
+		}
+	}
+	return neighbors, nil
+}
+
+func (g verifiedGraph) GetNeighborsBulk(ctx context.Context, familyIDs []string) (map[string][]*models.FamilyConnection, error) {
+	neighbors := make(map[string][]*models.FamilyConnection, len(familyIDs))
+	for _, familyID := range familyIDs {
+		neighbors[familyID], _ = g.GetNeighbors(ctx, familyID)
+	}
+	return neighbors, nil
+}
+
+func (g verifiedGraph) GetConnection(ctx context.Context, from, to string) (*models.FamilyConnection, error) {
+	if connection, ok := g.store.edge(from, to); ok && connection.Verified {
+		return connection, nil
+	}
+	return nil, nil
+}
+
+func (g verifiedGraph) GetFamilyTrustScore(ctx context.Context, familyID string) (float64, error) {
+	return g.store.trustScore(familyID), nil
+}
@@ -0,0 +1,431 @@
+package memory
+
+import (
+	"context"
+	"families-linkedin/internal/models"
+	"families-linkedin/internal/repository"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+var _ repository.FamilyStore = (*FamilyRepository)(nil)
+
+// FamilyRepository is the in-memory counterpart of repository.FamilyRepository
+type FamilyRepository struct {
+	store *Store
+}
+
+func NewFamilyRepository(store *Store) *FamilyRepository {
+	return &FamilyRepository{store: store}
+}
+
+// CreateFamily creates a new family in the store
+func (r *FamilyRepository) CreateFamily(ctx context.Context, family *models.Family) error {
+	r.store.mutex.Lock()
+	defer r.store.mutex.Unlock()
+
+	if _, exists := r.store.families[family.ID]; exists {
+		return fmt.Errorf("family already exists: %s", family.ID)
+	}
+
+	r.store.families[family.ID] = cloneFamily(family)
+	return nil
+}
+
+// GetFamilyByID retrieves a family by its ID
+func (r *FamilyRepository) GetFamilyByID(ctx context.Context, familyID string) (*models.Family, error) {
+	r.store.mutex.RLock()
+	defer r.store.mutex.RUnlock()
+
+	family, exists := r.store.families[familyID]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", repository.ErrFamilyNotFound, familyID)
+	}
+
+	return cloneFamily(family), nil
+}
+
+// UpdateFamily updates an existing family
+func (r *FamilyRepository) UpdateFamily(ctx context.Context, family *models.Family) error {
+	r.store.mutex.Lock()
+	defer r.store.mutex.Unlock()
+
+	family.UpdatedAt = time.Now()
+
+	existing, exists := r.store.families[family.ID]
+	if !exists {
+		return nil // MATCH found nothing to update
+	}
+
+	updated := cloneFamily(family)
+	updated.CreatedAt = existing.CreatedAt
+	updated.Centrality = existing.Centrality // Only written by the analytics batch
+	updated.Community.CommunityGroup = existing.Community.CommunityGroup
+	r.store.families[family.ID] = updated
+	return nil
+}
+
+// DeleteFamily soft deletes a family (sets status to inactive)
+func (r *FamilyRepository) DeleteFamily(ctx context.Context, familyID string) error {
+	r.store.mutex.Lock()
+	defer r.store.mutex.Unlock()
+
+	if family, exists := r.store.families[familyID]; exists {
+		family.ActiveStatus = "INACTIVE"
+		family.UpdatedAt = time.Now()
+	}
+
+	return nil
+}
+
+// SearchFamilies searches for families based on criteria
+func (r *FamilyRepository) SearchFamilies(ctx context.Context, criteria *models.FamilySearchCriteria) ([]*models.Family, error) {
+	r.store.mutex.RLock()
+	defer r.store.mutex.RUnlock()
+
+	var families []*models.Family
+	for _, family := range r.store.families {
+		if family.ActiveStatus != "ACTIVE" {
+			continue
+		}
+		if criteria.City != "" && family.Location.City != criteria.City {
+			continue
+		}
+		if criteria.State != "" && family.Location.State != criteria.State {
+			continue
+		}
+		if criteria.Caste != "" && family.Community.Caste != criteria.Caste {
+			continue
+		}
+		if criteria.Religion != "" && family.Community.Religion != criteria.Religion {
+			continue
+		}
+		if criteria.MinTrustScore > 0 && family.TrustScore < criteria.MinTrustScore {
+			continue
+		}
+		if criteria.VerifiedOnly && family.Verification.Status != "VERIFIED" {
+			continue
+		}
+		if len(criteria.Languages) > 0 {
+			found := false
+			for _, lang := range family.Community.Languages {
+				if containsString(criteria.Languages, lang) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				continue
+			}
+		}
+		families = append(families, family)
+	}
+
+	sort.Slice(families, func(i, j int) bool {
+		if families[i].TrustScore != families[j].TrustScore {
+			return families[i].TrustScore > families[j].TrustScore
+		}
+		return families[i].CreatedAt.After(families[j].CreatedAt)
+	})
+
+	if criteria.Limit > 0 {
+		families = paginate(families, criteria.Offset, criteria.Limit)
+	}
+
+	results := make([]*models.Family, 0, len(families))
+	for _, family := range families {
+		results = append(results, cloneFamily(family))
+	}
+
+	return results, nil
+}
+
+// GetFamiliesByIDs retrieves multiple families by their IDs
+func (r *FamilyRepository) GetFamiliesByIDs(ctx context.Context, familyIDs []string) ([]*models.Family, error) {
+	if len(familyIDs) == 0 {
+		return []*models.Family{}, nil
+	}
+
+	r.store.mutex.RLock()
+	defer r.store.mutex.RUnlock()
+
+	var families []*models.Family
+	seen := make(map[string]bool)
+	for _, familyID := range familyIDs {
+		family, exists := r.store.families[familyID]
+		if !exists || seen[familyID] {
+			continue
+		}
+		seen[familyID] = true
+		families = append(families, cloneFamily(family))
+	}
+
+	sort.SliceStable(families, func(i, j int) bool {
+		return families[i].TrustScore > families[j].TrustScore
+	})
+
+	return families, nil
+}
+
+// GetActiveFamilyIDs returns the IDs of all active families, in ID order
+func (r *FamilyRepository) GetActiveFamilyIDs(ctx context.Context) ([]string, error) {
+	r.store.mutex.RLock()
+	defer r.store.mutex.RUnlock()
+
+	var familyIDs []string
+	for familyID, family := range r.store.families {
+		if family.ActiveStatus == "ACTIVE" {
+			familyIDs = append(familyIDs, familyID)
+		}
+	}
+	sort.Strings(familyIDs)
+
+	return familyIDs, nil
+}
+
+// GetVerifiedFamilyIDs returns the IDs of active, verified families verified by the given verifier, in ID order
+func (r *FamilyRepository) GetVerifiedFamilyIDs(ctx context.Context, verifiedBy string) ([]string, error) {
+	r.store.mutex.RLock()
+	defer r.store.mutex.RUnlock()
+
+	var familyIDs []string
+	for familyID, family := range r.store.families {
+		if family.ActiveStatus == "ACTIVE" && family.Verification.Status == "VERIFIED" && family.Verification.VerifiedBy == verifiedBy {
+			familyIDs = append(familyIDs, familyID)
+		}
+	}
+	sort.Strings(familyIDs)
+
+	return familyIDs, nil
+}
+
+// GetFamilyTrustFactors gathers the inputs to a family's connection-based trust score,
+// averaging the strength of verified connections after decay. It returns nil when the
+// family does not exist.
+func (r *FamilyRepository) GetFamilyTrustFactors(ctx context.Context, familyID string, decay *models.StrengthDecay) (*models.TrustFactors, error) {
+	r.store.mutex.RLock()
+	defer r.store.mutex.RUnlock()
+
+	family, exists := r.store.families[familyID]
+	if !exists {
+		return nil, nil
+	}
+
+	factors := &models.TrustFactors{
+		VerificationStatus: family.Verification.Status,
+		ActiveStatus:       family.ActiveStatus,
+		CreatedAt:          family.CreatedAt,
+	}
+
+	var verified []*models.FamilyConnection
+	for _, connection := range r.store.edges[familyID] {
+		if !connection.Verified {
+			factors.UnverifiedConnections++
+			continue
+		}
+		factors.VerifiedConnections++
+		verified = append(verified, connection)
+		switch connection.RelationType {
+		case "RELATIVE":
+			factors.RelativeConnections++
+		case "COMMUNITY_RELATION":
+			factors.CommunityConnections++
+		}
+	}
+	factors.AverageStrength = models.AverageDecayedStrength(verified, decay, time.Now())
+
+	return factors, nil
+}
+
+// maxTrustHistory bounds the trust score history kept for each family
+const maxTrustHistory = 50
+
+// UpdateFamilyTrustScore updates the family's trust score and appends it to the family's
+// trust history, recording where the score came from
+func (r *FamilyRepository) UpdateFamilyTrustScore(ctx context.Context, familyID string, score float64, source string) error {
+	r.store.mutex.Lock()
+	defer r.store.mutex.Unlock()
+
+	if family, exists := r.store.families[familyID]; exists {
+		r.recordTrustScore(family, score, source, time.Now())
+	}
+
+	return nil
+}
+
+// UpdateFamilyTrustScores stores recomputed trust scores, writing and recording only those
+// that moved by at least models.TrustScoreTolerance or last came from another source. It
+// returns the IDs of those families.
+func (r *FamilyRepository) UpdateFamilyTrustScores(ctx context.Context, scores map[string]float64, source string) ([]string, error) {
+	r.store.mutex.Lock()
+	defer r.store.mutex.Unlock()
+
+	now := time.Now()
+	changed := []string{}
+	for familyID, score := range scores {
+		family, exists := r.store.families[familyID]
+		if !exists {
+			continue
+		}
+		history := r.store.trustHistory[familyID]
+		sameSource := len(history) > 0 && history[len(history)-1].Source == source
+		if sameSource && math.Abs(family.TrustScore-score) < models.TrustScoreTolerance {
+			continue
+		}
+		r.recordTrustScore(family, score, source, now)
+		changed = append(changed, familyID)
+	}
+
+	return changed, nil
+}
+
+// recordTrustScore sets a family's trust score and appends it to the history. The caller
+// holds the write lock.
+func (r *FamilyRepository) recordTrustScore(family *models.Family, score float64, source string, now time.Time) {
+	family.TrustScore = score
+	family.UpdatedAt = now
+
+	history := append(r.store.trustHistory[family.ID], models.TrustScoreRecord{
+		Score:        score,
+		Source:       source,
+		CalculatedAt: now,
+	})
+	if len(history) > maxTrustHistory {
+		history = history[len(history)-maxTrustHistory:]
+	}
+	r.store.trustHistory[family.ID] = history
+}
+
+// GetTrustScoreHistory returns a family's recorded trust scores, newest first
+func (r *FamilyRepository) GetTrustScoreHistory(ctx context.Context, familyID string) ([]models.TrustScoreRecord, error) {
+	r.store.mutex.RLock()
+	defer r.store.mutex.RUnlock()
+
+	recorded := r.store.trustHistory[familyID]
+	history := make([]models.TrustScoreRecord, 0, len(recorded))
+	for i := len(recorded) - 1; i >= 0; i-- {
+		history = append(history, recorded[i])
+	}
+
+	return history, nil
+}
+
+// UpdateFamilyCentrality stores centrality scores on families
+func (r *FamilyRepository) UpdateFamilyCentrality(ctx context.Context, scores map[string]models.Centrality) error {
+	r.store.mutex.Lock()
+	defer r.store.mutex.Unlock()
+
+	for familyID, centrality := range scores {
+		if family, exists := r.store.families[familyID]; exists {
+			family.Centrality = centrality
+		}
+	}
+
+	return nil
+}
+
+// UpdateFamilyCommunities stores detected community IDs on families
+func (r *FamilyRepository) UpdateFamilyCommunities(ctx context.Context, assignments map[string]int) error {
+	r.store.mutex.Lock()
+	defer r.store.mutex.Unlock()
+
+	for familyID, communityID := range assignments {
+		if family, exists := r.store.families[familyID]; exists {
+			family.Community.CommunityGroup = communityID
+		}
+	}
+
+	return nil
+}
+
+// GetTopConnectors returns the active families with the highest betweenness centrality
+func (r *FamilyRepository) GetTopConnectors(ctx context.Context, criteria *models.ConnectorCriteria) ([]*models.Family, error) {
+	r.store.mutex.RLock()
+	defer r.store.mutex.RUnlock()
+
+	var families []*models.Family
+	for _, family := range r.store.families {
+		if family.ActiveStatus != "ACTIVE" || family.Centrality.CalculatedAt.IsZero() {
+			continue
+		}
+		if criteria.Region != "" && family.Location.Region != criteria.Region {
+			continue
+		}
+		if criteria.Caste != "" && family.Community.Caste != criteria.Caste {
+			continue
+		}
+		families = append(families, family)
+	}
+
+	sort.Slice(families, func(i, j int) bool {
+		if families[i].Centrality.Betweenness != families[j].Centrality.Betweenness {
+			return families[i].Centrality.Betweenness > families[j].Centrality.Betweenness
+		}
+		if families[i].Centrality.PageRank != families[j].Centrality.PageRank {
+			return families[i].Centrality.PageRank > families[j].Centrality.PageRank
+		}
+		return families[i].ID < families[j].ID
+	})
+
+	if criteria.Limit > 0 {
+		families = paginate(families, 0, criteria.Limit)
+	}
+
+	results := make([]*models.Family, 0, len(families))
+	for _, family := range families {
+		results = append(results, cloneFamily(family))
+	}
+
+	return results, nil
+}
+
+// DismissSuggestion records that a family does not want another family suggested again
+func (r *FamilyRepository) DismissSuggestion(ctx context.Context, familyID, suggestedFamilyID string) error {
+	r.store.mutex.Lock()
+	defer r.store.mutex.Unlock()
+
+	_, familyExists := r.store.families[familyID]
+	_, suggestedExists := r.store.families[suggestedFamilyID]
+	if !familyExists || !suggestedExists {
+		return nil // MATCH found nothing to link
+	}
+
+	dismissed, ok := r.store.dismissals[familyID]
+	if !ok {
+		dismissed = make(map[string]bool)
+		r.store.dismissals[familyID] = dismissed
+	}
+	dismissed[suggestedFamilyID] = true
+
+	return nil
+}
+
+// GetDismissedSuggestions returns the families a family has dismissed as suggestions
+func (r *FamilyRepository) GetDismissedSuggestions(ctx context.Context, familyID string) ([]string, error) {
+	r.store.mutex.RLock()
+	defer r.store.mutex.RUnlock()
+
+	var familyIDs []string
+	for dismissedID := range r.store.dismissals[familyID] {
+		familyIDs = append(familyIDs, dismissedID)
+	}
+	sort.Strings(familyIDs)
+
+	return familyIDs, nil
+}
+
+func paginate[T any](items []T, offset, limit int) []T {
+	if offset < 0 {
+		offset = 0
+	}
+	if offset >= len(items) {
+		return items[:0]
+	}
+	end := offset + limit
+	if end > len(items) {
+		end = len(items)
+	}
+	return items[offset:end]
+}
@@ -0,0 +1,266 @@
+package memory
+
+import (
+	"context"
+	"families-linkedin/internal/models"
+	"families-linkedin/internal/repository"
+	"fmt"
+	"sort"
+	"time"
+)
+
+var _ repository.PersonStore = (*PersonRepository)(nil)
+
+// PersonRepository is the in-memory counterpart of repository.PersonRepository
+type PersonRepository struct {
+	store *Store
+}
+
+func NewPersonRepository(store *Store) *PersonRepository {
+	return &PersonRepository{store: store}
+}
+
+// CreatePerson creates a new person attached to an existing family
+func (r *PersonRepository) CreatePerson(ctx context.Context, person *models.Person) error {
+	r.store.mutex.Lock()
+	defer r.store.mutex.Unlock()
+
+	// Like the Cypher MATCH on the family, nothing is created for an unknown family
+	if _, exists := r.store.families[person.FamilyID]; !exists {
+		return nil
+	}
+
+	if _, exists := r.store.persons[person.ID]; exists {
+		return fmt.Errorf("person already exists: %s", person.ID)
+	}
+
+	r.store.persons[person.ID] = clonePerson(person)
+	return nil
+}
+
+// GetPersonByID retrieves a person by ID
+func (r *PersonRepository) GetPersonByID(ctx context.Context, personID string) (*models.Person, error) {
+	r.store.mutex.RLock()
+	defer r.store.mutex.RUnlock()
+
+	person, exists := r.store.persons[personID]
+	if !exists {
+		return nil, fmt.Errorf("person not found: %s", personID)
+	}
+
+	return clonePerson(person), nil
+}
+
+// GetPersonsByIDs retrieves persons by their IDs, skipping IDs that match nobody
+func (r *PersonRepository) GetPersonsByIDs(ctx context.Context, personIDs []string) ([]*models.Person, error) {
+	r.store.mutex.RLock()
+	defer r.store.mutex.RUnlock()
+
+	persons := []*models.Person{}
+	seen := make(map[string]bool)
+	for _, personID := range personIDs {
+		person, exists := r.store.persons[personID]
+		if !exists || seen[personID] {
+			continue
+		}
+		seen[personID] = true
+		persons = append(persons, clonePerson(person))
+	}
+
+	return persons, nil
+}
+
+// GetPersonsByFamilyID retrieves all persons in a family
+func (r *PersonRepository) GetPersonsByFamilyID(ctx context.Context, familyID string) ([]*models.Person, error) {
+	r.store.mutex.RLock()
+	defer r.store.mutex.RUnlock()
+
+	var persons []*models.Person
+	for _, person := range r.store.persons {
+		if person.FamilyID == familyID {
+			persons = append(persons, clonePerson(person))
+		}
+	}
+
+	sort.Slice(persons, func(i, j int) bool {
+		if persons[i].Age != persons[j].Age {
+			return persons[i].Age > persons[j].Age
+		}
+		return persons[i].ID < persons[j].ID
+	})
+
+	return persons, nil
+}
+
+// SearchEligiblePersons searches for eligible marriage candidates
+func (r *PersonRepository) SearchEligiblePersons(ctx context.Context, criteria *models.PersonSearchCriteria) ([]*models.Person, error) {
+	r.store.mutex.RLock()
+	defer r.store.mutex.RUnlock()
+
+	var persons []*models.Person
+	for _, person := range r.store.persons {
+		if !person.EligibleForMarriage {
+			continue
+		}
+		if criteria.Gender != "" && person.Gender != criteria.Gender {
+			continue
+		}
+		if criteria.MinAge > 0 && person.Age < criteria.MinAge {
+			continue
+		}
+		if criteria.MaxAge > 0 && person.Age > criteria.MaxAge {
+			continue
+		}
+		if criteria.MaritalStatus != "" && person.MaritalStatus != criteria.MaritalStatus {
+			continue
+		}
+		if len(criteria.Education) > 0 && !containsString(criteria.Education, person.Education.HighestDegree) {
+			continue
+		}
+		if len(criteria.Profession) > 0 && !containsString(criteria.Profession, person.Profession.Industry) {
+			continue
+		}
+		if criteria.MinIncome > 0 && person.Profession.AnnualIncome < criteria.MinIncome {
+			continue
+		}
+		if criteria.MaxIncome > 0 && person.Profession.AnnualIncome > criteria.MaxIncome {
+			continue
+		}
+		persons = append(persons, person)
+	}
+
+	sort.Slice(persons, func(i, j int) bool {
+		if persons[i].Age != persons[j].Age {
+			return persons[i].Age < persons[j].Age
+		}
+		return persons[i].ID < persons[j].ID
+	})
+
+	if criteria.Limit > 0 {
+		persons = paginate(persons, criteria.Offset, criteria.Limit)
+	}
+
+	results := make([]*models.Person, 0, len(persons))
+	for _, person := range persons {
+		results = append(results, clonePerson(person))
+	}
+
+	return results, nil
+}
+
+// UpdatePerson updates an existing person
+func (r *PersonRepository) UpdatePerson(ctx context.Context, person *models.Person) error {
+	r.store.mutex.Lock()
+	defer r.store.mutex.Unlock()
+
+	person.UpdatedAt = time.Now()
+
+	existing, exists := r.store.persons[person.ID]
+	if !exists {
+		return nil // MATCH found nothing to update
+	}
+
+	updated := clonePerson(person)
+	updated.FamilyID = existing.FamilyID
+	updated.CreatedAt = existing.CreatedAt
+	r.store.persons[person.ID] = updated
+	return nil
+}
+
+// CreatePersonRelation stores a PARENT_OF or SPOUSE_OF relationship between two existing
+// persons. Like the Cypher MERGE, an existing relationship of the same type is left alone.
+func (r *PersonRepository) CreatePersonRelation(ctx context.Context, relation *models.PersonRelation) error {
+	r.store.mutex.Lock()
+	defer r.store.mutex.Unlock()
+
+	if _, exists := r.store.persons[relation.FromPersonID]; !exists {
+		return nil // MATCH found nothing to relate
+	}
+	if _, exists := r.store.persons[relation.ToPersonID]; !exists {
+		return nil
+	}
+
+	for _, existing := range r.store.personRelations[relation.FromPersonID] {
+		if existing.Type != relation.Type {
+			continue
+		}
+		if existing.FromPersonID == relation.FromPersonID && existing.ToPersonID == relation.ToPersonID {
+			return nil
+		}
+		if relation.Type == models.RelationSpouseOf && existing.Joins(relation.FromPersonID, relation.ToPersonID) {
+			return nil
+		}
+	}
+
+	stored := *relation
+	r.store.personRelations[relation.FromPersonID] = append(r.store.personRelations[relation.FromPersonID], &stored)
+	r.store.personRelations[relation.ToPersonID] = append(r.store.personRelations[relation.ToPersonID], &stored)
+	return nil
+}
+
+// GetPersonRelations retrieves the relationships of a person, in either direction
+func (r *PersonRepository) GetPersonRelations(ctx context.Context, personID string) ([]*models.PersonRelation, error) {
+	relations, err := r.GetPersonRelationsBulk(ctx, []string{personID})
+	if err != nil {
+		return nil, err
+	}
+
+	return relations[personID], nil
+}
+
+// GetPersonRelationsBulk retrieves the relationships of several persons, in either direction
+func (r *PersonRepository) GetPersonRelationsBulk(ctx context.Context, personIDs []string) (map[string][]*models.PersonRelation, error) {
+	r.store.mutex.RLock()
+	defer r.store.mutex.RUnlock()
+
+	result := make(map[string][]*models.PersonRelation, len(personIDs))
+	for _, personID := range personIDs {
+		stored := r.store.personRelations[personID]
+		if len(stored) == 0 {
+			continue
+		}
+
+		relations := make([]*models.PersonRelation, 0, len(stored))
+		for _, relation := range stored {
+			clone := *relation
+			relations = append(relations, &clone)
+		}
+
+		sort.Slice(relations, func(i, j int) bool {
+			if relations[i].Type != relations[j].Type {
+				return relations[i].Type < relations[j].Type
+			}
+			return relations[i].Other(personID) < relations[j].Other(personID)
+		})
+
+		result[personID] = relations
+	}
+
+	return result, nil
+}
+
+// IsAncestor reports whether ancestorID is a parent, grandparent or earlier ancestor of personID
+func (r *PersonRepository) IsAncestor(ctx context.Context, ancestorID, personID string) (bool, error) {
+	r.store.mutex.RLock()
+	defer r.store.mutex.RUnlock()
+
+	visited := map[string]bool{personID: true}
+	queue := []string{personID}
+	for head := 0; head < len(queue); head++ {
+		for _, relation := range r.store.personRelations[queue[head]] {
+			if relation.Type != models.RelationParentOf || relation.ToPersonID != queue[head] {
+				continue
+			}
+			parentID := relation.FromPersonID
+			if parentID == ancestorID {
+				return true, nil
+			}
+			if !visited[parentID] {
+				visited[parentID] = true
+				queue = append(queue, parentID)
+			}
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,119 @@
+// Package memory provides an in-process implementation of the repository
+// store interfaces so the API and services can run without a Neo4j instance.
+package memory
+
+import (
+	"families-linkedin/internal/models"
+	"sort"
+	"sync"
+)
+
+// Store holds the shared in-memory graph used by the memory repositories
+type Store struct {
+	families map[string]*models.Family
+	persons  map[string]*models.Person
+	// edges mirrors the two directed FAMILY_RELATION relationships created per connection
+	edges map[string]map[string]*models.FamilyConnection
+	// dismissals holds, per family, the families it no longer wants suggested
+	dismissals map[string]map[string]bool
+	// trustHistory holds each family's recorded trust scores, oldest first
+	trustHistory map[string][]models.TrustScoreRecord
+	// personRelations holds every PARENT_OF and SPOUSE_OF relationship under both persons
+	personRelations map[string][]*models.PersonRelation
+	mutex           sync.RWMutex
+}
+
+// NewStore creates an empty in-memory graph store
+func NewStore() *Store {
+	return &Store{
+		families:        make(map[string]*models.Family),
+		persons:         make(map[string]*models.Person),
+		edges:           make(map[string]map[string]*models.FamilyConnection),
+		dismissals:      make(map[string]map[string]bool),
+		trustHistory:    make(map[string][]models.TrustScoreRecord),
+		personRelations: make(map[string][]*models.PersonRelation),
+	}
+}
+
+// neighborIDs returns the IDs adjacent to a family in a stable order.
+// Callers must hold the store lock.
+func (s *Store) neighborIDs(familyID string) []string {
+	adjacent := s.edges[familyID]
+	ids := make([]string, 0, len(adjacent))
+	for id := range adjacent {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// edge returns the stored relationship from one family to another.
+// Callers must hold the store lock.
+func (s *Store) edge(fromID, toID string) (*models.FamilyConnection, bool) {
+	adjacent, ok := s.edges[fromID]
+	if !ok {
+		return nil, false
+	}
+	connection, ok := adjacent[toID]
+	return connection, ok
+}
+
+// addEdge stores a directed relationship. Callers must hold the write lock.
+func (s *Store) addEdge(connection *models.FamilyConnection) {
+	adjacent, ok := s.edges[connection.FromFamilyID]
+	if !ok {
+		adjacent = make(map[string]*models.FamilyConnection)
+		s.edges[connection.FromFamilyID] = adjacent
+	}
+	adjacent[connection.ToFamilyID] = connection
+}
+
+// trustScore returns the stored trust score of a family, or zero if unknown.
+// Callers must hold the store lock.
+func (s *Store) trustScore(familyID string) float64 {
+	if family, ok := s.families[familyID]; ok {
+		return family.TrustScore
+	}
+	return 0
+}
+
+// isActive reports whether a family exists and is active. Callers must hold the store lock.
+func (s *Store) isActive(familyID string) bool {
+	family, ok := s.families[familyID]
+	return ok && family.ActiveStatus == "ACTIVE"
+}
+
+func cloneFamily(family *models.Family) *models.Family {
+	clone := *family
+	clone.Location.Coordinates = append([]float64(nil), family.Location.Coordinates...)
+	clone.Community.Languages = append([]string(nil), family.Community.Languages...)
+	return &clone
+}
+
+func clonePerson(person *models.Person) *models.Person {
+	clone := *person
+	clone.Hobbies = append([]string(nil), person.Hobbies...)
+	clone.Preferences.PreferredEducation = append([]string(nil), person.Preferences.PreferredEducation...)
+	clone.Preferences.PreferredProfession = append([]string(nil), person.Preferences.PreferredProfession...)
+	clone.Preferences.PreferredLocation = append([]string(nil), person.Preferences.PreferredLocation...)
+	clone.Preferences.PreferredCaste = append([]string(nil), person.Preferences.PreferredCaste...)
+	return &clone
+}
+
+func cloneConnection(connection *models.FamilyConnection) *models.FamilyConnection {
+	clone := *connection
+	clone.Metadata = make(map[string]interface{}, len(connection.Metadata))
+	for k, v := range connection.Metadata {
+		clone.Metadata[k] = v
+	}
+	return &clone
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -50,6 +50,14 @@ func (r *PersonRepository) CreatePerson(ctx context.Context, person *models.Pers
 				body_type: $body_type,
 				hobbies: $hobbies,
 				profile_visibility: $profile_visibility,
+				preferred_age_range: $preferred_age_range,
+				preferred_education: $preferred_education,
+				preferred_profession: $preferred_profession,
+				preferred_location: $preferred_location,
+				preferred_caste: $preferred_caste,
+				preferred_income: $preferred_income,
+				max_distance: $max_distance,
+				flexible_on_requirements: $flexible_on_requirements,
 				created_at: datetime($created_at),
 				updated_at: datetime($updated_at)
 			})
@@ -93,6 +101,7 @@ func (r *PersonRepository) CreatePerson(ctx context.Context, person *models.Pers
 			"role":                   role,
 			"primary_member":         person.EligibleForMarriage,
 		}
+		addPreferenceParams(params, person.Preferences)
 
 		_, err := tx.Run(ctx, query, params)
 		return nil, err
@@ -139,6 +148,48 @@ func (r *PersonRepository) GetPersonByID(ctx context.Context, personID string) (
 	return result.(*models.Person), nil
 }
 
+// GetPersonsByIDs retrieves persons by their IDs in one query, skipping IDs that match nobody
+func (r *PersonRepository) GetPersonsByIDs(ctx context.Context, personIDs []string) ([]*models.Person, error) {
+	if len(personIDs) == 0 {
+		return []*models.Person{}, nil
+	}
+
+	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	result, err := database.ExecuteReadTransaction(ctx, session, func(ctx context.Context, tx neo4j.ManagedTransaction) (interface{}, error) {
+		query := `
+			MATCH (p:Person)
+			WHERE p.person_id IN $person_ids
+			RETURN p
+		`
+
+		result, err := tx.Run(ctx, query, map[string]interface{}{
+			"person_ids": personIDs,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		persons := []*models.Person{}
+		for result.Next(ctx) {
+			person, err := r.mapRecordToPerson(result.Record())
+			if err != nil {
+				return nil, err
+			}
+			persons = append(persons, person)
+		}
+
+		return persons, result.Err()
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result.([]*models.Person), nil
+}
+
 // GetPersonsByFamilyID retrieves all persons in a family
 func (r *PersonRepository) GetPersonsByFamilyID(ctx context.Context, familyID string) ([]*models.Person, error) {
 	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
@@ -293,6 +344,14 @@ func (r *PersonRepository) UpdatePerson(ctx context.Context, person *models.Pers
 				p.body_type = $body_type,
 				p.hobbies = $hobbies,
 				p.profile_visibility = $profile_visibility,
+				p.preferred_age_range = $preferred_age_range,
+				p.preferred_education = $preferred_education,
+				p.preferred_profession = $preferred_profession,
+				p.preferred_location = $preferred_location,
+				p.preferred_caste = $preferred_caste,
+				p.preferred_income = $preferred_income,
+				p.max_distance = $max_distance,
+				p.flexible_on_requirements = $flexible_on_requirements,
 				p.updated_at = datetime($updated_at)
 			RETURN p.person_id
 		`
@@ -324,6 +383,7 @@ func (r *PersonRepository) UpdatePerson(ctx context.Context, person *models.Pers
 			"profile_visibility":     person.ProfileVisibility,
 			"updated_at":             person.UpdatedAt.Format(time.RFC3339),
 		}
+		addPreferenceParams(params, person.Preferences)
 
 		_, err := tx.Run(ctx, query, params)
 		return nil, err
@@ -332,6 +392,145 @@ func (r *PersonRepository) UpdatePerson(ctx context.Context, person *models.Pers
 	return err
 }
 
+// CreatePersonRelation stores a PARENT_OF or SPOUSE_OF relationship between two persons.
+// An existing relationship of the same type between them is left alone.
+func (r *PersonRepository) CreatePersonRelation(ctx context.Context, relation *models.PersonRelation) error {
+	// Relationship types cannot be parameters, so pick the query by type
+	var query string
+	switch relation.Type {
+	case models.RelationParentOf:
+		query = `
+			MATCH (parent:Person {person_id: $from_person_id}), (child:Person {person_id: $to_person_id})
+			MERGE (parent)-[r:PARENT_OF]->(child)
+			ON CREATE SET r.created_at = datetime($created_at)
+		`
+	case models.RelationSpouseOf:
+		query = `
+			MATCH (p1:Person {person_id: $from_person_id}), (p2:Person {person_id: $to_person_id})
+			MERGE (p1)-[r:SPOUSE_OF]-(p2)
+			ON CREATE SET r.created_at = datetime($created_at)
+		`
+	default:
+		return fmt.Errorf("unsupported person relation: %s", relation.Type)
+	}
+
+	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	_, err := database.ExecuteWithTransaction(ctx, session, func(ctx context.Context, tx neo4j.ManagedTransaction) (interface{}, error) {
+		_, err := tx.Run(ctx, query, map[string]interface{}{
+			"from_person_id": relation.FromPersonID,
+			"to_person_id":   relation.ToPersonID,
+			"created_at":     relation.CreatedAt.Format(time.RFC3339),
+		})
+		return nil, err
+	})
+
+	return err
+}
+
+// GetPersonRelations retrieves the relationships of a person, in either direction
+func (r *PersonRepository) GetPersonRelations(ctx context.Context, personID string) ([]*models.PersonRelation, error) {
+	relations, err := r.GetPersonRelationsBulk(ctx, []string{personID})
+	if err != nil {
+		return nil, err
+	}
+
+	return relations[personID], nil
+}
+
+// GetPersonRelationsBulk retrieves the relationships of several persons, in either direction
+func (r *PersonRepository) GetPersonRelationsBulk(ctx context.Context, personIDs []string) (map[string][]*models.PersonRelation, error) {
+	if len(personIDs) == 0 {
+		return map[string][]*models.PersonRelation{}, nil
+	}
+
+	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	result, err := database.ExecuteReadTransaction(ctx, session, func(ctx context.Context, tx neo4j.ManagedTransaction) (interface{}, error) {
+		query := `
+			UNWIND $person_ids as person_id
+			MATCH (p:Person {person_id: person_id})-[r:PARENT_OF|SPOUSE_OF]-(other:Person)
+			RETURN person_id, startNode(r).person_id as from_person_id, endNode(r).person_id as to_person_id,
+				type(r) as type, r.created_at as created_at
+			ORDER BY person_id, type, other.person_id
+		`
+		
+		result, err := tx.Run(ctx, query, map[string]interface{}{
+			"person_ids": personIDs,
+		})
+		
+		if err != nil {
+			return nil, err
+		}
+
+		relations := make(map[string][]*models.PersonRelation, len(personIDs))
+		for result.Next(ctx) {
+			record := result.Record()
+			personID, _ := record.Get("person_id")
+			fromPersonID, _ := record.Get("from_person_id")
+			toPersonID, _ := record.Get("to_person_id")
+			relationType, _ := record.Get("type")
+			createdAt, _ := record.Get("created_at")
+
+			relation := &models.PersonRelation{
+				FromPersonID: fromPersonID.(string),
+				ToPersonID:   toPersonID.(string),
+				Type:         relationType.(string),
+			}
+			if created, ok := createdAt.(time.Time); ok {
+				relation.CreatedAt = created
+			}
+
+			relations[personID.(string)] = append(relations[personID.(string)], relation)
+		}
+
+		return relations, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result.(map[string][]*models.PersonRelation), nil
+}
+
+// IsAncestor reports whether ancestorID is a parent, grandparent or earlier ancestor of personID
+func (r *PersonRepository) IsAncestor(ctx context.Context, ancestorID, personID string) (bool, error) {
+	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	result, err := database.ExecuteReadTransaction(ctx, session, func(ctx context.Context, tx neo4j.ManagedTransaction) (interface{}, error) {
+		query := `
+			MATCH (ancestor:Person {person_id: $ancestor_id}), (p:Person {person_id: $person_id})
+			RETURN EXISTS { MATCH (ancestor)-[:PARENT_OF*1..]->(p) } as is_ancestor
+		`
+		
+		result, err := tx.Run(ctx, query, map[string]interface{}{
+			"ancestor_id": ancestorID,
+			"person_id":   personID,
+		})
+		
+		if err != nil {
+			return nil, err
+		}
+
+		if result.Next(ctx) {
+			isAncestor, _ := result.Record().Get("is_ancestor")
+			return isAncestor.(bool), nil
+		}
+
+		return false, nil // Either person is missing
+	})
+
+	if err != nil {
+		return false, err
+	}
+
+	return result.(bool), nil
+}
+
 // Helper function to map Neo4j record to Person model
 func (r *PersonRepository) mapRecordToPerson(record *neo4j.Record) (*models.Person, error) {
 	node, ok := record.Get("p")
@@ -368,8 +567,8 @@ func (r *PersonRepository) mapRecordToPerson(record *neo4j.Record) (*models.Pers
 		person.Age = int(age)
 	}
 	
-	if dob, ok := props["date_of_birth"].(time.Time); ok {
-		person.DateOfBirth = dob
+	if dob, ok := props["date_of_birth"].(neo4j.Date); ok {
+		person.DateOfBirth = dob.Time()
 	}
 	
 	if status, ok := props["marital_status"].(string); ok {
@@ -436,6 +635,9 @@ func (r *PersonRepository) mapRecordToPerson(record *neo4j.Record) (*models.Pers
 		person.ProfileVisibility = visibility
 	}
 
+	// Marriage preferences
+	mapPreferences(props, &person.Preferences)
+
 	// Timestamps
 	if createdAt, ok := props["created_at"].(time.Time); ok {
 		person.CreatedAt = createdAt
@@ -446,4 +648,59 @@ func (r *PersonRepository) mapRecordToPerson(record *neo4j.Record) (*models.Pers
 	}
 
 	return person, nil
+}
+
+// addPreferenceParams adds a person's marriage preferences to the parameters of a write
+func addPreferenceParams(params map[string]interface{}, preferences models.MarriagePreferences) {
+	params["preferred_age_range"] = preferences.PreferredAgeRange[:]
+	params["preferred_education"] = preferences.PreferredEducation
+	params["preferred_profession"] = preferences.PreferredProfession
+	params["preferred_location"] = preferences.PreferredLocation
+	params["preferred_caste"] = preferences.PreferredCaste
+	params["preferred_income"] = preferences.PreferredIncome[:]
+	params["max_distance"] = preferences.MaxDistance
+	params["flexible_on_requirements"] = preferences.FlexibleOnRequirements
+}
+
+// mapPreferences reads marriage preferences from person node properties, where lists come
+// back as []interface{} and integers as int64
+func mapPreferences(props map[string]interface{}, preferences *models.MarriagePreferences) {
+	if ages := int64List(props["preferred_age_range"]); len(ages) == 2 {
+		preferences.PreferredAgeRange = [2]int{int(ages[0]), int(ages[1])}
+	}
+	preferences.PreferredEducation = stringList(props["preferred_education"])
+	preferences.PreferredProfession = stringList(props["preferred_profession"])
+	preferences.PreferredLocation = stringList(props["preferred_location"])
+	preferences.PreferredCaste = stringList(props["preferred_caste"])
+	if incomes := int64List(props["preferred_income"]); len(incomes) == 2 {
+		preferences.PreferredIncome = [2]int64{incomes[0], incomes[1]}
+	}
+	if distance, ok := props["max_distance"].(int64); ok {
+		preferences.MaxDistance = int(distance)
+	}
+	if flexible, ok := props["flexible_on_requirements"].(bool); ok {
+		preferences.FlexibleOnRequirements = flexible
+	}
+}
+
+func stringList(value interface{}) []string {
+	items, _ := value.([]interface{})
+	var list []string
+	for _, item := range items {
+		if str, ok := item.(string); ok {
+			list = append(list, str)
+		}
+	}
+	return list
+}
+
+func int64List(value interface{}) []int64 {
+	items, _ := value.([]interface{})
+	var list []int64
+	for _, item := range items {
+		if number, ok := item.(int64); ok {
+			list = append(list, number)
+		}
+	}
+	return list
 }
\ No newline at end of file
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"families-linkedin/internal/models"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// stored converts a write parameter to the type the driver reads it back as: lists become
+// []interface{} and integers int64
+func stored(value interface{}) interface{} {
+	switch v := reflect.ValueOf(value); v.Kind() {
+	case reflect.Slice:
+		list := make([]interface{}, v.Len())
+		for i := range list {
+			list[i] = stored(v.Index(i).Interface())
+		}
+		return list
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		return v.Int()
+	default:
+		return value
+	}
+}
+
+// personRecord builds the record the driver returns for a person written by CreatePerson
+func personRecord(person *models.Person) *neo4j.Record {
+	props := map[string]interface{}{
+		"person_id":             person.ID,
+		"family_id":             person.FamilyID,
+		"first_name":            person.FirstName,
+		"gender":                person.Gender,
+		"age":                   int64(person.Age),
+		"date_of_birth":         neo4j.DateOf(person.DateOfBirth),
+		"marital_status":        person.MaritalStatus,
+		"eligible_for_marriage": person.EligibleForMarriage,
+		"highest_degree":        person.Education.HighestDegree,
+		"industry":              person.Profession.Industry,
+		"annual_income":         person.Profession.AnnualIncome,
+	}
+
+	params := make(map[string]interface{})
+	addPreferenceParams(params, person.Preferences)
+	for key, value := range params {
+		props[key] = stored(value)
+	}
+
+	return &neo4j.Record{Keys: []string{"p"}, Values: []interface{}{neo4j.Node{Props: props}}}
+}
+
+func TestMapRecordToPersonRoundTrips(t *testing.T) {
+	person := models.NewPerson("fam-1", "Asha", "Rao", "Female", time.Date(1996, 4, 12, 0, 0, 0, 0, time.UTC))
+	person.Preferences = models.MarriagePreferences{
+		PreferredAgeRange:      [2]int{27, 33},
+		PreferredEducation:     []string{"Graduate", "Post-Graduate"},
+		PreferredProfession:    []string{"Technology"},
+		PreferredLocation:      []string{"Pune", "Mumbai"},
+		PreferredCaste:         []string{"Brahmin"},
+		PreferredIncome:        [2]int64{800000, 3000000},
+		MaxDistance:            150,
+		FlexibleOnRequirements: false,
+	}
+
+	mapped, err := (&PersonRepository{}).mapRecordToPerson(personRecord(person))
+	if err != nil {
+		t.Fatalf("mapRecordToPerson: %v", err)
+	}
+
+	if !reflect.DeepEqual(mapped.Preferences, person.Preferences) {
+		t.Errorf("preferences = %+v, want %+v", mapped.Preferences, person.Preferences)
+	}
+	if !mapped.DateOfBirth.Equal(person.DateOfBirth) {
+		t.Errorf("date of birth = %v, want %v", mapped.DateOfBirth, person.DateOfBirth)
+	}
+}
+
+func TestMutualMatchingOnMappedPersons(t *testing.T) {
+	newPerson := func(name, gender string, born int, preferences models.MarriagePreferences) *models.Person {
+		person := models.NewPerson("fam-"+name, name, "", gender, time.Date(born, 1, 1, 0, 0, 0, 0, time.UTC))
+		person.Education.HighestDegree = "Graduate"
+		person.Profession.Industry = "Technology"
+		person.Preferences = preferences
+		mapped, err := (&PersonRepository{}).mapRecordToPerson(personRecord(person))
+		if err != nil {
+			t.Fatalf("mapRecordToPerson: %v", err)
+		}
+		return mapped
+	}
+	seeker := newPerson("seeker", "Male", 1995, models.MarriagePreferences{PreferredAgeRange: [2]int{20, 40}})
+
+	tests := []struct {
+		name        string
+		preferences models.MarriagePreferences
+		wantMatch   bool
+		wantUnmet   []string
+	}{
+		{"no preferences stored", models.MarriagePreferences{}, true, nil},
+		{"age range accepts seeker", models.MarriagePreferences{PreferredAgeRange: [2]int{25, 40}}, true, nil},
+		{"age range rules seeker out", models.MarriagePreferences{PreferredAgeRange: [2]int{18, 22}}, false, []string{models.PreferenceAge}},
+		{"flexible on unmet education", models.MarriagePreferences{PreferredAgeRange: [2]int{25, 40}, PreferredEducation: []string{"Doctorate"}, FlexibleOnRequirements: true}, true, []string{models.PreferenceEducation}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			candidate := newPerson("candidate", "Female", 1997, tt.preferences)
+
+			if got := candidate.MatchesPreferences(seeker, nil, nil); got != tt.wantMatch {
+				t.Fatalf("candidate.MatchesPreferences(seeker) = %v, want %v", got, tt.wantMatch)
+			}
+			if !tt.wantMatch {
+				return
+			}
+
+			match := models.NewEligibleMatch(candidate, &models.Family{}, nil)
+			match.CalculateMutualScore(seeker, &models.Family{})
+			if !reflect.DeepEqual(match.CandidateUnmetPreferences, tt.wantUnmet) {
+				t.Errorf("candidate unmet preferences = %v, want %v", match.CandidateUnmetPreferences, tt.wantUnmet)
+			}
+			if match.CompatibilityScore <= 0 {
+				t.Errorf("mutual score = %v, want > 0", match.CompatibilityScore)
+			}
+		})
+	}
+}
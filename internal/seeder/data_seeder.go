@@ -151,6 +151,7 @@ func (s *DataSeeder) createFamily(familyID string, surname SurnameData, city Cit
 		Community: models.Community{
 			Caste:     surname.Caste,
 			SubCaste:  s.generateSubCaste(surname.Caste),
+			Gotra:     s.generateGotra(),
 			Religion:  "Hindu",
 			Languages: s.generateLanguages(city.Region),
 		},
@@ -159,10 +160,7 @@ func (s *DataSeeder) createFamily(familyID string, surname SurnameData, city Cit
 			Email:        fmt.Sprintf("%s.family@example.com", surname.Surname),
 			Address:      fmt.Sprintf("%s, %s", city.Name, city.State),
 		},
-		Verification: models.Verification{
-			Status:     s.generateVerificationStatus(),
-			VerifiedBy: "community",
-		},
+		Verification: s.generateVerification(),
 		TrustScore:   s.generateTrustScore(),
 		ActiveStatus: "ACTIVE",
 		PrivacySettings: models.PrivacySettings{
@@ -288,6 +286,11 @@ func (s *DataSeeder) generateSubCaste(caste string) string {
 	return "Other"
 }
 
+func (s *DataSeeder) generateGotra() string {
+	gotras := []string{"Kashyap", "Bharadwaj", "Vashishtha", "Atri", "Gautam", "Vishwamitra", "Jamadagni", "Agastya"}
+	return gotras[rand.Intn(len(gotras))]
+}
+
 func (s *DataSeeder) generateLanguages(region string) []string {
 	languages := map[string][]string{
 		"North": {"Hindi", "Punjabi", "Urdu"},
@@ -308,6 +311,21 @@ func (s *DataSeeder) generatePhone() string {
 		rand.Intn(10), rand.Intn(10), rand.Intn(10), rand.Intn(10), rand.Intn(10), rand.Intn(10))
 }
 
+// generateVerification verifies most families through the community and a few through
+// staff, who seed the propagated trust score
+func (s *DataSeeder) generateVerification() models.Verification {
+	status := s.generateVerificationStatus()
+	verifiedBy := "community"
+	if status == "VERIFIED" && rand.Intn(100) < 5 {
+		verifiedBy = models.VerifiedByStaff
+	}
+
+	return models.Verification{
+		Status:     status,
+		VerifiedBy: verifiedBy,
+	}
+}
+
 func (s *DataSeeder) generateVerificationStatus() string {
 	statuses := []string{"VERIFIED", "PENDING", "UNVERIFIED"}
 	weights := []int{70, 20, 10} // 70% verified
@@ -659,6 +677,7 @@ func (s *DataSeeder) familyToMap(family *models.Family) map[string]interface{} {
 		"region":              family.Location.Region,
 		"caste":               family.Community.Caste,
 		"sub_caste":           family.Community.SubCaste,
+		"gotra":               family.Community.Gotra,
 		"religion":            family.Community.Religion,
 		"languages":           family.Community.Languages,
 		"trust_score":         family.TrustScore,
@@ -696,6 +715,16 @@ func (s *DataSeeder) personToMap(person *models.Person) map[string]interface{} {
 		"body_type":             person.PhysicalAttributes.BodyType,
 		"hobbies":               person.Hobbies,
 		"profile_visibility":    person.ProfileVisibility,
+
+		"preferred_age_range":      person.Preferences.PreferredAgeRange[:],
+		"preferred_education":      person.Preferences.PreferredEducation,
+		"preferred_profession":     person.Preferences.PreferredProfession,
+		"preferred_location":       person.Preferences.PreferredLocation,
+		"preferred_caste":          person.Preferences.PreferredCaste,
+		"preferred_income":         person.Preferences.PreferredIncome[:],
+		"max_distance":             person.Preferences.MaxDistance,
+		"flexible_on_requirements": person.Preferences.FlexibleOnRequirements,
+
 		"created_at":            person.CreatedAt.Format(time.RFC3339),
 		"updated_at":            person.UpdatedAt.Format(time.RFC3339),
 		"role":                  s.determineRole(person),
@@ -0,0 +1,338 @@
+package service
+
+import (
+	"context"
+	"families-linkedin/internal/analytics"
+	"families-linkedin/internal/models"
+	"fmt"
+	"time"
+)
+
+// communityResolution weighs community size against density in Louvain; 1 is standard modularity
+const communityResolution = 1.0
+
+// CentralitySummary describes a centrality recomputation
+type CentralitySummary struct {
+	Families           int       `json:"families"`
+	Connections        int       `json:"connections"`
+	PageRankIterations int       `json:"pagerank_iterations"`
+	BetweennessSources int       `json:"betweenness_sources"`
+	BetweennessExact   bool      `json:"betweenness_exact"`
+	DurationMs         int64     `json:"duration_ms"`
+	CalculatedAt       time.Time `json:"calculated_at"`
+}
+
+// CommunitySummary describes a community detection run
+type CommunitySummary struct {
+	Families       int       `json:"families"`
+	Connections    int       `json:"connections"`
+	CommunityCount int       `json:"community_count"`
+	Modularity     float64   `json:"modularity"`
+	Levels         int       `json:"levels"`
+	DurationMs     int64     `json:"duration_ms"`
+	CalculatedAt   time.Time `json:"calculated_at"`
+}
+
+// CommunityReport lists the communities found by the last detection run, largest first,
+// and the connections bridging them, strongest first
+type CommunityReport struct {
+	CommunitySummary
+	Communities []analytics.CommunityStats  `json:"communities"`
+	Bridges     []analytics.CommunityBridge `json:"bridges"`
+}
+
+// TrustSummary describes a propagated trust recomputation
+type TrustSummary struct {
+	Families     int       `json:"families"`
+	Seeds        int       `json:"seeds"` // Staff-verified families trust flows from; none skips the run
+	Iterations   int       `json:"iterations"`
+	Updated      int       `json:"updated"` // Families whose score changed
+	DurationMs   int64     `json:"duration_ms"`
+	CalculatedAt time.Time `json:"calculated_at"`
+}
+
+// AnalyticsSummary describes a full analytics run
+type AnalyticsSummary struct {
+	Centrality  *CentralitySummary `json:"centrality"`
+	Communities *CommunitySummary  `json:"communities"`
+	Trust       *TrustSummary      `json:"trust"`
+	Landmarks   *LandmarkSummary   `json:"landmarks"`
+}
+
+// StartAnalytics recomputes graph centrality, communities, trust and the landmark distance
+// oracle now and then every
+// PerformanceConfig.AnalyticsRefreshInterval until ctx is cancelled. It does nothing
+// when the interval is not positive. Runs happen in the background; failures are
+// counted in metrics and the previous results stay in place.
+func (s *ConnectionService) StartAnalytics(ctx context.Context) {
+	interval := s.performance.AnalyticsRefreshInterval
+	if interval <= 0 {
+		return
+	}
+
+	go func() {
+		_, _ = s.RecomputeAnalytics(ctx)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				_, _ = s.RecomputeAnalytics(ctx)
+			}
+		}
+	}()
+}
+
+// RecomputeAnalytics recomputes centrality, communities and propagated trust over the whole
+// family graph and stores the results on the families, then rebuilds the landmark distance
+// oracle. Concurrent runs happen one at a time.
+func (s *ConnectionService) RecomputeAnalytics(ctx context.Context) (*AnalyticsSummary, error) {
+	s.analyticsMutex.Lock()
+	defer s.analyticsMutex.Unlock()
+
+	loadStart := time.Now()
+	connections, err := s.connectionRepo.GetAllConnections(ctx)
+	if err != nil {
+		s.metrics.IncrementCounter("connection_service_centrality_errors")
+		s.metrics.IncrementCounter("connection_service_communities_errors")
+		return nil, fmt.Errorf("failed to load connections: %w", err)
+	}
+	graph := analytics.NewGraph(connections)
+
+	centrality, err := s.recomputeCentrality(ctx, graph)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := s.detectCommunities(ctx, graph)
+	if err != nil {
+		return nil, err
+	}
+
+	trust, err := s.recomputeTrust(ctx, connections)
+	if err != nil {
+		return nil, err
+	}
+
+	landmarks := s.buildDistanceOracle(graph, loadStart)
+
+	return &AnalyticsSummary{
+		Centrality:  centrality,
+		Communities: &report.CommunitySummary,
+		Trust:       trust,
+		Landmarks:   landmarks,
+	}, nil
+}
+
+// recomputeCentrality computes PageRank, betweenness and degree centrality and stores the
+// scores on the families. The caller holds analyticsMutex.
+func (s *ConnectionService) recomputeCentrality(ctx context.Context, graph *analytics.Graph) (*CentralitySummary, error) {
+	start := time.Now()
+	defer s.metrics.RecordDuration("connection_service_centrality", start)
+
+	options := analytics.DefaultCentralityOptions()
+	if s.performance.BetweennessSamples > 0 {
+		options.BetweennessSamples = s.performance.BetweennessSamples
+	}
+
+	result := analytics.ComputeCentrality(graph, options)
+
+	if err := s.familyRepo.UpdateFamilyCentrality(ctx, result.Scores); err != nil {
+		s.metrics.IncrementCounter("connection_service_centrality_errors")
+		return nil, fmt.Errorf("failed to store centrality scores: %w", err)
+	}
+
+	s.metrics.IncrementCounter("connection_service_centrality_success")
+	s.metrics.RecordValue("connection_service_centrality_families", float64(result.Families))
+
+	return &CentralitySummary{
+		Families:           result.Families,
+		Connections:        result.Connections,
+		PageRankIterations: result.PageRankIterations,
+		BetweennessSources: result.BetweennessSources,
+		BetweennessExact:   result.BetweennessSources == result.Families,
+		DurationMs:         time.Since(start).Milliseconds(),
+		CalculatedAt:       time.Now(),
+	}, nil
+}
+
+// recomputeTrust propagates trust from the staff-verified families along verified
+// connections, weighted by their decayed strength, and stores the active families' 0-10
+// scores in one batch. Only scores that changed are written and recorded in the history.
+// Without staff-verified families the stored scores are left alone. The caller holds
+// analyticsMutex.
+func (s *ConnectionService) recomputeTrust(ctx context.Context, connections []*models.FamilyConnection) (*TrustSummary, error) {
+	start := time.Now()
+	defer s.metrics.RecordDuration("connection_service_trust", start)
+
+	familyIDs, err := s.familyRepo.GetActiveFamilyIDs(ctx)
+	if err != nil {
+		s.metrics.IncrementCounter("connection_service_trust_errors")
+		return nil, fmt.Errorf("failed to load families: %w", err)
+	}
+
+	seedIDs, err := s.familyRepo.GetVerifiedFamilyIDs(ctx, models.VerifiedByStaff)
+	if err != nil {
+		s.metrics.IncrementCounter("connection_service_trust_errors")
+		return nil, fmt.Errorf("failed to load trust seeds: %w", err)
+	}
+
+	// Inactive families neither hold nor pass on trust, and trust flows along decayed strengths
+	graph := analytics.NewFamilyGraph(familyIDs, decayConnections(connections, s.decay, start))
+	summary := &TrustSummary{Families: graph.Len()}
+
+	result := analytics.ComputeTrust(graph, seedIDs, analytics.DefaultTrustOptions())
+	summary.Seeds = result.Seeds
+	summary.Iterations = result.Iterations
+
+	if result.Seeds == 0 {
+		s.metrics.IncrementCounter("connection_service_trust_skipped")
+	} else {
+		changed, err := s.familyRepo.UpdateFamilyTrustScores(ctx, result.Scores, models.TrustSourcePropagated)
+		if err != nil {
+			s.metrics.IncrementCounter("connection_service_trust_errors")
+			return nil, fmt.Errorf("failed to store trust scores: %w", err)
+		}
+		summary.Updated = len(changed)
+		s.metrics.IncrementCounter("connection_service_trust_success")
+	}
+
+	s.metrics.RecordValue("connection_service_trust_seeds", float64(result.Seeds))
+
+	summary.DurationMs = time.Since(start).Milliseconds()
+	summary.CalculatedAt = time.Now()
+	return summary, nil
+}
+
+// DetectCommunities partitions the family graph into communities with strength-weighted
+// Louvain and stores each family's community ID
+func (s *ConnectionService) DetectCommunities(ctx context.Context) (*CommunityReport, error) {
+	s.analyticsMutex.Lock()
+	defer s.analyticsMutex.Unlock()
+
+	connections, err := s.connectionRepo.GetAllConnections(ctx)
+	if err != nil {
+		s.metrics.IncrementCounter("connection_service_communities_errors")
+		return nil, fmt.Errorf("failed to load connections: %w", err)
+	}
+
+	return s.detectCommunities(ctx, analytics.NewGraph(connections))
+}
+
+// detectCommunities runs community detection, stores the community IDs and keeps the
+// report for GetCommunities. The caller holds analyticsMutex.
+func (s *ConnectionService) detectCommunities(ctx context.Context, graph *analytics.Graph) (*CommunityReport, error) {
+	start := time.Now()
+	defer s.metrics.RecordDuration("connection_service_communities", start)
+
+	result := analytics.ComputeCommunities(graph, communityResolution)
+
+	if err := s.familyRepo.UpdateFamilyCommunities(ctx, result.Assignments); err != nil {
+		s.metrics.IncrementCounter("connection_service_communities_errors")
+		return nil, fmt.Errorf("failed to store communities: %w", err)
+	}
+
+	s.metrics.IncrementCounter("connection_service_communities_success")
+	s.metrics.RecordValue("connection_service_communities_count", float64(len(result.Communities)))
+	s.metrics.RecordValue("connection_service_communities_modularity", result.Modularity)
+	largest, singletons := 0, 0
+	for _, community := range result.Communities {
+		largest = max(largest, community.Members)
+		if community.Members == 1 {
+			singletons++
+		}
+	}
+	s.metrics.RecordValue("connection_service_communities_largest", float64(largest))
+	s.metrics.RecordValue("connection_service_communities_singletons", float64(singletons))
+
+	report := &CommunityReport{
+		CommunitySummary: CommunitySummary{
+			Families:       graph.Len(),
+			Connections:    graph.EdgeCount(),
+			CommunityCount: len(result.Communities),
+			Modularity:     result.Modularity,
+			Levels:         result.Levels,
+			DurationMs:     time.Since(start).Milliseconds(),
+			CalculatedAt:   time.Now(),
+		},
+		Communities: result.Communities,
+		Bridges:     result.Bridges,
+	}
+
+	s.communityMutex.Lock()
+	s.communities = report
+	s.communityMutex.Unlock()
+
+	return report, nil
+}
+
+// GetCommunities returns the largest communities from the last detection run and the
+// bridges between them, running detection first if it has not run in this process
+func (s *ConnectionService) GetCommunities(ctx context.Context, limit int) (*CommunityReport, error) {
+	start := time.Now()
+	defer s.metrics.RecordDuration("connection_service_get_communities", start)
+
+	if limit <= 0 || limit > 200 {
+		limit = 50 // Default limit
+	}
+
+	s.communityMutex.RLock()
+	report := s.communities
+	s.communityMutex.RUnlock()
+
+	if report == nil {
+		var err error
+		if report, err = s.DetectCommunities(ctx); err != nil {
+			s.metrics.IncrementCounter("connection_service_get_communities_errors")
+			return nil, err
+		}
+	}
+
+	// Communities are numbered by size, so the first limit IDs are the largest
+	trimmed := &CommunityReport{
+		CommunitySummary: report.CommunitySummary,
+		Communities:      report.Communities[:min(limit, len(report.Communities))],
+		Bridges:          []analytics.CommunityBridge{},
+	}
+	for _, bridge := range report.Bridges {
+		if bridge.ToCommunity <= limit {
+			trimmed.Bridges = append(trimmed.Bridges, bridge)
+		}
+	}
+
+	return trimmed, nil
+}
+
+// GetTopConnectors returns the families that sit on the most shortest paths between other
+// families, which makes them the best people to ask for introductions
+func (s *ConnectionService) GetTopConnectors(ctx context.Context, criteria *models.ConnectorCriteria) ([]*models.Family, error) {
+	start := time.Now()
+	defer s.metrics.RecordDuration("connection_service_get_connectors", start)
+
+	if criteria.Limit <= 0 || criteria.Limit > 100 {
+		criteria.Limit = 20 // Default limit
+	}
+
+	families, err := s.familyRepo.GetTopConnectors(ctx, criteria)
+	if err != nil {
+		s.metrics.IncrementCounter("connection_service_get_connectors_errors")
+		return nil, fmt.Errorf("failed to get top connectors: %w", err)
+	}
+
+	return families, nil
+}
+
+// decayConnections returns copies of connections whose strength is discounted by decay as of now
+func decayConnections(connections []*models.FamilyConnection, decay *models.StrengthDecay, now time.Time) []*models.FamilyConnection {
+	decayed := make([]*models.FamilyConnection, len(connections))
+	for i, connection := range connections {
+		copied := *connection
+		copied.Strength = connection.DecayedStrength(decay, now)
+		decayed[i] = &copied
+	}
+	return decayed
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"families-linkedin/internal/config"
+	"families-linkedin/internal/metrics"
+	"families-linkedin/internal/models"
+	"families-linkedin/internal/repository/memory"
+	"testing"
+)
+
+// countingFamilies counts the trust score writes made against the memory backend
+type countingFamilies struct {
+	*memory.FamilyRepository
+	writes int
+}
+
+func (f *countingFamilies) UpdateFamilyTrustScore(ctx context.Context, familyID string, score float64, source string) error {
+	f.writes++
+	return f.FamilyRepository.UpdateFamilyTrustScore(ctx, familyID, score, source)
+}
+
+func (f *countingFamilies) UpdateFamilyTrustScores(ctx context.Context, scores map[string]float64, source string) ([]string, error) {
+	f.writes++
+	return f.FamilyRepository.UpdateFamilyTrustScores(ctx, scores, source)
+}
+
+func TestRecomputeAnalyticsRecordsOnlyChangedTrustScores(t *testing.T) {
+	ctx := context.Background()
+	network := newTestNetwork(t, yearlyHalving)
+	network.familiesNamed("A", "B", "C", "D")
+	seed := network.family("S")
+	seed.Verification.Status = "VERIFIED"
+	seed.Verification.VerifiedBy = models.VerifiedByStaff
+	if err := network.families.UpdateFamily(ctx, seed); err != nil {
+		t.Fatalf("UpdateFamily: %v", err)
+	}
+	network.connect("S", "A", 0.9, 0)
+	network.connect("A", "B", 0.8, 0)
+	network.connect("B", "C", 0.7, 0)
+
+	families := &countingFamilies{FamilyRepository: network.families}
+	service := NewConnectionService(network.connections, families, config.PerformanceConfig{}, yearlyHalving, nil, metrics.NewCollector())
+
+	tests := []struct {
+		name        string
+		connect     [2]string // Connection added before the run, if any
+		wantUpdated int
+		wantHistory map[string]int
+	}{
+		{"first run replaces every default score", [2]string{}, 5, map[string]int{"S": 1, "A": 1, "B": 1, "C": 1, "D": 1}},
+		{"unchanged graph records nothing", [2]string{}, 0, map[string]int{"S": 1, "A": 1, "B": 1, "C": 1, "D": 1}},
+		{"new connection records the families it moves", [2]string{"C", "D"}, -1, map[string]int{"D": 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.connect[0] != "" {
+				network.connect(tt.connect[0], tt.connect[1], 0.9, 0)
+			}
+
+			families.writes = 0
+			summary, err := service.RecomputeAnalytics(ctx)
+			if err != nil {
+				t.Fatalf("RecomputeAnalytics: %v", err)
+			}
+			if families.writes != 1 {
+				t.Errorf("made %d trust score writes, want one batch", families.writes)
+			}
+			if tt.wantUpdated >= 0 && summary.Trust.Updated != tt.wantUpdated {
+				t.Errorf("updated = %d, want %d", summary.Trust.Updated, tt.wantUpdated)
+			}
+			if tt.wantUpdated < 0 && summary.Trust.Updated == 0 {
+				t.Errorf("updated = 0, want the families the new connection moves")
+			}
+
+			for familyID, want := range tt.wantHistory {
+				history, err := network.families.GetTrustScoreHistory(ctx, familyID)
+				if err != nil {
+					t.Fatalf("GetTrustScoreHistory(%s): %v", familyID, err)
+				}
+				if len(history) != want {
+					t.Errorf("history of %s has %d entries, want %d", familyID, len(history), want)
+				}
+			}
+		})
+	}
+}
+
+func TestGetTopConnectors(t *testing.T) {
+	ctx := context.Background()
+	network := newTestNetwork(t, yearlyHalving)
+	network.familiesNamed("H", "A", "B", "C", "X", "Y")
+	// H joins A, B and C; B leads on to X and X to Y
+	network.connect("H", "A", 0.9, 0)
+	network.connect("H", "B", 0.9, 0)
+	network.connect("H", "C", 0.9, 0)
+	network.connect("B", "X", 0.9, 0)
+	network.connect("X", "Y", 0.9, 0)
+
+	north, err := network.families.GetFamilyByID(ctx, "X")
+	if err != nil {
+		t.Fatalf("GetFamilyByID: %v", err)
+	}
+	north.Location.Region = "North"
+	if err := network.families.UpdateFamily(ctx, north); err != nil {
+		t.Fatalf("UpdateFamily: %v", err)
+	}
+
+	service := network.connectionService()
+	if _, err := service.RecomputeAnalytics(ctx); err != nil {
+		t.Fatalf("RecomputeAnalytics: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		criteria  models.ConnectorCriteria
+		wantFirst []string // The leading connectors, in order
+		wantCount int
+	}{
+		{"ranked by betweenness", models.ConnectorCriteria{Limit: 3}, []string{"H", "B", "X"}, 3},
+		{"limited", models.ConnectorCriteria{Limit: 1}, []string{"H"}, 1},
+		{"default limit", models.ConnectorCriteria{}, []string{"H", "B", "X"}, 6},
+		{"region", models.ConnectorCriteria{Region: "North"}, []string{"X"}, 1},
+		{"caste without families", models.ConnectorCriteria{Caste: "Maratha"}, []string{}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			criteria := tt.criteria
+			connectors, err := service.GetTopConnectors(ctx, &criteria)
+			if err != nil {
+				t.Fatalf("GetTopConnectors: %v", err)
+			}
+			if len(connectors) != tt.wantCount {
+				t.Fatalf("got %d connectors, want %d", len(connectors), tt.wantCount)
+			}
+			for i, familyID := range tt.wantFirst {
+				if connectors[i].ID != familyID {
+					t.Errorf("connector %d = %s, want %s", i, connectors[i].ID, familyID)
+				}
+			}
+		})
+	}
+}
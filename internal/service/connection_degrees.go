@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"families-linkedin/internal/analytics"
+	"fmt"
+	"time"
+)
+
+// MaxDegreeTargets bounds the families one degree lookup may ask about
+const MaxDegreeTargets = 200
+
+// Where a degree of separation came from
+const (
+	DegreeSourceOracle = "oracle" // Landmark bounds were tight
+	DegreeSourceSearch = "search" // A bidirectional BFS settled it
+)
+
+// LandmarkSummary describes a distance oracle build
+type LandmarkSummary struct {
+	Families     int       `json:"families"`
+	Landmarks    int       `json:"landmarks"`
+	DurationMs   int64     `json:"duration_ms"`
+	CalculatedAt time.Time `json:"calculated_at"`
+}
+
+// FamilyDegree is the degree of separation between a viewer and another family
+type FamilyDegree struct {
+	FamilyID   string `json:"family_id"`
+	Degree     int    `json:"degree"` // Hops from the viewer; -1 when not connected or not found within the search depth
+	Exact      bool   `json:"exact"`  // False when the degree is only known to be at least LowerBound
+	LowerBound int    `json:"lower_bound"`
+	UpperBound int    `json:"upper_bound"` // -1 when unknown
+	Source     string `json:"source"`
+}
+
+// BuildDistanceOracle loads the family graph and rebuilds the landmark distance oracle
+func (s *ConnectionService) BuildDistanceOracle(ctx context.Context) (*LandmarkSummary, error) {
+	s.analyticsMutex.Lock()
+	defer s.analyticsMutex.Unlock()
+
+	loadStart := time.Now()
+	connections, err := s.connectionRepo.GetAllConnections(ctx)
+	if err != nil {
+		s.metrics.IncrementCounter("connection_service_landmarks_errors")
+		return nil, fmt.Errorf("failed to load connections: %w", err)
+	}
+
+	return s.buildDistanceOracle(analytics.NewGraph(connections), loadStart), nil
+}
+
+// buildDistanceOracle precomputes BFS distances from the configured number of landmark
+// families and swaps in the new oracle. loadedAt is when the graph was read, so
+// connections created after it mark the oracle stale. The caller holds analyticsMutex.
+func (s *ConnectionService) buildDistanceOracle(graph *analytics.Graph, loadedAt time.Time) *LandmarkSummary {
+	start := time.Now()
+	defer s.metrics.RecordDuration("connection_service_landmarks", start)
+
+	oracle := analytics.NewDistanceOracle(graph, s.performance.LandmarkCount)
+
+	s.oracleMutex.Lock()
+	s.distanceOracle = oracle
+	s.oracleLoadedAt = loadedAt
+	s.oracleMutex.Unlock()
+
+	summary := &LandmarkSummary{
+		Families:     oracle.Families(),
+		Landmarks:    len(oracle.Landmarks()),
+		DurationMs:   time.Since(start).Milliseconds(),
+		CalculatedAt: time.Now(),
+	}
+
+	s.metrics.IncrementCounter("connection_service_landmarks_success")
+	s.metrics.RecordValue("connection_service_landmarks_count", float64(summary.Landmarks))
+
+	return summary
+}
+
+// markConnectionsChanged records that the graph changed under the distance oracle
+func (s *ConnectionService) markConnectionsChanged() {
+	s.oracleMutex.Lock()
+	s.graphChangedAt = time.Now()
+	s.oracleMutex.Unlock()
+}
+
+// GetDegrees returns the degree of separation between a viewer and each of the given
+// families. Landmark bounds answer most families without a search; the rest fall back to
+// a bidirectional BFS bounded by maxDepth and the landmark upper bound. Connections
+// created since the oracle was built can only shorten distances, so until the next build
+// only its upper bounds are trusted. The oracle is built first if it has not been built
+// in this process.
+func (s *ConnectionService) GetDegrees(ctx context.Context, viewerID string, familyIDs []string, maxDepth int) ([]FamilyDegree, error) {
+	start := time.Now()
+	defer s.metrics.RecordDuration("connection_service_degrees", start)
+
+	if maxDepth <= 0 || maxDepth > 6 {
+		maxDepth = 4 // Default max depth
+	}
+	if len(familyIDs) > MaxDegreeTargets {
+		s.metrics.IncrementCounter("connection_service_degrees_errors")
+		return nil, fmt.Errorf("at most %d families can be looked up at once", MaxDegreeTargets)
+	}
+
+	if _, err := s.familyRepo.GetFamilyByID(ctx, viewerID); err != nil {
+		s.metrics.IncrementCounter("connection_service_degrees_errors")
+		return nil, fmt.Errorf("family not found: %w", err)
+	}
+
+	s.oracleMutex.RLock()
+	oracle := s.distanceOracle
+	stale := !s.graphChangedAt.Before(s.oracleLoadedAt)
+	s.oracleMutex.RUnlock()
+
+	if oracle == nil {
+		if _, err := s.BuildDistanceOracle(ctx); err != nil {
+			s.metrics.IncrementCounter("connection_service_degrees_errors")
+			return nil, err
+		}
+		s.oracleMutex.RLock()
+		oracle = s.distanceOracle
+		stale = !s.graphChangedAt.Before(s.oracleLoadedAt)
+		s.oracleMutex.RUnlock()
+	}
+
+	degrees := make([]FamilyDegree, 0, len(familyIDs))
+	for _, familyID := range familyIDs {
+		bounds := oracle.Bounds(viewerID, familyID)
+		if stale && viewerID != familyID {
+			bounds = analytics.DistanceBounds{Lower: 1, Upper: bounds.Upper}
+		}
+
+		degree := FamilyDegree{
+			FamilyID:   familyID,
+			Degree:     -1,
+			LowerBound: bounds.Lower,
+			UpperBound: bounds.Upper,
+			Source:     DegreeSourceOracle,
+		}
+
+		switch {
+		case bounds.Exact():
+			degree.Exact = true
+			if !bounds.Unreachable {
+				degree.Degree = bounds.Lower
+			}
+			s.metrics.IncrementCounter("connection_service_degrees_oracle_hits")
+
+		case bounds.Lower > maxDepth:
+			// Too far to search; the lower bound is all that is known
+
+		default:
+			depth := maxDepth
+			if bounds.Upper >= 0 {
+				depth = min(depth, bounds.Upper)
+			}
+
+			path, err := s.pathFinder.FindPath(ctx, viewerID, familyID, depth, nil)
+			if err != nil {
+				s.metrics.IncrementCounter("connection_service_degrees_errors")
+				return nil, fmt.Errorf("failed to find path to %s: %w", familyID, err)
+			}
+			s.metrics.IncrementCounter("connection_service_degrees_searches")
+
+			degree.Source = DegreeSourceSearch
+			if path != nil {
+				degree.Degree = path.Degree
+				degree.Exact = true
+				degree.LowerBound, degree.UpperBound = path.Degree, path.Degree
+			} else {
+				degree.LowerBound = max(degree.LowerBound, depth+1)
+			}
+		}
+
+		degrees = append(degrees, degree)
+	}
+
+	return degrees, nil
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"families-linkedin/internal/analytics"
+	"fmt"
+	"time"
+)
+
+// maxComponentMembers caps the family IDs listed for each component in a fragility report
+const maxComponentMembers = 50
+
+// FragilityReport describes how well connected the active families are and which
+// families and connections the network depends on
+type FragilityReport struct {
+	Families                 int     `json:"families"`
+	Connections              int     `json:"connections"`
+	ComponentCount           int     `json:"component_count"`
+	LargestComponent         int     `json:"largest_component"`
+	LargestComponentCoverage float64 `json:"largest_component_coverage"` // Share of active families in the largest component
+	IsolatedFamilies         int     `json:"isolated_families"`          // Families without any connection
+	ArticulationPoints       int     `json:"articulation_points"`
+	Bridges                  int     `json:"bridges"`
+
+	ComponentSizes      []ComponentSize      `json:"component_sizes"`      // Largest first
+	Components          []NetworkComponent   `json:"components"`           // Components cut off from the largest one, largest first
+	CriticalFamilies    []CriticalFamily     `json:"critical_families"`    // Articulation points, most stranding first
+	CriticalConnections []CriticalConnection `json:"critical_connections"` // Bridges, most stranding first
+
+	DurationMs   int64     `json:"duration_ms"`
+	CalculatedAt time.Time `json:"calculated_at"`
+}
+
+// ComponentSize counts the components of one size
+type ComponentSize struct {
+	Size  int `json:"size"`
+	Count int `json:"count"`
+}
+
+// NetworkComponent is a group of families reachable from each other but from no one else.
+// Components are numbered by size, so the largest is 1.
+type NetworkComponent struct {
+	ID        int      `json:"id"`
+	Size      int      `json:"size"`
+	FamilyIDs []string `json:"family_ids"` // Up to maxComponentMembers
+}
+
+// CriticalFamily is a family whose departure would split its component
+type CriticalFamily struct {
+	FamilyID   string `json:"family_id"`
+	FamilyName string `json:"family_name"`
+	Component  int    `json:"component"`
+	Pieces     int    `json:"pieces"`   // Components left behind once the family is gone
+	Stranded   int    `json:"stranded"` // Families that would lose their route to the rest of the component
+}
+
+// CriticalConnection is a connection whose removal would split its component
+type CriticalConnection struct {
+	FromFamilyID string  `json:"from_family_id"`
+	ToFamilyID   string  `json:"to_family_id"`
+	RelationType string  `json:"relation_type"`
+	Strength     float64 `json:"strength"`
+	Component    int     `json:"component"`
+	Stranded     int     `json:"stranded"` // Families on the smaller side
+}
+
+// AnalyzeNetworkFragility finds the connected components of the active families and the
+// articulation points and bridges holding them together. limit caps the components,
+// critical families and critical connections listed.
+func (s *ConnectionService) AnalyzeNetworkFragility(ctx context.Context, limit int) (*FragilityReport, error) {
+	start := time.Now()
+	defer s.metrics.RecordDuration("connection_service_fragility", start)
+
+	if limit <= 0 || limit > 100 {
+		limit = 20 // Default limit
+	}
+
+	familyIDs, err := s.familyRepo.GetActiveFamilyIDs(ctx)
+	if err != nil {
+		s.metrics.IncrementCounter("connection_service_fragility_errors")
+		return nil, fmt.Errorf("failed to load families: %w", err)
+	}
+
+	connections, err := s.connectionRepo.GetAllConnections(ctx)
+	if err != nil {
+		s.metrics.IncrementCounter("connection_service_fragility_errors")
+		return nil, fmt.Errorf("failed to load connections: %w", err)
+	}
+
+	graph := analytics.NewFamilyGraph(familyIDs, connections)
+	result := analytics.AnalyzeFragility(graph)
+
+	report := &FragilityReport{
+		Families:            graph.Len(),
+		Connections:         graph.EdgeCount(),
+		ComponentCount:      len(result.Components),
+		ArticulationPoints:  len(result.ArticulationPoints),
+		Bridges:             len(result.Bridges),
+		ComponentSizes:      []ComponentSize{},
+		Components:          []NetworkComponent{},
+		CriticalFamilies:    []CriticalFamily{},
+		CriticalConnections: []CriticalConnection{},
+	}
+
+	for i, members := range result.Components {
+		if len(members) == 1 {
+			report.IsolatedFamilies++
+		}
+
+		// Components are sorted by size, so equal sizes are adjacent
+		if sizes := report.ComponentSizes; len(sizes) > 0 && sizes[len(sizes)-1].Size == len(members) {
+			sizes[len(sizes)-1].Count++
+		} else {
+			report.ComponentSizes = append(report.ComponentSizes, ComponentSize{Size: len(members), Count: 1})
+		}
+
+		if i == 0 || len(report.Components) >= limit {
+			continue
+		}
+		component := NetworkComponent{ID: i + 1, Size: len(members)}
+		for _, node := range members[:min(len(members), maxComponentMembers)] {
+			component.FamilyIDs = append(component.FamilyIDs, graph.ID(node))
+		}
+		report.Components = append(report.Components, component)
+	}
+	if len(result.Components) > 0 && graph.Len() > 0 {
+		report.LargestComponent = len(result.Components[0])
+		report.LargestComponentCoverage = float64(report.LargestComponent) / float64(graph.Len())
+	}
+
+	points := result.ArticulationPoints[:min(limit, len(result.ArticulationPoints))]
+	criticalIDs := make([]string, len(points))
+	for i, point := range points {
+		criticalIDs[i] = graph.ID(point.Node)
+	}
+	families, err := s.familyRepo.GetFamiliesByIDs(ctx, criticalIDs)
+	if err != nil {
+		s.metrics.IncrementCounter("connection_service_fragility_errors")
+		return nil, fmt.Errorf("failed to load critical families: %w", err)
+	}
+	names := make(map[string]string, len(families))
+	for _, family := range families {
+		names[family.ID] = family.Name
+	}
+	for i, point := range points {
+		report.CriticalFamilies = append(report.CriticalFamilies, CriticalFamily{
+			FamilyID:   criticalIDs[i],
+			FamilyName: names[criticalIDs[i]],
+			Component:  point.Component + 1,
+			Pieces:     point.Pieces,
+			Stranded:   point.Stranded,
+		})
+	}
+
+	for _, bridge := range result.Bridges[:min(limit, len(result.Bridges))] {
+		report.CriticalConnections = append(report.CriticalConnections, CriticalConnection{
+			FromFamilyID: bridge.Connection.FromFamilyID,
+			ToFamilyID:   bridge.Connection.ToFamilyID,
+			RelationType: bridge.Connection.RelationType,
+			Strength:     bridge.Connection.Strength,
+			Component:    bridge.Component + 1,
+			Stranded:     bridge.Stranded,
+		})
+	}
+
+	s.metrics.RecordValue("connection_service_components", float64(report.ComponentCount))
+	s.metrics.RecordValue("connection_service_largest_component_coverage", report.LargestComponentCoverage)
+	s.metrics.RecordValue("connection_service_articulation_points", float64(report.ArticulationPoints))
+	s.metrics.RecordValue("connection_service_bridges", float64(report.Bridges))
+
+	report.DurationMs = time.Since(start).Milliseconds()
+	report.CalculatedAt = time.Now()
+
+	return report, nil
+}
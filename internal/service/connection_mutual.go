@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"families-linkedin/internal/models"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// MaxMutualCountFamilies bounds the families one mutual connection count may ask about
+const MaxMutualCountFamilies = 200
+
+// MutualConnectionPage is one page of the families two families are both connected to
+type MutualConnectionPage struct {
+	Family1ID string                     `json:"family1_id"`
+	Family2ID string                     `json:"family2_id"`
+	Total     int                        `json:"total"`
+	Offset    int                        `json:"offset"`
+	Limit     int                        `json:"limit"`
+	Mutual    []*models.MutualConnection `json:"mutual_connections"`
+}
+
+// GetMutualConnections lists the active families directly connected to both families,
+// strongest first. A mutual family's combined strength is the decayed strength of the
+// two-hop path through it, the product of its two connections' strengths. Only the
+// requested page of families is loaded.
+func (s *ConnectionService) GetMutualConnections(ctx context.Context, family1ID, family2ID string, offset, limit int) (*MutualConnectionPage, error) {
+	start := time.Now()
+	defer s.metrics.RecordDuration("connection_service_mutual", start)
+
+	if limit <= 0 || limit > 100 {
+		limit = 20 // Default limit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	if family1ID == family2ID {
+		s.metrics.IncrementCounter("connection_service_mutual_errors")
+		return nil, fmt.Errorf("mutual connections need two different families")
+	}
+
+	mutuals, err := s.connectionRepo.GetMutualConnections(ctx, family1ID, family2ID)
+	if err != nil {
+		s.metrics.IncrementCounter("connection_service_mutual_errors")
+		return nil, fmt.Errorf("failed to get mutual connections: %w", err)
+	}
+
+	now := time.Now()
+	for _, mutual := range mutuals {
+		mutual.CombinedStrength = mutual.ToFamily1.DecayedStrength(s.decay, now) * mutual.ToFamily2.DecayedStrength(s.decay, now)
+	}
+	sort.Slice(mutuals, func(i, j int) bool {
+		if mutuals[i].CombinedStrength != mutuals[j].CombinedStrength {
+			return mutuals[i].CombinedStrength > mutuals[j].CombinedStrength
+		}
+		return mutuals[i].FamilyID < mutuals[j].FamilyID
+	})
+
+	page := &MutualConnectionPage{
+		Family1ID: family1ID,
+		Family2ID: family2ID,
+		Total:     len(mutuals),
+		Offset:    offset,
+		Limit:     limit,
+		Mutual:    []*models.MutualConnection{},
+	}
+	if offset >= len(mutuals) {
+		return page, nil
+	}
+	page.Mutual = mutuals[offset:min(offset+limit, len(mutuals))]
+
+	familyIDs := make([]string, len(page.Mutual))
+	for i, mutual := range page.Mutual {
+		familyIDs[i] = mutual.FamilyID
+	}
+	families, err := s.familyRepo.GetFamiliesByIDs(ctx, familyIDs)
+	if err != nil {
+		s.metrics.IncrementCounter("connection_service_mutual_errors")
+		return nil, fmt.Errorf("failed to get mutual families: %w", err)
+	}
+	familiesByID := make(map[string]*models.Family, len(families))
+	for _, family := range families {
+		familiesByID[family.ID] = family
+	}
+	for _, mutual := range page.Mutual {
+		mutual.Family = familiesByID[mutual.FamilyID]
+	}
+
+	s.metrics.RecordValue("connection_service_mutual_count", float64(page.Total))
+	return page, nil
+}
+
+// CountMutualConnections counts the active families directly connected to both a family
+// and each of the other families, without loading them. It is cheap enough for listings
+// to show "N mutual families" next to every entry. Every other family gets a count, zero
+// included; the family itself is left out.
+func (s *ConnectionService) CountMutualConnections(ctx context.Context, familyID string, otherFamilyIDs []string) (map[string]int, error) {
+	start := time.Now()
+	defer s.metrics.RecordDuration("connection_service_mutual_counts", start)
+
+	if len(otherFamilyIDs) > MaxMutualCountFamilies {
+		s.metrics.IncrementCounter("connection_service_mutual_errors")
+		return nil, fmt.Errorf("at most %d families can be counted at once", MaxMutualCountFamilies)
+	}
+
+	others := make([]string, 0, len(otherFamilyIDs))
+	for _, otherID := range otherFamilyIDs {
+		if otherID != familyID {
+			others = append(others, otherID)
+		}
+	}
+
+	stored, err := s.connectionRepo.CountMutualConnections(ctx, familyID, others)
+	if err != nil {
+		s.metrics.IncrementCounter("connection_service_mutual_errors")
+		return nil, fmt.Errorf("failed to count mutual connections: %w", err)
+	}
+
+	counts := make(map[string]int, len(others))
+	for _, otherID := range others {
+		counts[otherID] = stored[otherID]
+	}
+
+	return counts, nil
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+// mutualNetwork joins A and Z through three mutual families: M1 strongly, M3 through a
+// connection decayed to half its strength and M2 weakly. N is connected to A only.
+func mutualNetwork(t *testing.T) *testNetwork {
+	network := newTestNetwork(t, yearlyHalving)
+	network.familiesNamed("A", "Z", "M1", "M2", "M3", "N")
+	network.connect("A", "M1", 0.9, 0)
+	network.connect("Z", "M1", 0.9, 0) // 0.81
+	network.connect("A", "M3", 0.9, year)
+	network.connect("Z", "M3", 0.9, 0) // 0.405 decayed, 0.81 without decay
+	network.connect("A", "M2", 0.8, 0)
+	network.connect("Z", "M2", 0.4, 0) // 0.32
+	network.connect("A", "N", 0.9, 0)
+	return network
+}
+
+func TestGetMutualConnections(t *testing.T) {
+	service := mutualNetwork(t).connectionService()
+
+	tests := []struct {
+		name      string
+		offset    int
+		limit     int
+		want      []string
+		wantLimit int
+	}{
+		{"strongest first at the default limit", 0, 0, []string{"M1", "M3", "M2"}, 20},
+		{"page inside the list", 1, 1, []string{"M3"}, 1},
+		{"last page shorter than the limit", 2, 5, []string{"M2"}, 5},
+		{"offset past the end", 3, 2, []string{}, 2},
+		{"negative offset starts at the beginning", -1, 2, []string{"M1", "M3"}, 2},
+		{"limit above the maximum", 0, 500, []string{"M1", "M3", "M2"}, 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := service.GetMutualConnections(context.Background(), "A", "Z", tt.offset, tt.limit)
+			if err != nil {
+				t.Fatalf("GetMutualConnections: %v", err)
+			}
+			if page.Total != 3 {
+				t.Errorf("total = %d, want 3 whatever the page", page.Total)
+			}
+			if page.Limit != tt.wantLimit {
+				t.Errorf("limit = %d, want %d", page.Limit, tt.wantLimit)
+			}
+
+			got := []string{}
+			for _, mutual := range page.Mutual {
+				got = append(got, mutual.FamilyID)
+				if mutual.Family == nil || mutual.Family.ID != mutual.FamilyID {
+					t.Errorf("mutual family %s not loaded", mutual.FamilyID)
+				}
+				if mutual.ToFamily1.FromFamilyID != "A" || mutual.ToFamily2.FromFamilyID != "Z" {
+					t.Errorf("connections of %s not oriented from A and Z", mutual.FamilyID)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("mutual connections = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := service.GetMutualConnections(context.Background(), "A", "A", 0, 0); err == nil {
+		t.Errorf("GetMutualConnections of a family with itself succeeded")
+	}
+}
+
+func TestCountMutualConnections(t *testing.T) {
+	service := mutualNetwork(t).connectionService()
+
+	tests := []struct {
+		name   string
+		others []string
+		want   map[string]int
+	}{
+		{"several families", []string{"Z", "N", "M1"}, map[string]int{"Z": 3, "N": 0, "M1": 0}},
+		{"the family itself is left out", []string{"A", "Z"}, map[string]int{"Z": 3}},
+		{"unknown family counts zero", []string{"Unknown"}, map[string]int{"Unknown": 0}},
+		{"no families", nil, map[string]int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			counts, err := service.CountMutualConnections(context.Background(), "A", tt.others)
+			if err != nil {
+				t.Fatalf("CountMutualConnections: %v", err)
+			}
+			if !reflect.DeepEqual(counts, tt.want) {
+				t.Errorf("counts = %v, want %v", counts, tt.want)
+			}
+		})
+	}
+
+	tooMany := make([]string, MaxMutualCountFamilies+1)
+	if _, err := service.CountMutualConnections(context.Background(), "A", tooMany); err == nil {
+		t.Errorf("CountMutualConnections of %d families succeeded", len(tooMany))
+	}
+}
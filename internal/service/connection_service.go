@@ -3,23 +3,48 @@ package service
 import (
 	"context"
 	"families-linkedin/internal/algorithms"
+	"families-linkedin/internal/analytics"
+	"families-linkedin/internal/cache"
+	"families-linkedin/internal/config"
+	"families-linkedin/internal/kinship"
 	"families-linkedin/internal/metrics"
 	"families-linkedin/internal/models"
 	"families-linkedin/internal/repository"
 	"fmt"
+	"slices"
+	"sort"
+	"sync"
 	"time"
 )
 
+// snapshotMetricsInterval is how often the graph snapshot staleness is published
+const snapshotMetricsInterval = 15 * time.Second
+
 type ConnectionService struct {
-	connectionRepo *repository.ConnectionRepository
-	familyRepo     *repository.FamilyRepository
-	pathFinder     algorithms.PathFinder
-	metrics        *metrics.Collector
+	connectionRepo      repository.ConnectionStore
+	familyRepo          repository.FamilyStore
+	pathFinder          algorithms.PathFinder
+	strongestPathFinder algorithms.PathFinder
+	pathCache           *algorithms.PathCache     // nil without a cache backend
+	snapshot            *algorithms.GraphSnapshot // nil when path queries go to the database
+	performance         config.PerformanceConfig
+	decay               *models.StrengthDecay // Discounts connection strength by age
+	analyticsMutex      sync.Mutex            // Serializes whole-graph analytics runs
+	communities         *CommunityReport      // Last community detection run, nil until one completes
+	communityMutex      sync.RWMutex
+	distanceOracle      *analytics.DistanceOracle // Landmark distances, nil until the first build
+	oracleLoadedAt      time.Time                 // When the oracle's graph was read
+	graphChangedAt      time.Time                 // Last connection created by this process
+	oracleMutex         sync.RWMutex
+	metrics             *metrics.Collector
 }
 
 func NewConnectionService(
-	connectionRepo *repository.ConnectionRepository,
-	familyRepo *repository.FamilyRepository,
+	connectionRepo repository.ConnectionStore,
+	familyRepo repository.FamilyStore,
+	performance config.PerformanceConfig,
+	decay *models.StrengthDecay,
+	cacheBackend cache.Backend,
 	metrics *metrics.Collector,
 ) *ConnectionService {
 	// Create bidirectional BFS path finder with repository adapter
@@ -27,22 +52,116 @@ func NewConnectionService(
 		connectionRepo: connectionRepo,
 		familyRepo:     familyRepo,
 	}
+
+	// Optionally run path queries against an in-memory snapshot instead of the database
+	var graph algorithms.GraphRepository = repoAdapter
+	var snapshot *algorithms.GraphSnapshot
+	if performance.GraphSnapshotEnabled {
+		snapshot = algorithms.NewGraphSnapshot(connectionRepo, repoAdapter)
+		graph = snapshot
+	}
 	
-	pathFinder := algorithms.NewBidirectionalBFS(repoAdapter)
-	
-	// Wrap with caching
-	cachedPathFinder := algorithms.NewCachedPathFinder(pathFinder, 5*time.Minute)
+	var pathFinder algorithms.PathFinder = algorithms.NewBidirectionalBFS(graph, decay)
+
+	// Strength-weighted finder for mode=strongest
+	var strongestPathFinder algorithms.PathFinder = algorithms.NewStrongestPathFinder(graph, decay)
+
+	// Wrap both finders with one shared cache; the mode is part of the cache key
+	var pathCache *algorithms.PathCache
+	if cacheBackend != nil {
+		pathCache = algorithms.NewPathCache(cacheBackend, performance.CacheTTL, metrics)
+		pathFinder = algorithms.NewCachedPathFinder(pathFinder, pathCache, algorithms.PathModeShortest)
+		strongestPathFinder = algorithms.NewCachedPathFinder(strongestPathFinder, pathCache, algorithms.PathModeStrongest)
+	}
 
 	return &ConnectionService{
-		connectionRepo: connectionRepo,
-		familyRepo:     familyRepo,
-		pathFinder:     cachedPathFinder,
-		metrics:        metrics,
+		connectionRepo:      connectionRepo,
+		familyRepo:          familyRepo,
+		pathFinder:          pathFinder,
+		strongestPathFinder: strongestPathFinder,
+		pathCache:           pathCache,
+		snapshot:            snapshot,
+		performance:         performance,
+		decay:               decay,
+		metrics:             metrics,
+	}
+}
+
+// StartCacheInvalidation applies connection changes published by other replicas to the
+// path cache until ctx is cancelled. It does nothing when caching is disabled.
+func (s *ConnectionService) StartCacheInvalidation(ctx context.Context) error {
+	if s.pathCache == nil {
+		return nil
+	}
+
+	return s.pathCache.Listen(ctx)
+}
+
+// StartGraphSnapshot loads the in-memory graph snapshot and keeps it fresh until ctx is
+// cancelled. It does nothing when PerformanceConfig.GraphSnapshotEnabled is off.
+func (s *ConnectionService) StartGraphSnapshot(ctx context.Context) error {
+	if s.snapshot == nil {
+		return nil
 	}
+
+	if err := s.refreshGraphSnapshot(ctx); err != nil {
+		return err
+	}
+
+	interval := s.performance.GraphSnapshotRefreshInterval
+	if interval <= 0 {
+		interval = 5 * time.Minute // Default refresh interval
+	}
+
+	go func() {
+		ticker := time.NewTicker(snapshotMetricsInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if s.snapshot.Staleness() >= interval {
+					// Failures are counted in metrics, the previous snapshot keeps serving
+					_ = s.refreshGraphSnapshot(ctx)
+				}
+				s.recordGraphSnapshotMetrics()
+			}
+		}
+	}()
+
+	return nil
+}
+
+// refreshGraphSnapshot reloads the graph snapshot from the database
+func (s *ConnectionService) refreshGraphSnapshot(ctx context.Context) error {
+	start := time.Now()
+	defer s.metrics.RecordDuration("connection_service_graph_snapshot_refresh", start)
+
+	if err := s.snapshot.Refresh(ctx); err != nil {
+		s.metrics.IncrementCounter("connection_service_graph_snapshot_refresh_errors")
+		return fmt.Errorf("failed to refresh graph snapshot: %w", err)
+	}
+
+	s.metrics.IncrementCounter("connection_service_graph_snapshot_refreshed")
+	s.recordGraphSnapshotMetrics()
+	return nil
+}
+
+// recordGraphSnapshotMetrics publishes the snapshot size, footprint and staleness
+func (s *ConnectionService) recordGraphSnapshotMetrics() {
+	stats := s.snapshot.Stats()
+	s.metrics.RecordValue("connection_service_graph_snapshot_families", float64(stats.Families))
+	s.metrics.RecordValue("connection_service_graph_snapshot_connections", float64(stats.Connections))
+	s.metrics.RecordValue("connection_service_graph_snapshot_memory_bytes", float64(stats.MemoryBytes))
+	s.metrics.RecordValue("connection_service_graph_snapshot_staleness_seconds", time.Since(stats.LoadedAt).Seconds())
 }
 
-// FindConnectionPath finds the shortest path between two families
-func (s *ConnectionService) FindConnectionPath(ctx context.Context, fromFamilyID, toFamilyID string, maxDepth int) (*models.ConnectionPath, error) {
+// FindConnectionPath finds a path between two families. Mode selects the fewest-hops
+// path (algorithms.PathModeShortest) or the highest-strength path (algorithms.PathModeStrongest).
+// Optional constraints restrict the families and connections the path may use.
+func (s *ConnectionService) FindConnectionPath(ctx context.Context, fromFamilyID, toFamilyID string, maxDepth int, mode string, constraints *algorithms.PathConstraints) (*models.ConnectionPath, error) {
 	start := time.Now()
 	defer s.metrics.RecordDuration("connection_service_find_path", start)
 
@@ -50,7 +169,20 @@ func (s *ConnectionService) FindConnectionPath(ctx context.Context, fromFamilyID
 		maxDepth = 4 // Default max depth
 	}
 
-	path, err := s.pathFinder.FindPath(ctx, fromFamilyID, toFamilyID, maxDepth)
+	var pathFinder algorithms.PathFinder
+	switch mode {
+	case "", algorithms.PathModeShortest:
+		pathFinder = s.pathFinder
+	case algorithms.PathModeStrongest:
+		pathFinder = s.strongestPathFinder
+	default:
+		s.metrics.IncrementCounter("connection_service_find_path_errors")
+		return nil, fmt.Errorf("unsupported path mode: %s", mode)
+	}
+
+	ctx, stats := algorithms.WithSearchStats(ctx)
+	path, err := pathFinder.FindPath(ctx, fromFamilyID, toFamilyID, maxDepth, constraints)
+	s.metrics.ObserveValue("connection_service_find_path_round_trips", float64(stats.RoundTrips()))
 	if err != nil {
 		s.metrics.IncrementCounter("connection_service_find_path_errors")
 		return nil, fmt.Errorf("failed to find connection path: %w", err)
@@ -68,8 +200,9 @@ func (s *ConnectionService) FindConnectionPath(ctx context.Context, fromFamilyID
 	return path, nil
 }
 
-// FindMultipleConnectionPaths finds multiple paths between two families
-func (s *ConnectionService) FindMultipleConnectionPaths(ctx context.Context, fromFamilyID, toFamilyID string, maxDepth, maxPaths int) ([]*models.ConnectionPath, error) {
+// FindMultipleConnectionPaths finds ranked, distinct paths between two families.
+// rankBy orders them by hops (algorithms.RankByHops) or by strength (algorithms.RankByStrength).
+func (s *ConnectionService) FindMultipleConnectionPaths(ctx context.Context, fromFamilyID, toFamilyID string, maxDepth, maxPaths int, rankBy string, constraints *algorithms.PathConstraints) ([]*models.ConnectionPath, error) {
 	start := time.Now()
 	defer s.metrics.RecordDuration("connection_service_find_multiple_paths", start)
 
@@ -80,7 +213,20 @@ func (s *ConnectionService) FindMultipleConnectionPaths(ctx context.Context, fro
 		maxPaths = 3 // Default max paths
 	}
 
-	paths, err := s.connectionRepo.FindMultiplePaths(ctx, fromFamilyID, toFamilyID, maxDepth, maxPaths)
+	var pathFinder algorithms.PathFinder
+	switch rankBy {
+	case "", algorithms.RankByHops:
+		pathFinder = s.pathFinder
+	case algorithms.RankByStrength:
+		pathFinder = s.strongestPathFinder
+	default:
+		s.metrics.IncrementCounter("connection_service_find_multiple_paths_errors")
+		return nil, fmt.Errorf("unsupported path ranking: %s", rankBy)
+	}
+
+	ctx, stats := algorithms.WithSearchStats(ctx)
+	paths, err := pathFinder.FindMultiplePaths(ctx, fromFamilyID, toFamilyID, maxDepth, maxPaths, constraints)
+	s.metrics.ObserveValue("connection_service_find_multiple_paths_round_trips", float64(stats.RoundTrips()))
 	if err != nil {
 		s.metrics.IncrementCounter("connection_service_find_multiple_paths_errors")
 		return nil, fmt.Errorf("failed to find multiple paths: %w", err)
@@ -134,7 +280,7 @@ func (s *ConnectionService) GetFamilyNetwork(ctx context.Context, familyID strin
 	// Organize families by degree
 	for _, family := range connectedFamilies {
 		// Find the shortest path to determine the degree
-		path, err := s.pathFinder.FindPath(ctx, familyID, family.ID, degree)
+		path, err := s.pathFinder.FindPath(ctx, familyID, family.ID, degree, nil)
 		if err != nil {
 			continue // Skip if path not found
 		}
@@ -178,25 +324,31 @@ func (s *ConnectionService) FindCommonConnections(ctx context.Context, family1ID
 	// Find intersection
 	commonFamilyIDs := findIntersection(connections1, connections2)
 
-	var commonConnections []*CommonConnection
+	// Load the common families in one round trip
+	commonFamilies, err := s.familyRepo.GetFamiliesByIDs(ctx, commonFamilyIDs)
+	if err != nil {
+		s.metrics.IncrementCounter("connection_service_find_common_errors")
+		return nil, fmt.Errorf("failed to get common families: %w", err)
+	}
 
-	for _, commonFamilyID := range commonFamilyIDs {
-		// Get the common family
-		commonFamily, err := s.familyRepo.GetFamilyByID(ctx, commonFamilyID)
-		if err != nil {
-			continue // Skip if family not found
-		}
+	// One level-by-level search from each family finds its shortest path to every common family
+	tree1, err := s.shortestPathTree(ctx, family1ID, maxDegree)
+	if err != nil {
+		s.metrics.IncrementCounter("connection_service_find_common_errors")
+		return nil, fmt.Errorf("failed to search from family1: %w", err)
+	}
+	tree2, err := s.shortestPathTree(ctx, family2ID, maxDegree)
+	if err != nil {
+		s.metrics.IncrementCounter("connection_service_find_common_errors")
+		return nil, fmt.Errorf("failed to search from family2: %w", err)
+	}
 
-		// Find paths from both families to the common family
-		path1, err := s.pathFinder.FindPath(ctx, family1ID, commonFamilyID, maxDegree)
-		if err != nil {
-			continue
-		}
+	var commonConnections []*CommonConnection
+	now := time.Now()
 
-		path2, err := s.pathFinder.FindPath(ctx, family2ID, commonFamilyID, maxDegree)
-		if err != nil {
-			continue
-		}
+	for _, commonFamily := range commonFamilies {
+		path1 := s.treePath(tree1, family1ID, commonFamily.ID, now)
+		path2 := s.treePath(tree2, family2ID, commonFamily.ID, now)
 
 		if path1 != nil && path2 != nil {
 			commonConnection := &CommonConnection{
@@ -210,7 +362,7 @@ func (s *ConnectionService) FindCommonConnections(ctx context.Context, family1ID
 		}
 	}
 
-	// Sort by total degree (shortest total path first)
+	// Sort by total degree (shortest total path first), strongest first within a degree
 	s.sortCommonConnectionsByDegree(commonConnections)
 
 	s.metrics.IncrementCounter("connection_service_find_common_success")
@@ -219,6 +371,62 @@ func (s *ConnectionService) FindCommonConnections(ctx context.Context, family1ID
 	return commonConnections, nil
 }
 
+// shortestPathTree runs a breadth-first search from a family up to maxDepth hops, one bulk
+// edge query per level. It returns the connection each reached family was first reached by.
+func (s *ConnectionService) shortestPathTree(ctx context.Context, fromID string, maxDepth int) (map[string]*models.FamilyConnection, error) {
+	reachedBy := map[string]*models.FamilyConnection{fromID: nil}
+	frontier := []string{fromID}
+
+	for depth := 1; depth <= maxDepth && len(frontier) > 0; depth++ {
+		edges, err := s.connectionRepo.GetFamilyEdgesBulk(ctx, frontier)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get family connections: %w", err)
+		}
+
+		var next []string
+		for _, familyID := range frontier {
+			for _, edge := range edges[familyID] {
+				if _, seen := reachedBy[edge.ToFamilyID]; seen {
+					continue
+				}
+				reachedBy[edge.ToFamilyID] = edge
+				next = append(next, edge.ToFamilyID)
+			}
+		}
+		frontier = next
+	}
+
+	return reachedBy, nil
+}
+
+// treePath follows a shortest path tree back from a family to its root, or returns nil when
+// the search did not reach the family
+func (s *ConnectionService) treePath(reachedBy map[string]*models.FamilyConnection, fromID, toID string, now time.Time) *models.ConnectionPath {
+	if _, reached := reachedBy[toID]; !reached || toID == fromID {
+		return nil
+	}
+
+	var connections []models.FamilyConnection
+	path := []string{toID}
+	for edge := reachedBy[toID]; edge != nil; edge = reachedBy[edge.FromFamilyID] {
+		connections = append(connections, *edge)
+		path = append(path, edge.FromFamilyID)
+	}
+	slices.Reverse(connections)
+	slices.Reverse(path)
+
+	connectionPath := &models.ConnectionPath{
+		SourceFamilyID: fromID,
+		TargetFamilyID: toID,
+		Path:           path,
+		Degree:         len(connections),
+		CalculatedAt:   now,
+	}
+	connectionPath.CalculatePathStrength(connections, s.decay)
+	connectionPath.SpecificRelation, _ = kinship.PathRelation(connectionPath.Edges)
+	return connectionPath
+}
+
 // CreateConnection creates a new connection between families with validation
 func (s *ConnectionService) CreateConnection(ctx context.Context, connection *models.FamilyConnection) error {
 	start := time.Now()
@@ -240,10 +448,56 @@ func (s *ConnectionService) CreateConnection(ctx context.Context, connection *mo
 		return fmt.Errorf("failed to create connection: %w", err)
 	}
 
+	// Make the new connection visible to snapshot path queries before the next reload
+	if s.snapshot != nil {
+		s.snapshot.ApplyConnection(connection)
+	}
+
+	// Distances may have shortened since the landmark oracle was built
+	s.markConnectionsChanged()
+
+	// Drop cached searches the new connection may shorten or make possible
+	if s.pathCache != nil {
+		if err := s.pathCache.InvalidateConnection(ctx, connection.FromFamilyID, connection.ToFamilyID); err != nil {
+			s.metrics.IncrementCounter("connection_service_cache_invalidation_errors")
+		}
+	}
+
 	s.metrics.IncrementCounter("connection_service_created")
 	return nil
 }
 
+// ReconfirmConnection records that two families reconfirmed their connection, resetting the
+// decay of its strength. It returns nil when the families are not directly connected.
+func (s *ConnectionService) ReconfirmConnection(ctx context.Context, fromFamilyID, toFamilyID string) (*models.FamilyConnection, error) {
+	start := time.Now()
+	defer s.metrics.RecordDuration("connection_service_reconfirm", start)
+
+	connection, err := s.connectionRepo.ReconfirmConnection(ctx, fromFamilyID, toFamilyID, start)
+	if err != nil {
+		s.metrics.IncrementCounter("connection_service_reconfirm_errors")
+		return nil, fmt.Errorf("failed to reconfirm connection: %w", err)
+	}
+	if connection == nil {
+		return nil, nil // The families are not directly connected
+	}
+
+	// Make the renewed strength visible to snapshot path queries before the next reload
+	if s.snapshot != nil {
+		s.snapshot.ApplyConnection(connection)
+	}
+
+	// Drop cached searches that used the decayed strength
+	if s.pathCache != nil {
+		if err := s.pathCache.InvalidateConnection(ctx, fromFamilyID, toFamilyID); err != nil {
+			s.metrics.IncrementCounter("connection_service_cache_invalidation_errors")
+		}
+	}
+
+	s.metrics.IncrementCounter("connection_service_reconfirmed")
+	return connection, nil
+}
+
 // GetNetworkStats provides comprehensive statistics about the family network
 func (s *ConnectionService) GetNetworkStats(ctx context.Context) (*NetworkStats, error) {
 	start := time.Now()
@@ -295,18 +549,15 @@ func (s *ConnectionService) AnalyzeConnectionStrength(ctx context.Context, path
 		AnalyzedAt:     time.Now(),
 	}
 
+	connectionStrengths, err := s.decayedStrengths(ctx, path)
+	if err != nil {
+		s.metrics.IncrementCounter("connection_service_analyze_errors")
+		return nil, err
+	}
 	totalStrength := 0.0
-	var connectionStrengths []float64
-
-	// Analyze each connection in the path
-	for i := 0; i < len(path.Path)-1; i++ {
-		strength, err := s.connectionRepo.GetConnectionStrength(ctx, path.Path[i], path.Path[i+1])
-		if err != nil {
-			s.metrics.IncrementCounter("connection_service_analyze_errors")
-			return nil, fmt.Errorf("failed to get connection strength: %w", err)
-		}
 
-		connectionStrengths = append(connectionStrengths, strength)
+	// Analyze each connection in the path at its decayed strength
+	for i, strength := range connectionStrengths {
 		totalStrength += strength
 
 		if strength < analysis.WeakestLink {
@@ -338,6 +589,46 @@ func (s *ConnectionService) AnalyzeConnectionStrength(ctx context.Context, path
 	return analysis, nil
 }
 
+// decayedStrengths returns the decayed strength of each connection along a path. Paths from
+// the path finders carry them in their edges; other paths load their connections in one query.
+func (s *ConnectionService) decayedStrengths(ctx context.Context, path *models.ConnectionPath) ([]float64, error) {
+	strengths := make([]float64, 0, len(path.Path)-1)
+	if pathHasEdges(path) {
+		for _, edge := range path.Edges {
+			strengths = append(strengths, edge.DecayedStrength)
+		}
+		return strengths, nil
+	}
+
+	edges, err := s.connectionRepo.GetFamilyEdgesBulk(ctx, path.Path[:len(path.Path)-1])
+	if err != nil {
+		return nil, fmt.Errorf("failed to get connections along path: %w", err)
+	}
+
+	now := time.Now()
+	for i := 0; i < len(path.Path)-1; i++ {
+		connection := findConnection(edges[path.Path[i]], path.Path[i+1])
+		if connection == nil {
+			return nil, fmt.Errorf("no connection between %s and %s", path.Path[i], path.Path[i+1])
+		}
+		strengths = append(strengths, connection.DecayedStrength(s.decay, now))
+	}
+	return strengths, nil
+}
+
+// pathHasEdges reports whether a path records the connection for each of its hops
+func pathHasEdges(path *models.ConnectionPath) bool {
+	if len(path.Edges) != len(path.Path)-1 {
+		return false
+	}
+	for i, edge := range path.Edges {
+		if edge.FromFamilyID != path.Path[i] || edge.ToFamilyID != path.Path[i+1] {
+			return false
+		}
+	}
+	return true
+}
+
 // Supporting types and methods
 
 type FamilyNetwork struct {
@@ -382,20 +673,33 @@ type ConnectionAnalysis struct {
 
 // Repository adapter for the path finding algorithms
 type repositoryAdapter struct {
-	connectionRepo *repository.ConnectionRepository
-	familyRepo     *repository.FamilyRepository
+	connectionRepo repository.ConnectionStore
+	familyRepo     repository.FamilyStore
+}
+
+func (ra *repositoryAdapter) GetNeighbors(ctx context.Context, familyID string) ([]*models.FamilyConnection, error) {
+	algorithms.RecordRoundTrip(ctx)
+	return ra.connectionRepo.GetFamilyEdges(ctx, familyID)
 }
 
-func (ra *repositoryAdapter) GetNeighbors(ctx context.Context, familyID string) ([]string, error) {
-	return ra.connectionRepo.GetFamilyConnections(ctx, familyID, 1)
+func (ra *repositoryAdapter) GetNeighborsBulk(ctx context.Context, familyIDs []string) (map[string][]*models.FamilyConnection, error) {
+	algorithms.RecordRoundTrip(ctx)
+	return ra.connectionRepo.GetFamilyEdgesBulk(ctx, familyIDs)
 }
 
-func (ra *repositoryAdapter) GetConnectionStrength(ctx context.Context, from, to string) (float64, error) {
-	return ra.connectionRepo.GetConnectionStrength(ctx, from, to)
+func (ra *repositoryAdapter) GetConnection(ctx context.Context, from, to string) (*models.FamilyConnection, error) {
+	algorithms.RecordRoundTrip(ctx)
+	return ra.connectionRepo.GetConnection(ctx, from, to)
 }
 
+// GetFamilyTrustScore returns the family's stored trust score, the one the API reports
 func (ra *repositoryAdapter) GetFamilyTrustScore(ctx context.Context, familyID string) (float64, error) {
-	return ra.familyRepo.GetFamilyTrustScore(ctx, familyID)
+	family, err := ra.familyRepo.GetFamilyByID(ctx, familyID)
+	if err != nil || family == nil {
+		return 0.0, err
+	}
+
+	return family.TrustScore, nil
 }
 
 // Helper functions
@@ -419,6 +723,16 @@ func (s *ConnectionService) validateConnection(connection *models.FamilyConnecti
 	return nil
 }
 
+// findConnection returns the edge leading to a family, or nil
+func findConnection(edges []*models.FamilyConnection, toID string) *models.FamilyConnection {
+	for _, edge := range edges {
+		if edge.ToFamilyID == toID {
+			return edge
+		}
+	}
+	return nil
+}
+
 func findIntersection(slice1, slice2 []string) []string {
 	m := make(map[string]bool)
 	var intersection []string
@@ -437,13 +751,12 @@ func findIntersection(slice1, slice2 []string) []string {
 }
 
 func (s *ConnectionService) sortCommonConnectionsByDegree(connections []*CommonConnection) {
-	for i := 0; i < len(connections)-1; i++ {
-		for j := 0; j < len(connections)-i-1; j++ {
-			if connections[j].TotalDegree > connections[j+1].TotalDegree {
-				connections[j], connections[j+1] = connections[j+1], connections[j]
-			}
+	sort.SliceStable(connections, func(i, j int) bool {
+		if connections[i].TotalDegree != connections[j].TotalDegree {
+			return connections[i].TotalDegree < connections[j].TotalDegree
 		}
-	}
+		return connections[i].CombinedStrength > connections[j].CombinedStrength
+	})
 }
 
 func getIntValue(m map[string]interface{}, key string) int {
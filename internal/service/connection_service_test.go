@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"families-linkedin/internal/algorithms"
+	"families-linkedin/internal/config"
+	"families-linkedin/internal/metrics"
+	"families-linkedin/internal/models"
+	"families-linkedin/internal/repository/memory"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAnalyzeConnectionStrengthAppliesDecay(t *testing.T) {
+	network := newTestNetwork(t, yearlyHalving)
+	network.familiesNamed("A", "B", "C")
+	network.connect("A", "B", 0.9, 0)
+	network.connect("B", "C", 0.9, 2*year) // Decays to a quarter
+
+	tests := []struct {
+		name         string
+		path         []string
+		wantStrength []float64
+		wantWeakest  int
+		wantClass    string
+	}{
+		{"fresh connection", []string{"A", "B"}, []float64{0.9}, 0, "Strong"},
+		{"decayed connection is the weakest link", []string{"A", "B", "C"}, []float64{0.9, 0.225}, 1, "Weak"},
+		{"reversed path", []string{"C", "B", "A"}, []float64{0.225, 0.9}, 0, "Weak"},
+	}
+
+	service := network.connectionService()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := &models.ConnectionPath{Path: tt.path}
+			analysis, err := service.AnalyzeConnectionStrength(context.Background(), path)
+			if err != nil {
+				t.Fatalf("AnalyzeConnectionStrength: %v", err)
+			}
+
+			if len(analysis.ConnectionStrengths) != len(tt.wantStrength) {
+				t.Fatalf("strengths = %v, want %v", analysis.ConnectionStrengths, tt.wantStrength)
+			}
+			for i, want := range tt.wantStrength {
+				if math.Abs(analysis.ConnectionStrengths[i]-want) > 0.01 {
+					t.Errorf("strengths = %v, want %v", analysis.ConnectionStrengths, tt.wantStrength)
+					break
+				}
+			}
+			if analysis.WeakestLinkIndex != tt.wantWeakest {
+				t.Errorf("weakest link = %d, want %d", analysis.WeakestLinkIndex, tt.wantWeakest)
+			}
+			if analysis.PathClassification != tt.wantClass {
+				t.Errorf("classification = %q, want %q", analysis.PathClassification, tt.wantClass)
+			}
+		})
+	}
+
+	if _, err := service.AnalyzeConnectionStrength(context.Background(), &models.ConnectionPath{Path: []string{"A", "C"}}); err == nil {
+		t.Errorf("AnalyzeConnectionStrength on an unconnected pair succeeded")
+	}
+}
+
+// countingConnections counts the bulk edge queries made against the memory backend
+type countingConnections struct {
+	*memory.ConnectionRepository
+	bulkQueries int
+}
+
+func (c *countingConnections) GetFamilyEdgesBulk(ctx context.Context, familyIDs []string) (map[string][]*models.FamilyConnection, error) {
+	c.bulkQueries++
+	return c.ConnectionRepository.GetFamilyEdgesBulk(ctx, familyIDs)
+}
+
+func TestAnalyzeConnectionStrengthReadsFoundPathsWithoutQueries(t *testing.T) {
+	network := newTestNetwork(t, yearlyHalving)
+	network.familiesNamed("A", "B", "C")
+	network.connect("A", "B", 0.9, 0)
+	network.connect("B", "C", 0.9, 2*year)
+
+	connections := &countingConnections{ConnectionRepository: network.connections}
+	service := NewConnectionService(connections, network.families, config.PerformanceConfig{}, yearlyHalving, nil, metrics.NewCollector())
+
+	path, err := service.FindConnectionPath(context.Background(), "A", "C", 4, algorithms.PathModeShortest, nil)
+	if err != nil || path == nil {
+		t.Fatalf("FindConnectionPath = %v, %v", path, err)
+	}
+
+	connections.bulkQueries = 0
+	analysis, err := service.AnalyzeConnectionStrength(context.Background(), path)
+	if err != nil {
+		t.Fatalf("AnalyzeConnectionStrength: %v", err)
+	}
+	if connections.bulkQueries != 0 {
+		t.Errorf("analysis made %d queries, want none for a path carrying its edges", connections.bulkQueries)
+	}
+	if analysis.WeakestLinkIndex != 1 || math.Abs(analysis.WeakestLink-0.225) > 0.01 {
+		t.Errorf("weakest link = %d (%.3f), want 1 (0.225)", analysis.WeakestLinkIndex, analysis.WeakestLink)
+	}
+}
+
+func TestRepositoryAdapterReadsStoredTrustScore(t *testing.T) {
+	ctx := context.Background()
+	network := newTestNetwork(t, yearlyHalving)
+	network.familiesNamed("A", "B")
+	network.connect("A", "B", 0.9, 0)
+	if err := network.families.UpdateFamilyTrustScore(ctx, "A", 8.5, models.TrustSourcePropagated); err != nil {
+		t.Fatalf("UpdateFamilyTrustScore: %v", err)
+	}
+
+	// Path searches rank with the score the API reports, not one recalculated from connections
+	adapter := &repositoryAdapter{connectionRepo: network.connections, familyRepo: network.families}
+	score, err := adapter.GetFamilyTrustScore(ctx, "A")
+	if err != nil {
+		t.Fatalf("GetFamilyTrustScore: %v", err)
+	}
+	if score != 8.5 {
+		t.Errorf("trust score = %.2f, want the stored 8.50", score)
+	}
+}
+
+func TestFindCommonConnections(t *testing.T) {
+	network := newTestNetwork(t, yearlyHalving)
+	network.familiesNamed("A", "B", "C", "D", "M", "N")
+	network.connect("A", "B", 0.9, 0)
+	network.connect("B", "D", 0.9, 0)
+	network.connect("A", "C", 0.9, 0)
+	network.connect("C", "D", 0.9, year) // Decays to 0.45
+	network.connect("B", "M", 0.8, 0)
+	network.connect("C", "N", 0.8, 0)
+
+	tests := []struct {
+		name      string
+		maxDegree int
+		want      []string // Common family: path from A, path from D
+	}{
+		{"direct neighbors, strongest first", 1, []string{"B: A-B, D-B", "C: A-C, D-C"}},
+		{"two hops away, through the decayed link last", 2, []string{"M: A-B-M, D-B-M", "N: A-C-N, D-C-N"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			connections := &countingConnections{ConnectionRepository: network.connections}
+			service := NewConnectionService(connections, network.families, config.PerformanceConfig{}, yearlyHalving, nil, metrics.NewCollector())
+
+			common, err := service.FindCommonConnections(context.Background(), "A", "D", tt.maxDegree)
+			if err != nil {
+				t.Fatalf("FindCommonConnections: %v", err)
+			}
+
+			var got []string
+			for _, connection := range common {
+				got = append(got, connection.CommonFamily.ID+": "+strings.Join(connection.PathToFamily1.Path, "-")+", "+strings.Join(connection.PathToFamily2.Path, "-"))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("common connections = %v, want %v", got, tt.want)
+			}
+
+			// One bulk query per level of each endpoint's search, however many families are common
+			if connections.bulkQueries > 2*tt.maxDegree {
+				t.Errorf("made %d bulk edge queries, want at most %d", connections.bulkQueries, 2*tt.maxDegree)
+			}
+		})
+	}
+}
+
+func TestFindCommonConnectionsRebuildsPathsFromEachFamily(t *testing.T) {
+	network := newTestNetwork(t, yearlyHalving)
+	network.familiesNamed("A", "B", "D", "M")
+	network.connect("A", "B", 0.9, 0)
+	network.connect("M", "B", 0.8, year) // Stored from M, decays to 0.4
+	network.connect("D", "B", 0.5, 0)
+
+	common, err := network.connectionService().FindCommonConnections(context.Background(), "A", "D", 2)
+	if err != nil {
+		t.Fatalf("FindCommonConnections: %v", err)
+	}
+	if len(common) != 1 || common[0].CommonFamily.ID != "M" {
+		t.Fatalf("common connections = %v, want only M", common)
+	}
+
+	tests := []struct {
+		name         string
+		path         *models.ConnectionPath
+		wantEdges    []string // Each hop, oriented along the path
+		wantStrength float64
+	}{
+		{"from family1", common[0].PathToFamily1, []string{"A>B", "B>M"}, 0.9 * 0.4},
+		{"from family2", common[0].PathToFamily2, []string{"D>B", "B>M"}, 0.5 * 0.4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, edge := range tt.path.Edges {
+				got = append(got, edge.FromFamilyID+">"+edge.ToFamilyID)
+			}
+			if !reflect.DeepEqual(got, tt.wantEdges) {
+				t.Errorf("edges = %v, want %v", got, tt.wantEdges)
+			}
+			if tt.path.Degree != 2 || tt.path.TargetFamilyID != "M" {
+				t.Errorf("path to %s of degree %d, want M of degree 2", tt.path.TargetFamilyID, tt.path.Degree)
+			}
+			if math.Abs(tt.path.PathStrength-tt.wantStrength) > 1e-9 {
+				t.Errorf("strength = %.4f, want %.4f", tt.path.PathStrength, tt.wantStrength)
+			}
+		})
+	}
+
+	if common[0].TotalDegree != 4 {
+		t.Errorf("total degree = %d, want 4", common[0].TotalDegree)
+	}
+	if want := (0.36 + 0.2) / 2; math.Abs(common[0].CombinedStrength-want) > 1e-9 {
+		t.Errorf("combined strength = %.4f, want %.4f", common[0].CombinedStrength, want)
+	}
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+	"families-linkedin/internal/algorithms"
+	"families-linkedin/internal/models"
+	"fmt"
+	"time"
+)
+
+// Bounds on the families one introduction plan may connect
+const (
+	MinSteinerFamilies = 2
+	MaxSteinerFamilies = 10
+)
+
+// steinerSearchWorkers bounds the pair path searches a plan runs at once
+const steinerSearchWorkers = 8
+
+// FindSteinerTree plans how to connect a group of families through as few intermediaries
+// as possible, e.g. to bring them together for a wedding or community event. The tree is
+// approximated from shortest paths between every pair of families, so it follows the
+// graph snapshot, the path cache and the given constraints.
+func (s *ConnectionService) FindSteinerTree(ctx context.Context, familyIDs []string, maxDepth int, constraints *algorithms.PathConstraints) (*models.SteinerTree, error) {
+	start := time.Now()
+	defer s.metrics.RecordDuration("connection_service_steiner", start)
+
+	if maxDepth <= 0 || maxDepth > 6 {
+		maxDepth = 4 // Default max depth
+	}
+	if len(familyIDs) < MinSteinerFamilies || len(familyIDs) > MaxSteinerFamilies {
+		s.metrics.IncrementCounter("connection_service_steiner_errors")
+		return nil, fmt.Errorf("between %d and %d families can be connected at once", MinSteinerFamilies, MaxSteinerFamilies)
+	}
+
+	ctx, stats := algorithms.WithSearchStats(ctx)
+	tree, err := algorithms.FindSteinerTree(ctx, s.pathFinder, familyIDs, maxDepth, constraints, steinerSearchWorkers)
+	s.metrics.ObserveValue("connection_service_steiner_round_trips", float64(stats.RoundTrips()))
+	if err != nil {
+		s.metrics.IncrementCounter("connection_service_steiner_errors")
+		return nil, fmt.Errorf("failed to connect families: %w", err)
+	}
+
+	s.metrics.RecordValue("connection_service_steiner_edges", float64(len(tree.Edges)))
+	s.metrics.RecordValue("connection_service_steiner_intermediaries", float64(len(tree.IntermediaryIDs)))
+
+	return tree, nil
+}
@@ -0,0 +1,220 @@
+package service
+
+import (
+	"context"
+	"families-linkedin/internal/algorithms"
+	"families-linkedin/internal/kinship"
+	"families-linkedin/internal/models"
+	"fmt"
+	"time"
+)
+
+// Kinship path search depth limits, counted in parent, child and spouse steps
+const (
+	DefaultKinshipDepth = 8
+	MaxKinshipDepth     = 12
+)
+
+// maxParents is how many parents a person may be recorded with
+const maxParents = 2
+
+// AddPersonRelation records a PARENT_OF or SPOUSE_OF relationship between two persons. A
+// parent must be older than the child, a child has at most two parents, nobody can become
+// their own ancestor, and spouses cannot be each other's ancestors.
+func (s *FamilyService) AddPersonRelation(ctx context.Context, relation *models.PersonRelation) error {
+	start := time.Now()
+	defer s.metrics.RecordDuration("family_service_add_relation", start)
+
+	if err := s.validatePersonRelation(ctx, relation); err != nil {
+		s.metrics.IncrementCounter("family_service_relation_validation_errors")
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	if relation.CreatedAt.IsZero() {
+		relation.CreatedAt = time.Now()
+	}
+	if err := s.personRepo.CreatePersonRelation(ctx, relation); err != nil {
+		s.metrics.IncrementCounter("family_service_add_relation_errors")
+		return fmt.Errorf("failed to add relation: %w", err)
+	}
+
+	s.metrics.IncrementCounter("family_service_relation_added")
+	return nil
+}
+
+// GetPersonRelations lists the parents, children and spouses recorded for a person
+func (s *FamilyService) GetPersonRelations(ctx context.Context, personID string) ([]*models.PersonRelation, error) {
+	if _, err := s.personRepo.GetPersonByID(ctx, personID); err != nil {
+		s.metrics.IncrementCounter("family_service_get_relations_errors")
+		return nil, fmt.Errorf("person not found: %w", err)
+	}
+
+	relations, err := s.personRepo.GetPersonRelations(ctx, personID)
+	if err != nil {
+		s.metrics.IncrementCounter("family_service_get_relations_errors")
+		return nil, fmt.Errorf("failed to get relations: %w", err)
+	}
+
+	return relations, nil
+}
+
+// FindKinshipPath answers how one person is related to another: the shortest chain of
+// parent, child and spouse steps between them, each named for the gender of the person it
+// leads to, reduced to a relationship named in the locale. Relationships the locale has no
+// term for are named in English. It returns nil when they are not related within maxDepth steps.
+func (s *FamilyService) FindKinshipPath(ctx context.Context, fromPersonID, toPersonID string, maxDepth int, localeCode string) (*models.KinshipPath, error) {
+	start := time.Now()
+	defer s.metrics.RecordDuration("family_service_kinship_path", start)
+
+	if maxDepth <= 0 || maxDepth > MaxKinshipDepth {
+		maxDepth = DefaultKinshipDepth
+	}
+	if localeCode == "" {
+		localeCode = kinship.English.Code()
+	}
+	locale, ok := kinship.Lookup(localeCode)
+	if !ok {
+		s.metrics.IncrementCounter("family_service_kinship_errors")
+		return nil, fmt.Errorf("unsupported locale: %s", localeCode)
+	}
+
+	path, err := algorithms.NewKinshipPathFinder(s.personRepo).FindPath(ctx, fromPersonID, toPersonID, maxDepth)
+	if err != nil {
+		s.metrics.IncrementCounter("family_service_kinship_errors")
+		return nil, fmt.Errorf("failed to find kinship path: %w", err)
+	}
+
+	// One lookup loads everyone on the path, both persons included, or just the two of them
+	personIDs := []string{fromPersonID, toPersonID}
+	if path != nil {
+		personIDs = path.PersonIDs
+	}
+	found, err := s.personRepo.GetPersonsByIDs(ctx, personIDs)
+	if err != nil {
+		s.metrics.IncrementCounter("family_service_kinship_errors")
+		return nil, fmt.Errorf("failed to get persons: %w", err)
+	}
+	persons := make(map[string]*models.Person, len(found))
+	genders := make(map[string]string, len(found))
+	for _, person := range found {
+		persons[person.ID] = person
+		genders[person.ID] = person.Gender
+	}
+	for _, personID := range personIDs {
+		if persons[personID] == nil {
+			s.metrics.IncrementCounter("family_service_kinship_errors")
+			return nil, fmt.Errorf("person not found: %s", personID)
+		}
+	}
+
+	if path == nil {
+		s.metrics.IncrementCounter("family_service_kinship_not_found")
+		return nil, nil
+	}
+	path.Describe(genders)
+
+	// Reduce the steps to one relationship, siblings told elder or younger by birth date
+	hops := make([]kinship.Hop, len(path.Steps))
+	for i, step := range path.Steps {
+		relative := persons[step.ToPersonID]
+		hops[i] = kinship.Hop{Kin: step.Kin, Gender: relative.Gender, Born: relative.DateOfBirth}
+	}
+	relation := kinship.Reduce(persons[fromPersonID].DateOfBirth, hops)
+
+	path.Relationship = relation.Chain()
+	path.Notation = relation.Notation()
+	path.Locale = locale.Code()
+	if path.Term, ok = locale.Term(relation); !ok {
+		path.Locale = kinship.English.Code()
+		if path.Term, ok = kinship.English.Term(relation); !ok {
+			path.Term = path.Relationship
+		}
+	}
+
+	s.metrics.IncrementCounter("family_service_kinship_found")
+	return path, nil
+}
+
+func (s *FamilyService) validatePersonRelation(ctx context.Context, relation *models.PersonRelation) error {
+	if !models.IsValidPersonRelation(relation.Type) {
+		return fmt.Errorf("relation type must be '%s' or '%s'", models.RelationParentOf, models.RelationSpouseOf)
+	}
+	if relation.FromPersonID == "" || relation.ToPersonID == "" {
+		return fmt.Errorf("both person IDs are required")
+	}
+	if relation.FromPersonID == relation.ToPersonID {
+		return fmt.Errorf("cannot relate a person to themselves")
+	}
+
+	from, err := s.personRepo.GetPersonByID(ctx, relation.FromPersonID)
+	if err != nil {
+		return fmt.Errorf("person not found: %w", err)
+	}
+	to, err := s.personRepo.GetPersonByID(ctx, relation.ToPersonID)
+	if err != nil {
+		return fmt.Errorf("person not found: %w", err)
+	}
+
+	existing, err := s.personRepo.GetPersonRelations(ctx, relation.ToPersonID)
+	if err != nil {
+		return fmt.Errorf("failed to get relations: %w", err)
+	}
+	parents := 0
+	for _, other := range existing {
+		if other.Joins(relation.FromPersonID, relation.ToPersonID) {
+			return fmt.Errorf("the persons are already related as %s", other.Type)
+		}
+		if other.Type == models.RelationParentOf && other.ToPersonID == relation.ToPersonID {
+			parents++
+		}
+	}
+
+	if relation.Type == models.RelationParentOf {
+		older, err := isOlder(from, to)
+		if err != nil {
+			return err
+		}
+		if !older {
+			return fmt.Errorf("a parent must be older than their child")
+		}
+		if parents >= maxParents {
+			return fmt.Errorf("a person cannot have more than %d parents", maxParents)
+		}
+		// The child must not already be an ancestor of the parent
+		cycle, err := s.personRepo.IsAncestor(ctx, relation.ToPersonID, relation.FromPersonID)
+		if err != nil {
+			return fmt.Errorf("failed to check ancestry: %w", err)
+		}
+		if cycle {
+			return fmt.Errorf("a person cannot be their own ancestor")
+		}
+		return nil
+	}
+
+	for _, pair := range [][2]string{{relation.FromPersonID, relation.ToPersonID}, {relation.ToPersonID, relation.FromPersonID}} {
+		ancestor, err := s.personRepo.IsAncestor(ctx, pair[0], pair[1])
+		if err != nil {
+			return fmt.Errorf("failed to check ancestry: %w", err)
+		}
+		if ancestor {
+			return fmt.Errorf("a person cannot marry their ancestor")
+		}
+	}
+	return nil
+}
+
+// isOlder reports whether one person is older than another, by date of birth when both
+// are recorded and by age when both ages are. Otherwise it asks for the missing date of birth.
+func isOlder(person, other *models.Person) (bool, error) {
+	if !person.DateOfBirth.IsZero() && !other.DateOfBirth.IsZero() {
+		return person.DateOfBirth.Before(other.DateOfBirth), nil
+	}
+	if person.Age > 0 && other.Age > 0 {
+		return person.Age > other.Age, nil
+	}
+	missing := person
+	if !person.DateOfBirth.IsZero() {
+		missing = other
+	}
+	return false, fmt.Errorf("date of birth of person %s is required to tell who is older", missing.ID)
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"families-linkedin/internal/models"
+	"strings"
+	"testing"
+)
+
+// kinshipNetwork stores a family whose persons are: G, father of F; F and M, parents of S;
+// N and Q, who have no date of birth, N with an age and Q without; and K, Y and O, stored
+// as a line of descent from K to O though K is the youngest
+func kinshipNetwork(t *testing.T) *testNetwork {
+	network := newTestNetwork(t, yearlyHalving)
+	network.family("FAM")
+	network.person("G", "FAM", "MALE", 70)
+	network.person("F", "FAM", "MALE", 45)
+	network.person("M", "FAM", "FEMALE", 43)
+	network.person("S", "FAM", "MALE", 20)
+	network.person("O", "FAM", "MALE", 50)
+	network.person("Y", "FAM", "MALE", 30)
+	network.person("K", "FAM", "MALE", 25)
+	network.parent("G", "F")
+	network.parent("F", "S")
+	network.parent("M", "S")
+	network.parent("K", "Y")
+	network.parent("Y", "O")
+
+	for _, undated := range []*models.Person{{ID: "N", FamilyID: "FAM", Age: 60}, {ID: "Q", FamilyID: "FAM"}} {
+		if err := network.persons.CreatePerson(context.Background(), undated); err != nil {
+			t.Fatalf("CreatePerson(%s): %v", undated.ID, err)
+		}
+	}
+	return network
+}
+
+func TestAddPersonRelation(t *testing.T) {
+	tests := []struct {
+		name     string
+		relation *models.PersonRelation
+		wantErr  string // Empty when the relation is valid
+	}{
+		{"parent", models.NewPersonRelation("G", "M", models.RelationParentOf), ""},
+		{"parent older by age alone", models.NewPersonRelation("N", "Y", models.RelationParentOf), ""},
+		{"spouses", models.NewPersonRelation("F", "Y", models.RelationSpouseOf), ""},
+		{"own ancestor", models.NewPersonRelation("O", "K", models.RelationParentOf), "their own ancestor"},
+		{"third parent", models.NewPersonRelation("G", "S", models.RelationParentOf), "more than 2 parents"},
+		{"parent not older than child", models.NewPersonRelation("S", "O", models.RelationParentOf), "older than their child"},
+		{"parent without date of birth", models.NewPersonRelation("Q", "S", models.RelationParentOf), "date of birth of person Q"},
+		{"marrying an ancestor", models.NewPersonRelation("S", "G", models.RelationSpouseOf), "marry their ancestor"},
+		{"marrying a descendant", models.NewPersonRelation("G", "S", models.RelationSpouseOf), "marry their ancestor"},
+		{"duplicate relation", models.NewPersonRelation("F", "S", models.RelationParentOf), "already related"},
+		{"second relation between the same persons", models.NewPersonRelation("S", "F", models.RelationSpouseOf), "already related"},
+		{"same person", models.NewPersonRelation("S", "S", models.RelationSpouseOf), "themselves"},
+		{"unknown person", models.NewPersonRelation("G", "Unknown", models.RelationParentOf), "not found"},
+		{"unknown type", models.NewPersonRelation("G", "M", "SIBLING_OF"), "relation type"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			network := kinshipNetwork(t)
+			err := network.familyService().AddPersonRelation(context.Background(), tt.relation)
+
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("AddPersonRelation: %v", err)
+				}
+				relations, _ := network.persons.GetPersonRelations(context.Background(), tt.relation.ToPersonID)
+				stored := false
+				for _, relation := range relations {
+					stored = stored || relation.Joins(tt.relation.FromPersonID, tt.relation.ToPersonID)
+				}
+				if !stored {
+					t.Errorf("relation %s %s %s not stored", tt.relation.FromPersonID, tt.relation.Type, tt.relation.ToPersonID)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("AddPersonRelation error = %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFindKinshipPath(t *testing.T) {
+	service := kinshipNetwork(t).familyService()
+
+	tests := []struct {
+		name     string
+		from, to string
+		maxDepth int
+		want     string // Persons on the path, empty when they are not related
+		wantErr  bool
+	}{
+		{"grandfather", "S", "G", 0, "S-F-G", false},
+		{"beyond max depth", "S", "G", 1, "", false},
+		{"unrelated", "S", "O", 0, "", false},
+		{"unknown person", "S", "Unknown", 0, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, err := service.FindKinshipPath(context.Background(), tt.from, tt.to, tt.maxDepth, "")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FindKinshipPath error = %v, want error %v", err, tt.wantErr)
+			}
+
+			got := ""
+			if path != nil {
+				got = strings.Join(path.PersonIDs, "-")
+				if path.Term == "" || path.Relationship == "" {
+					t.Errorf("path %s is not named", got)
+				}
+			}
+			if got != tt.want {
+				t.Errorf("FindKinshipPath = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,227 @@
+package service
+
+import (
+	"context"
+	"families-linkedin/internal/algorithms"
+	"families-linkedin/internal/matching"
+	"families-linkedin/internal/models"
+	"fmt"
+)
+
+// lineage is a person's recorded ancestry, the person themselves included at zero generations
+type lineage map[string][]lineAncestor
+
+type lineAncestor struct {
+	generations int
+	line        string // matching.LinePaternal, matching.LineMaternal or empty when unknown
+}
+
+// findLineages lists the ancestors of several persons up to the given number of generations,
+// each with the line of ascent named for the gender of the parent it passes through. Their
+// ancestry is read one generation at a time for all of them, and the parents' genders at once.
+func (s *FamilyService) findLineages(ctx context.Context, persons []*models.Person, generations int) (map[string]lineage, error) {
+	lineages := make(map[string]lineage, len(persons))
+	personIDs := make([]string, 0, len(persons))
+	for _, person := range persons {
+		lineages[person.ID] = lineage{person.ID: {{generations: 0}}}
+		personIDs = append(personIDs, person.ID)
+	}
+	if generations <= 0 {
+		return lineages, nil
+	}
+
+	ancestries, err := algorithms.NewKinshipPathFinder(s.personRepo).FindAncestorsBulk(ctx, personIDs, generations)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find ancestors: %w", err)
+	}
+
+	lines, err := s.parentLines(ctx, ancestries)
+	if err != nil {
+		return nil, err
+	}
+
+	for personID, ancestors := range ancestries {
+		found := lineages[personID]
+		for _, ancestor := range ancestors {
+			found[ancestor.PersonID] = append(found[ancestor.PersonID], lineAncestor{generations: ancestor.Generations, line: lines[ancestor.ParentID]})
+		}
+	}
+
+	return lineages, nil
+}
+
+// parentLines names the line of ascent through every parent the ancestries pass through.
+// Parents who are not recorded, or whose gender is not, leave their line unknown.
+func (s *FamilyService) parentLines(ctx context.Context, ancestries map[string][]algorithms.Ancestor) (map[string]string, error) {
+	seen := make(map[string]bool)
+	var parentIDs []string
+	for _, ancestors := range ancestries {
+		for _, ancestor := range ancestors {
+			if !seen[ancestor.ParentID] {
+				seen[ancestor.ParentID] = true
+				parentIDs = append(parentIDs, ancestor.ParentID)
+			}
+		}
+	}
+	if len(parentIDs) == 0 {
+		return map[string]string{}, nil
+	}
+
+	parents, err := s.personRepo.GetPersonsByIDs(ctx, parentIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get parents: %w", err)
+	}
+
+	lines := make(map[string]string, len(parents))
+	for _, parent := range parents {
+		switch parent.Gender {
+		case "Male":
+			lines[parent.ID] = matching.LinePaternal
+		case "Female":
+			lines[parent.ID] = matching.LineMaternal
+		}
+	}
+	return lines, nil
+}
+
+// commonAncestors pairs every line by which the seeker and a candidate reach a shared ancestor
+func commonAncestors(seeker, candidate lineage) []matching.CommonAncestor {
+	var common []matching.CommonAncestor
+	for ancestorID, seekerLines := range seeker {
+		for _, candidateLine := range candidate[ancestorID] {
+			for _, seekerLine := range seekerLines {
+				common = append(common, matching.CommonAncestor{
+					AncestorID:           ancestorID,
+					SeekerGenerations:    seekerLine.generations,
+					CandidateGenerations: candidateLine.generations,
+					SeekerLine:           seekerLine.line,
+					CandidateLine:        candidateLine.line,
+				})
+			}
+		}
+	}
+	return common
+}
+
+// relativeFamilies finds the families joined to a family by at most maxHops connections the
+// rule set labels as blood relatives, with the fewest such connections to each
+func (s *FamilyService) relativeFamilies(ctx context.Context, familyID string, rules *matching.RuleSet, maxHops int) (map[string]int, error) {
+	hops := map[string]int{familyID: 0}
+	frontier := []string{familyID}
+
+	for level := 1; level <= maxHops && len(frontier) > 0; level++ {
+		edges, err := s.connectionRepo.GetFamilyEdgesBulk(ctx, frontier)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get family connections: %w", err)
+		}
+
+		var next []string
+		for _, current := range frontier {
+			for _, edge := range edges[current] {
+				if !rules.IsRelativeLabel(edge.RelationType) && !rules.IsRelativeLabel(edge.SpecificRelation) {
+					continue
+				}
+				if _, seen := hops[edge.ToFamilyID]; seen {
+					continue
+				}
+				hops[edge.ToFamilyID] = level
+				next = append(next, edge.ToFamilyID)
+			}
+		}
+		frontier = next
+	}
+
+	delete(hops, familyID)
+	return hops, nil
+}
+
+// matchCandidate is a candidate who passed the basic checks, with their family
+type matchCandidate struct {
+	person *models.Person
+	family *models.Family
+}
+
+// matchVerdict is the outcome of screening one candidate
+type matchVerdict struct {
+	rules      *matching.RuleSet // The candidate's community rules
+	violations []models.RuleViolation
+}
+
+// matchScreen applies the community rules of both the seeker's family and each candidate's
+// family, reusing the relative families found under each rule set across candidates
+type matchScreen struct {
+	service      *FamilyService
+	seeker       *models.Person
+	seekerFamily *models.Family
+	rules        *matching.RuleSet // The seeker's community rules
+	relatives    map[*matching.RuleSet]map[string]int
+}
+
+func (s *FamilyService) newMatchScreen(seeker *models.Person, seekerFamily *models.Family) *matchScreen {
+	return &matchScreen{
+		service:      s,
+		seeker:       seeker,
+		seekerFamily: seekerFamily,
+		rules:        s.matchRules.For(seekerFamily.Community.Religion, seekerFamily.Community.Caste),
+		relatives:    make(map[*matching.RuleSet]map[string]int),
+	}
+}
+
+// check returns the rules each candidate breaks under either family's rule set, in candidate
+// order. The ancestry of the seeker and all candidates is read together, as deep as the
+// strictest rule set needs.
+func (ms *matchScreen) check(ctx context.Context, candidates []matchCandidate) ([]matchVerdict, error) {
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	verdicts := make([]matchVerdict, len(candidates))
+	persons := make([]*models.Person, 0, len(candidates)+1)
+	persons = append(persons, ms.seeker)
+	generations := ms.rules.AncestorGenerations()
+	for i, candidate := range candidates {
+		community := candidate.family.Community
+		verdicts[i].rules = ms.service.matchRules.For(community.Religion, community.Caste)
+		generations = max(generations, verdicts[i].rules.AncestorGenerations())
+		persons = append(persons, candidate.person)
+	}
+
+	lineages, err := ms.service.findLineages(ctx, persons, generations)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, candidate := range candidates {
+		common := commonAncestors(lineages[ms.seeker.ID], lineages[candidate.person.ID])
+
+		ruleSets := []*matching.RuleSet{ms.rules}
+		if verdicts[i].rules != ms.rules {
+			ruleSets = append(ruleSets, verdicts[i].rules)
+		}
+		for _, rules := range ruleSets {
+			relatives, err := ms.relativesUnder(ctx, rules)
+			if err != nil {
+				return nil, err
+			}
+			relatedness := matching.Relatedness{CommonAncestors: common, RelativeFamilyHops: relatives[candidate.family.ID]}
+			verdicts[i].violations = append(verdicts[i].violations, rules.Evaluate(candidate.person, ms.seekerFamily, candidate.family, relatedness)...)
+		}
+	}
+
+	return verdicts, nil
+}
+
+// relativesUnder finds the seeker's relative families as a rule set labels and limits them,
+// once per rule set
+func (ms *matchScreen) relativesUnder(ctx context.Context, rules *matching.RuleSet) (map[string]int, error) {
+	if relatives, ok := ms.relatives[rules]; ok {
+		return relatives, nil
+	}
+
+	relatives, err := ms.service.relativeFamilies(ctx, ms.seekerFamily.ID, rules, rules.RelativeFamilyHops)
+	if err != nil {
+		return nil, err
+	}
+	ms.relatives[rules] = relatives
+	return relatives, nil
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"families-linkedin/internal/metrics"
+	"families-linkedin/internal/models"
+	"families-linkedin/internal/repository/memory"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// countingPersons counts the person lookups made against the memory backend
+type countingPersons struct {
+	*memory.PersonRepository
+	relationQueries, personQueries int
+}
+
+func (p *countingPersons) GetPersonRelationsBulk(ctx context.Context, personIDs []string) (map[string][]*models.PersonRelation, error) {
+	p.relationQueries++
+	return p.PersonRepository.GetPersonRelationsBulk(ctx, personIDs)
+}
+
+func (p *countingPersons) GetPersonByID(ctx context.Context, personID string) (*models.Person, error) {
+	p.personQueries++
+	return p.PersonRepository.GetPersonByID(ctx, personID)
+}
+
+func (p *countingPersons) GetPersonsByIDs(ctx context.Context, personIDs []string) ([]*models.Person, error) {
+	p.personQueries++
+	return p.PersonRepository.GetPersonsByIDs(ctx, personIDs)
+}
+
+// cousinNetwork stores first cousins in connected families A and B, the seeker's father and
+// the candidate's mother being siblings, with the given religions
+func cousinNetwork(t *testing.T, seekerReligion, candidateReligion string) *testNetwork {
+	network := newTestNetwork(t, nil)
+	for familyID, religion := range map[string]string{"A": seekerReligion, "B": candidateReligion} {
+		family := network.family(familyID)
+		family.Community.Religion = religion
+		if err := network.families.UpdateFamily(context.Background(), family); err != nil {
+			t.Fatalf("UpdateFamily(%s): %v", familyID, err)
+		}
+	}
+	network.connect("A", "B", 0.9, 0)
+
+	network.person("Grandfather", "A", "Male", 80)
+	network.person("Father", "A", "Male", 55)
+	network.person("Mother", "B", "Female", 52)
+	network.person("Seeker", "A", "Male", 28)
+	network.person("Candidate", "B", "Female", 26)
+	network.parent("Grandfather", "Father")
+	network.parent("Grandfather", "Mother")
+	network.parent("Father", "Seeker")
+	network.parent("Mother", "Candidate")
+	return network
+}
+
+func TestGetEligibleMatchesAppliesBothFamiliesRules(t *testing.T) {
+	tests := []struct {
+		name              string
+		seeker, candidate string // Religions of the two families
+		want              []string
+	}{
+		{"neither family's rules exclude cousins", "Muslim", "Muslim", nil},
+		{"only the candidate's rules exclude cousins", "Muslim", "Christian", []string{"Christian: BLOOD_DEGREE", "Christian: RELATIVE_FAMILY"}},
+		{"only the seeker's rules exclude cousins", "Christian", "Muslim", []string{"Christian: BLOOD_DEGREE", "Christian: RELATIVE_FAMILY"}},
+		{"both families' rules exclude cousins", "Hindu", "Christian", []string{"Christian: BLOOD_DEGREE", "Christian: RELATIVE_FAMILY", "Hindu: RELATIVE_FAMILY", "Hindu: SAPINDA"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			network := cousinNetwork(t, tt.seeker, tt.candidate)
+
+			matches, rejections, err := network.familyService().GetEligibleMatches(context.Background(), "Seeker", 1, false)
+			if err != nil {
+				t.Fatalf("GetEligibleMatches: %v", err)
+			}
+
+			var got []string
+			for _, rejection := range rejections {
+				if rejection.RuleSet != tt.seeker || rejection.CandidateRuleSet != tt.candidate {
+					t.Errorf("rule sets = %s and %s, want %s and %s", rejection.RuleSet, rejection.CandidateRuleSet, tt.seeker, tt.candidate)
+				}
+				for _, violation := range rejection.Violations {
+					got = append(got, violation.RuleSet+": "+violation.Rule)
+				}
+			}
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("violations = %v, want %v", got, tt.want)
+			}
+			if wantMatches := len(tt.want) == 0; wantMatches != (len(matches) == 1) {
+				t.Errorf("matches = %d, want a match only when no rule is broken", len(matches))
+			}
+		})
+	}
+}
+
+func TestGetEligibleMatchesReadsAncestryInBulk(t *testing.T) {
+	network := cousinNetwork(t, "Hindu", "Hindu")
+	for _, name := range []string{"Second", "Third", "Fourth"} {
+		network.person(name, "B", "Female", 24)
+		network.parent("Mother", name)
+	}
+
+	persons := &countingPersons{PersonRepository: network.persons}
+	service := NewFamilyService(network.families, persons, network.connections, nil, nil, metrics.NewCollector())
+
+	_, rejections, err := service.GetEligibleMatches(context.Background(), "Seeker", 1, false)
+	if err != nil {
+		t.Fatalf("GetEligibleMatches: %v", err)
+	}
+	if len(rejections) != 4 {
+		t.Fatalf("rejections = %d, want all 4 cousins", len(rejections))
+	}
+
+	// Hindu sapinda rules look 4 generations up: one relation query per generation and one
+	// for the parents' genders, beside the seeker lookup, however many candidates there are
+	if persons.relationQueries > 4 {
+		t.Errorf("made %d relation queries, want at most one per generation", persons.relationQueries)
+	}
+	if persons.personQueries > 2 {
+		t.Errorf("made %d person lookups, want the seeker and one bulk lookup of parents", persons.personQueries)
+	}
+}
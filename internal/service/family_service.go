@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"families-linkedin/internal/matching"
 	"families-linkedin/internal/metrics"
 	"families-linkedin/internal/models"
 	"families-linkedin/internal/repository"
@@ -10,22 +11,31 @@ import (
 )
 
 type FamilyService struct {
-	familyRepo     *repository.FamilyRepository
-	personRepo     *repository.PersonRepository
-	connectionRepo *repository.ConnectionRepository
+	familyRepo     repository.FamilyStore
+	personRepo     repository.PersonStore
+	connectionRepo repository.ConnectionStore
+	decay          *models.StrengthDecay // Discounts connection strength in trust scores
+	matchRules     *matching.Rules       // Consanguinity and exogamy rules per community
 	metrics        *metrics.Collector
 }
 
 func NewFamilyService(
-	familyRepo *repository.FamilyRepository,
-	personRepo *repository.PersonRepository,
-	connectionRepo *repository.ConnectionRepository,
+	familyRepo repository.FamilyStore,
+	personRepo repository.PersonStore,
+	connectionRepo repository.ConnectionStore,
+	decay *models.StrengthDecay,
+	matchRules *matching.Rules,
 	metrics *metrics.Collector,
 ) *FamilyService {
+	if matchRules == nil {
+		matchRules = matching.DefaultRules()
+	}
 	return &FamilyService{
 		familyRepo:     familyRepo,
 		personRepo:     personRepo,
 		connectionRepo: connectionRepo,
+		decay:          decay,
+		matchRules:     matchRules,
 		metrics:        metrics,
 	}
 }
@@ -147,8 +157,12 @@ func (s *FamilyService) GetFamilyMembers(ctx context.Context, familyID string) (
 	return persons, nil
 }
 
-// GetEligibleMatches finds eligible marriage matches within the family network
-func (s *FamilyService) GetEligibleMatches(ctx context.Context, personID string, maxDegree int) ([]*models.EligibleMatch, error) {
+// GetEligibleMatches finds eligible marriage matches within the family network. Candidates
+// who pass the basic checks but break the consanguinity or exogamy rules of the seeker's
+// community or of their own are returned as rejections, with every rule they break and the
+// rule set it belongs to. Mutual matching also requires the candidate's preferences to accept
+// the seeker and scores both sides.
+func (s *FamilyService) GetEligibleMatches(ctx context.Context, personID string, maxDegree int, mutual bool) ([]*models.EligibleMatch, []*models.MatchRejection, error) {
 	start := time.Now()
 	defer s.metrics.RecordDuration("family_service_get_eligible_matches", start)
 
@@ -156,32 +170,30 @@ func (s *FamilyService) GetEligibleMatches(ctx context.Context, personID string,
 	seeker, err := s.personRepo.GetPersonByID(ctx, personID)
 	if err != nil {
 		s.metrics.IncrementCounter("family_service_match_errors")
-		return nil, fmt.Errorf("failed to get person: %w", err)
+		return nil, nil, fmt.Errorf("failed to get person: %w", err)
 	}
 
 	if !seeker.IsEligibleForMarriage() {
 		s.metrics.IncrementCounter("family_service_match_not_eligible")
-		return nil, fmt.Errorf("person is not eligible for marriage")
+		return nil, nil, fmt.Errorf("person is not eligible for marriage")
 	}
 
 	// Get seeker's family
 	seekerFamily, err := s.familyRepo.GetFamilyByID(ctx, seeker.FamilyID)
 	if err != nil {
 		s.metrics.IncrementCounter("family_service_match_errors")
-		return nil, fmt.Errorf("failed to get seeker's family: %w", err)
+		return nil, nil, fmt.Errorf("failed to get seeker's family: %w", err)
 	}
 
-	fmt.Println("seekerFamily", seekerFamily)
 	// Get connected families within the specified degree
 	connectedFamilyIDs, err := s.connectionRepo.GetFamilyConnections(ctx, seeker.FamilyID, maxDegree)
 	if err != nil {
 		s.metrics.IncrementCounter("family_service_match_errors")
-		return nil, fmt.Errorf("failed to get family connections: %w", err)
+		return nil, nil, fmt.Errorf("failed to get family connections: %w", err)
 	}
 
-	var eligibleMatches []*models.EligibleMatch
-
 	// Search for eligible persons in connected families
+	var candidates []matchCandidate
 	for _, familyID := range connectedFamilyIDs {
 		family, err := s.familyRepo.GetFamilyByID(ctx, familyID)
 		if err != nil {
@@ -196,19 +208,55 @@ func (s *FamilyService) GetEligibleMatches(ctx context.Context, personID string,
 
 		for _, candidate := range members {
 			// Check if candidate is eligible and compatible
-			if s.isEligibleCandidate(seeker, candidate) {
-				// Find connection path
-				connectionPath, err := s.connectionRepo.FindShortestPath(ctx, seeker.FamilyID, candidate.FamilyID, maxDegree)
-				if err != nil {
-					continue // Skip if no path found
-				}
+			if !s.isEligibleCandidate(seeker, candidate, seekerFamily, family) {
+				continue
+			}
+			if mutual && !candidate.MatchesPreferences(seeker, family, seekerFamily) {
+				s.metrics.IncrementCounter("family_service_match_unreciprocated")
+				continue // The candidate's preferences rule the seeker out
+			}
+			candidates = append(candidates, matchCandidate{person: candidate, family: family})
+		}
+	}
 
-				match := models.NewEligibleMatch(candidate, family, connectionPath)
-				match.CalculateCompatibilityScore(seeker)
+	// Apply the community rules of both families to all candidates together
+	screen := s.newMatchScreen(seeker, seekerFamily)
+	verdicts, err := screen.check(ctx, candidates)
+	if err != nil {
+		s.metrics.IncrementCounter("family_service_match_errors")
+		return nil, nil, fmt.Errorf("failed to check matching rules: %w", err)
+	}
 
-				eligibleMatches = append(eligibleMatches, match)
-			}
+	var eligibleMatches []*models.EligibleMatch
+	var rejections []*models.MatchRejection
+
+	for i, candidate := range candidates {
+		if violations := verdicts[i].violations; len(violations) > 0 {
+			rejections = append(rejections, &models.MatchRejection{
+				Person:           candidate.person,
+				FamilyID:         candidate.family.ID,
+				RuleSet:          screen.rules.Name,
+				CandidateRuleSet: verdicts[i].rules.Name,
+				Violations:       violations,
+			})
+			s.metrics.IncrementCounter("family_service_match_rejected")
+			continue
+		}
+
+		// Find connection path
+		connectionPath, err := s.connectionRepo.FindShortestPath(ctx, seeker.FamilyID, candidate.family.ID, maxDegree)
+		if err != nil {
+			continue // Skip if no path found
+		}
+
+		match := models.NewEligibleMatch(candidate.person, candidate.family, connectionPath)
+		if mutual {
+			match.CalculateMutualScore(seeker, seekerFamily)
+		} else {
+			match.CalculateCompatibilityScore(seeker, seekerFamily)
 		}
+
+		eligibleMatches = append(eligibleMatches, match)
 	}
 
 	// Sort by compatibility score (highest first)
@@ -216,7 +264,7 @@ func (s *FamilyService) GetEligibleMatches(ctx context.Context, personID string,
 
 	s.metrics.IncrementCounter("family_service_match_success")
 	s.metrics.RecordValue("family_service_matches_found", float64(len(eligibleMatches)))
-	return eligibleMatches, nil
+	return eligibleMatches, rejections, nil
 }
 
 // CalculateFamilyTrustScore calculates and updates the trust score for a family
@@ -224,14 +272,15 @@ func (s *FamilyService) CalculateFamilyTrustScore(ctx context.Context, familyID
 	start := time.Now()
 	defer s.metrics.RecordDuration("family_service_calculate_trust_score", start)
 
-	score, err := s.familyRepo.GetFamilyTrustScore(ctx, familyID)
+	breakdown, err := s.explainTrust(ctx, familyID)
 	if err != nil {
 		s.metrics.IncrementCounter("family_service_trust_score_errors")
 		return 0, fmt.Errorf("failed to calculate trust score: %w", err)
 	}
+	score := breakdown.Score
 
 	// Update the family's trust score
-	if err := s.familyRepo.UpdateFamilyTrustScore(ctx, familyID, score); err != nil {
+	if err := s.familyRepo.UpdateFamilyTrustScore(ctx, familyID, score, models.TrustSourceConnections); err != nil {
 		s.metrics.IncrementCounter("family_service_trust_score_update_errors")
 		return score, fmt.Errorf("failed to update trust score: %w", err)
 	}
@@ -241,6 +290,82 @@ func (s *FamilyService) CalculateFamilyTrustScore(ctx context.Context, familyID
 	return score, nil
 }
 
+// TrustExplanation explains a family's trust score. The stored score is usually the
+// propagated one, which the connection-based breakdown does not explain; Source says which
+// it is.
+type TrustExplanation struct {
+	FamilyID        string                    `json:"family_id"`
+	TrustScore      float64                   `json:"trust_score"` // As currently stored
+	Source          string                    `json:"source"`      // Where the stored score came from, one of the TrustSource values
+	UpdatedAt       time.Time                 `json:"updated_at"`
+	ConnectionScore float64                   `json:"connection_score"` // The connection-based score as computed now
+	Breakdown       *models.TrustBreakdown    `json:"breakdown"`        // How ConnectionScore is made up
+	History         []models.TrustScoreRecord `json:"history"`          // Newest first
+}
+
+// ExplainFamilyTrustScore breaks the connection-based trust score of a family into its
+// weighted components and returns it with the stored score, where that came from and the
+// history of stored scores
+func (s *FamilyService) ExplainFamilyTrustScore(ctx context.Context, familyID string) (*TrustExplanation, error) {
+	start := time.Now()
+	defer s.metrics.RecordDuration("family_service_explain_trust_score", start)
+
+	family, err := s.familyRepo.GetFamilyByID(ctx, familyID)
+	if err != nil {
+		s.metrics.IncrementCounter("family_service_trust_score_errors")
+		return nil, fmt.Errorf("family not found: %w", err)
+	}
+
+	breakdown, err := s.explainTrust(ctx, familyID)
+	if err != nil {
+		s.metrics.IncrementCounter("family_service_trust_score_errors")
+		return nil, fmt.Errorf("failed to calculate trust score: %w", err)
+	}
+
+	history, err := s.familyRepo.GetTrustScoreHistory(ctx, familyID)
+	if err != nil {
+		s.metrics.IncrementCounter("family_service_trust_score_errors")
+		return nil, fmt.Errorf("failed to get trust score history: %w", err)
+	}
+
+	return &TrustExplanation{
+		FamilyID:        familyID,
+		TrustScore:      family.TrustScore,
+		Source:          trustSource(family.TrustScore, history),
+		UpdatedAt:       family.UpdatedAt,
+		ConnectionScore: breakdown.Score,
+		Breakdown:       breakdown,
+		History:         history,
+	}, nil
+}
+
+// trustSource names where a stored trust score came from, given the history newest first.
+// Every calculated score is recorded, so a score the latest entry does not match was set
+// some other way.
+func trustSource(score float64, history []models.TrustScoreRecord) string {
+	switch {
+	case len(history) == 0:
+		return models.TrustSourceDefault
+	case history[0].Score == score:
+		return history[0].Source
+	default:
+		return models.TrustSourceUnrecorded
+	}
+}
+
+// explainTrust computes the connection-based trust breakdown of a family
+func (s *FamilyService) explainTrust(ctx context.Context, familyID string) (*models.TrustBreakdown, error) {
+	factors, err := s.familyRepo.GetFamilyTrustFactors(ctx, familyID, s.decay)
+	if err != nil {
+		return nil, err
+	}
+	if factors == nil {
+		return nil, fmt.Errorf("family not found: %s", familyID)
+	}
+
+	return models.ExplainTrust(factors, time.Now()), nil
+}
+
 // AddFamilyMember adds a new member to a family
 func (s *FamilyService) AddFamilyMember(ctx context.Context, person *models.Person) error {
 	start := time.Now()
@@ -363,7 +488,7 @@ func (s *FamilyService) validateConnection(connection *models.FamilyConnection)
 	return nil
 }
 
-func (s *FamilyService) isEligibleCandidate(seeker, candidate *models.Person) bool {
+func (s *FamilyService) isEligibleCandidate(seeker, candidate *models.Person, seekerFamily, candidateFamily *models.Family) bool {
 	// Basic eligibility checks
 	if !candidate.IsEligibleForMarriage() {
 		return false
@@ -380,7 +505,7 @@ func (s *FamilyService) isEligibleCandidate(seeker, candidate *models.Person) bo
 	}
 
 	// Check if candidate matches seeker's preferences
-	if !seeker.MatchesPreferences(candidate) {
+	if !seeker.MatchesPreferences(candidate, seekerFamily, candidateFamily) {
 		return false
 	}
 
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"families-linkedin/internal/models"
+	"families-linkedin/internal/repository"
+	"testing"
+)
+
+func TestExplainFamilyTrustScoreNamesTheStoredScoresSource(t *testing.T) {
+	ctx := context.Background()
+	network := newTestNetwork(t, yearlyHalving)
+	network.familiesNamed("A", "B")
+	seed := network.family("S")
+	seed.Verification.Status = "VERIFIED"
+	seed.Verification.VerifiedBy = models.VerifiedByStaff
+	if err := network.families.UpdateFamily(ctx, seed); err != nil {
+		t.Fatalf("UpdateFamily: %v", err)
+	}
+	network.connect("S", "A", 0.9, 0)
+	network.connect("A", "B", 0.8, 0)
+
+	families := network.familyService()
+	connections := network.connectionService()
+
+	tests := []struct {
+		name   string
+		update func(t *testing.T)
+		want   string
+	}{
+		{"score given at creation", func(t *testing.T) {}, models.TrustSourceDefault},
+		{"calculated from connections", func(t *testing.T) {
+			if _, err := families.CalculateFamilyTrustScore(ctx, "A"); err != nil {
+				t.Fatalf("CalculateFamilyTrustScore: %v", err)
+			}
+		}, models.TrustSourceConnections},
+		{"propagated from staff-verified families", func(t *testing.T) {
+			if _, err := connections.RecomputeAnalytics(ctx); err != nil {
+				t.Fatalf("RecomputeAnalytics: %v", err)
+			}
+		}, models.TrustSourcePropagated},
+		{"set through a profile update", func(t *testing.T) {
+			family, _ := network.families.GetFamilyByID(ctx, "A")
+			family.TrustScore = 1.5
+			if err := network.families.UpdateFamily(ctx, family); err != nil {
+				t.Fatalf("UpdateFamily: %v", err)
+			}
+		}, models.TrustSourceUnrecorded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.update(t)
+
+			explanation, err := families.ExplainFamilyTrustScore(ctx, "A")
+			if err != nil {
+				t.Fatalf("ExplainFamilyTrustScore: %v", err)
+			}
+			if explanation.Source != tt.want {
+				t.Errorf("source = %q, want %q", explanation.Source, tt.want)
+			}
+			if explanation.ConnectionScore != explanation.Breakdown.Score {
+				t.Errorf("connection score = %.2f, want the breakdown's %.2f", explanation.ConnectionScore, explanation.Breakdown.Score)
+			}
+			if tt.want == models.TrustSourceConnections && explanation.TrustScore != explanation.ConnectionScore {
+				t.Errorf("stored score = %.2f, want the connection score %.2f just calculated", explanation.TrustScore, explanation.ConnectionScore)
+			}
+		})
+	}
+	if _, err := families.ExplainFamilyTrustScore(ctx, "Unknown"); !errors.Is(err, repository.ErrFamilyNotFound) {
+		t.Errorf("ExplainFamilyTrustScore of an unknown family = %v, want %v", err, repository.ErrFamilyNotFound)
+	}
+}
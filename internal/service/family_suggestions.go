@@ -0,0 +1,274 @@
+package service
+
+import (
+	"context"
+	"families-linkedin/internal/models"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// maxSuggestionCandidates bounds the friends-of-friends scored in full, after a first
+	// ranking by Adamic-Adar
+	maxSuggestionCandidates = 200
+
+	// Profile similarity multiplies the structural score
+	suggestionCityBoost        = 0.25
+	suggestionSubCasteBoost    = 0.2
+	suggestionLanguageBoost    = 0.1 // Per shared language
+	suggestionMaxLanguageBoost = 0.2
+
+	// maxReasonNames caps the mutual connections named in a suggestion's reasons
+	maxReasonNames = 2
+)
+
+// suggestionCandidate accumulates the structural evidence for one suggested family
+type suggestionCandidate struct {
+	familyID   string
+	mutualIDs  []string
+	adamicAdar float64
+}
+
+// GetSuggestions ranks families the given family is not connected to yet by how likely
+// a connection is. Candidates are the connections of the family's connections, scored by
+// Adamic-Adar and Jaccard over the shared connections and boosted by a shared city,
+// sub-caste and languages. Dismissed suggestions are never returned.
+func (s *FamilyService) GetSuggestions(ctx context.Context, familyID string, limit int) ([]*models.FamilySuggestion, error) {
+	start := time.Now()
+	defer s.metrics.RecordDuration("family_service_get_suggestions", start)
+
+	if limit <= 0 || limit > 100 {
+		limit = 20 // Default limit
+	}
+
+	family, err := s.familyRepo.GetFamilyByID(ctx, familyID)
+	if err != nil {
+		s.metrics.IncrementCounter("family_service_suggestion_errors")
+		return nil, fmt.Errorf("family not found: %w", err)
+	}
+
+	edges, err := s.connectionRepo.GetFamilyEdges(ctx, familyID)
+	if err != nil {
+		s.metrics.IncrementCounter("family_service_suggestion_errors")
+		return nil, fmt.Errorf("failed to get connections: %w", err)
+	}
+	if len(edges) == 0 {
+		return []*models.FamilySuggestion{}, nil
+	}
+
+	connected := make(map[string]bool, len(edges))
+	neighborIDs := make([]string, 0, len(edges))
+	for _, edge := range edges {
+		if !connected[edge.ToFamilyID] {
+			connected[edge.ToFamilyID] = true
+			neighborIDs = append(neighborIDs, edge.ToFamilyID)
+		}
+	}
+
+	dismissedIDs, err := s.familyRepo.GetDismissedSuggestions(ctx, familyID)
+	if err != nil {
+		s.metrics.IncrementCounter("family_service_suggestion_errors")
+		return nil, fmt.Errorf("failed to get dismissed suggestions: %w", err)
+	}
+	excluded := make(map[string]bool, len(dismissedIDs))
+	for _, dismissedID := range dismissedIDs {
+		excluded[dismissedID] = true
+	}
+
+	neighborEdges, err := s.connectionRepo.GetFamilyEdgesBulk(ctx, neighborIDs)
+	if err != nil {
+		s.metrics.IncrementCounter("family_service_suggestion_errors")
+		return nil, fmt.Errorf("failed to get second degree connections: %w", err)
+	}
+
+	// Every path family -> neighbor -> candidate is one common connection. Neighbors with
+	// fewer connections say more about the pair, so Adamic-Adar weighs each by 1/log(degree).
+	candidates := make(map[string]*suggestionCandidate)
+	for _, neighborID := range neighborIDs {
+		adjacent := neighborEdges[neighborID]
+		if len(adjacent) < 2 {
+			continue // Only connected back to the family
+		}
+		weight := 1 / math.Log(float64(len(adjacent)))
+
+		for _, edge := range adjacent {
+			candidateID := edge.ToFamilyID
+			if candidateID == familyID || connected[candidateID] || excluded[candidateID] {
+				continue
+			}
+
+			candidate, ok := candidates[candidateID]
+			if !ok {
+				candidate = &suggestionCandidate{familyID: candidateID}
+				candidates[candidateID] = candidate
+			}
+			candidate.mutualIDs = append(candidate.mutualIDs, neighborID)
+			candidate.adamicAdar += weight
+		}
+	}
+	if len(candidates) == 0 {
+		return []*models.FamilySuggestion{}, nil
+	}
+
+	ranked := make([]*suggestionCandidate, 0, len(candidates))
+	for _, candidate := range candidates {
+		ranked = append(ranked, candidate)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].adamicAdar != ranked[j].adamicAdar {
+			return ranked[i].adamicAdar > ranked[j].adamicAdar
+		}
+		return ranked[i].familyID < ranked[j].familyID
+	})
+	if len(ranked) > maxSuggestionCandidates {
+		ranked = ranked[:maxSuggestionCandidates]
+	}
+
+	candidateIDs := make([]string, len(ranked))
+	for i, candidate := range ranked {
+		candidateIDs[i] = candidate.familyID
+	}
+
+	candidateEdges, err := s.connectionRepo.GetFamilyEdgesBulk(ctx, candidateIDs)
+	if err != nil {
+		s.metrics.IncrementCounter("family_service_suggestion_errors")
+		return nil, fmt.Errorf("failed to get candidate connections: %w", err)
+	}
+
+	// Load the candidates, and the neighbors so reasons can name them
+	families, err := s.familyRepo.GetFamiliesByIDs(ctx, append(candidateIDs, neighborIDs...))
+	if err != nil {
+		s.metrics.IncrementCounter("family_service_suggestion_errors")
+		return nil, fmt.Errorf("failed to get candidate families: %w", err)
+	}
+	familiesByID := make(map[string]*models.Family, len(families))
+	for _, f := range families {
+		familiesByID[f.ID] = f
+	}
+
+	suggestions := make([]*models.FamilySuggestion, 0, len(ranked))
+	for _, candidate := range ranked {
+		candidateFamily, ok := familiesByID[candidate.familyID]
+		if !ok || candidateFamily.ActiveStatus != "ACTIVE" {
+			continue
+		}
+
+		common := len(candidate.mutualIDs)
+		union := len(neighborIDs) + len(candidateEdges[candidate.familyID]) - common
+		jaccard := 0.0
+		if union > 0 {
+			jaccard = float64(common) / float64(union)
+		}
+
+		boost, reasons := suggestionBoost(family, candidateFamily)
+		reasons = append([]string{mutualReason(candidate.mutualIDs, familiesByID)}, reasons...)
+
+		suggestions = append(suggestions, &models.FamilySuggestion{
+			Family:            candidateFamily,
+			Score:             (candidate.adamicAdar + jaccard) * boost,
+			CommonConnections: common,
+			AdamicAdar:        candidate.adamicAdar,
+			Jaccard:           jaccard,
+			MutualFamilyIDs:   candidate.mutualIDs,
+			Reasons:           reasons,
+		})
+	}
+
+	sort.Slice(suggestions, func(i, j int) bool {
+		if suggestions[i].Score != suggestions[j].Score {
+			return suggestions[i].Score > suggestions[j].Score
+		}
+		return suggestions[i].Family.ID < suggestions[j].Family.ID
+	})
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+
+	s.metrics.IncrementCounter("family_service_suggestion_success")
+	return suggestions, nil
+}
+
+// DismissSuggestion stops a family from being suggested to another family
+func (s *FamilyService) DismissSuggestion(ctx context.Context, familyID, suggestedFamilyID string) error {
+	if familyID == suggestedFamilyID {
+		return fmt.Errorf("a family cannot dismiss itself")
+	}
+
+	for _, id := range []string{familyID, suggestedFamilyID} {
+		if _, err := s.familyRepo.GetFamilyByID(ctx, id); err != nil {
+			s.metrics.IncrementCounter("family_service_suggestion_errors")
+			return fmt.Errorf("family not found: %w", err)
+		}
+	}
+
+	if err := s.familyRepo.DismissSuggestion(ctx, familyID, suggestedFamilyID); err != nil {
+		s.metrics.IncrementCounter("family_service_suggestion_errors")
+		return fmt.Errorf("failed to dismiss suggestion: %w", err)
+	}
+
+	s.metrics.IncrementCounter("family_service_suggestion_dismissed")
+	return nil
+}
+
+// suggestionBoost returns the multiplier for profile similarity and the reasons behind it
+func suggestionBoost(family, candidate *models.Family) (float64, []string) {
+	boost := 1.0
+	var reasons []string
+
+	if family.Location.City != "" && strings.EqualFold(family.Location.City, candidate.Location.City) {
+		boost += suggestionCityBoost
+		reasons = append(reasons, fmt.Sprintf("Also in %s", candidate.Location.City))
+	}
+
+	if family.Community.SubCaste != "" && strings.EqualFold(family.Community.SubCaste, candidate.Community.SubCaste) {
+		boost += suggestionSubCasteBoost
+		reasons = append(reasons, fmt.Sprintf("Same sub-caste (%s)", candidate.Community.SubCaste))
+	}
+
+	var shared []string
+	for _, language := range candidate.Community.Languages {
+		for _, own := range family.Community.Languages {
+			if strings.EqualFold(language, own) {
+				shared = append(shared, language)
+				break
+			}
+		}
+	}
+	if len(shared) > 0 {
+		boost += math.Min(float64(len(shared))*suggestionLanguageBoost, suggestionMaxLanguageBoost)
+		reasons = append(reasons, fmt.Sprintf("Both speak %s", strings.Join(shared, ", ")))
+	}
+
+	return boost, reasons
+}
+
+// mutualReason describes the connections two families share, naming the first few.
+// Mutual IDs arrive in the order of the family's connections, most trusted first.
+func mutualReason(mutualIDs []string, families map[string]*models.Family) string {
+	var names []string
+	for _, mutualID := range mutualIDs {
+		if len(names) == maxReasonNames {
+			break
+		}
+		if family, ok := families[mutualID]; ok && family.Name != "" {
+			names = append(names, family.Name)
+		}
+	}
+
+	if len(mutualIDs) == 1 {
+		if len(names) == 1 {
+			return fmt.Sprintf("1 mutual connection: %s", names[0])
+		}
+		return "1 mutual connection"
+	}
+	if len(names) == 0 {
+		return fmt.Sprintf("%d mutual connections", len(mutualIDs))
+	}
+	if others := len(mutualIDs) - len(names); others > 0 {
+		return fmt.Sprintf("%d mutual connections, including %s", len(mutualIDs), strings.Join(names, " and "))
+	}
+	return fmt.Sprintf("%d mutual connections: %s", len(mutualIDs), strings.Join(names, " and "))
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestGetSuggestions(t *testing.T) {
+	ctx := context.Background()
+	network := newTestNetwork(t, yearlyHalving)
+	network.familiesNamed("Me", "M1", "M2", "M3", "X", "Y", "D")
+	network.connect("Me", "M1", 0.9, 0)
+	network.connect("Me", "M2", 0.9, 0)
+	network.connect("Me", "M3", 0.9, 0)
+	network.connect("M1", "M2", 0.9, 0) // Both already connected to Me
+	network.connect("M1", "X", 0.9, 0)
+	network.connect("M2", "X", 0.9, 0)
+	network.connect("M3", "Y", 0.9, 0)
+	network.connect("M1", "D", 0.9, 0)
+	network.connect("M2", "D", 0.9, 0)
+	network.connect("M3", "D", 0.9, 0)
+
+	service := network.familyService()
+
+	// M1, M2 and M3 are connections of Me already, so only X, Y and D are ever suggested
+	tests := []struct {
+		name    string
+		dismiss string // Suggestion dismissed before the request, if any
+		limit   int
+		want    []string // Suggested family and its mutual connection count, best first
+	}{
+		{"ranked by mutual connections", "", 0, []string{"D/3", "X/2", "Y/1"}},
+		{"limited", "", 2, []string{"D/3", "X/2"}},
+		{"dismissed suggestion excluded", "D", 0, []string{"X/2", "Y/1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.dismiss != "" {
+				if err := service.DismissSuggestion(ctx, "Me", tt.dismiss); err != nil {
+					t.Fatalf("DismissSuggestion: %v", err)
+				}
+			}
+
+			suggestions, err := service.GetSuggestions(ctx, "Me", tt.limit)
+			if err != nil {
+				t.Fatalf("GetSuggestions: %v", err)
+			}
+
+			got := []string{}
+			for _, suggestion := range suggestions {
+				got = append(got, fmt.Sprintf("%s/%d", suggestion.Family.ID, suggestion.CommonConnections))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("suggestions = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if err := service.DismissSuggestion(ctx, "Me", "Me"); err == nil {
+		t.Errorf("DismissSuggestion of the family itself succeeded")
+	}
+}
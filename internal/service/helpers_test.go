@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"families-linkedin/internal/config"
+	"families-linkedin/internal/metrics"
+	"families-linkedin/internal/models"
+	"families-linkedin/internal/repository/memory"
+	"testing"
+	"time"
+)
+
+// testNetwork is a family graph held in the memory backend, with services over it
+type testNetwork struct {
+	t           *testing.T
+	families    *memory.FamilyRepository
+	persons     *memory.PersonRepository
+	connections *memory.ConnectionRepository
+	decay       *models.StrengthDecay
+}
+
+func newTestNetwork(t *testing.T, decay *models.StrengthDecay) *testNetwork {
+	store := memory.NewStore()
+	return &testNetwork{
+		t:           t,
+		families:    memory.NewFamilyRepository(store),
+		persons:     memory.NewPersonRepository(store),
+		connections: memory.NewConnectionRepository(store),
+		decay:       decay,
+	}
+}
+
+func (n *testNetwork) familyService() *FamilyService {
+	return NewFamilyService(n.families, n.persons, n.connections, n.decay, nil, metrics.NewCollector())
+}
+
+func (n *testNetwork) connectionService() *ConnectionService {
+	return NewConnectionService(n.connections, n.families, config.PerformanceConfig{}, n.decay, nil, metrics.NewCollector())
+}
+
+// family stores an active family whose ID is its name
+func (n *testNetwork) family(id string) *models.Family {
+	n.t.Helper()
+
+	family := models.NewFamily(id, id)
+	family.ID = id
+	family.Location.City = "Pune"
+	family.Location.State = "Maharashtra"
+	family.Community.Religion = "Hindu"
+	family.Community.Caste = "Brahmin"
+	if err := n.families.CreateFamily(context.Background(), family); err != nil {
+		n.t.Fatalf("CreateFamily(%s): %v", id, err)
+	}
+	return family
+}
+
+// connect joins two stored families with a verified connection last confirmed age ago
+func (n *testNetwork) connect(fromID, toID string, strength float64, age time.Duration) *models.FamilyConnection {
+	n.t.Helper()
+
+	connection := models.NewFamilyConnection(fromID, toID, "RELATIVE", "COUSIN", strength, true)
+	connection.CreatedAt = time.Now().Add(-age)
+	connection.EstablishedDate = connection.CreatedAt
+	if err := n.connections.CreateConnection(context.Background(), connection); err != nil {
+		n.t.Fatalf("CreateConnection(%s, %s): %v", fromID, toID, err)
+	}
+	return connection
+}
+
+// person stores a person whose ID is their name, born the given number of years ago
+func (n *testNetwork) person(id, familyID, gender string, age int) *models.Person {
+	n.t.Helper()
+
+	person := models.NewPerson(familyID, id, familyID, gender, time.Now().AddDate(-age, 0, -1))
+	person.ID = id
+	if err := n.persons.CreatePerson(context.Background(), person); err != nil {
+		n.t.Fatalf("CreatePerson(%s): %v", id, err)
+	}
+	return person
+}
+
+// parent records that one stored person is another's parent
+func (n *testNetwork) parent(parentID, childID string) {
+	n.t.Helper()
+
+	relation := models.NewPersonRelation(parentID, childID, models.RelationParentOf)
+	if err := n.persons.CreatePersonRelation(context.Background(), relation); err != nil {
+		n.t.Fatalf("CreatePersonRelation(%s, %s): %v", parentID, childID, err)
+	}
+}
+
+// familiesNamed stores a family for every ID
+func (n *testNetwork) familiesNamed(ids ...string) {
+	n.t.Helper()
+	for _, id := range ids {
+		n.family(id)
+	}
+}
+
+// yearlyHalving halves connection strength every year since it was last confirmed
+var yearlyHalving = &models.StrengthDecay{Function: models.DecayExponential, HalfLife: 365 * 24 * time.Hour}
+
+const year = 365 * 24 * time.Hour
@@ -0,0 +1,308 @@
+package service
+
+import (
+	"context"
+	"families-linkedin/internal/algorithms"
+	"families-linkedin/internal/metrics"
+	"families-linkedin/internal/models"
+	"families-linkedin/internal/repository"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Weights of the introduction score components, summing to 1
+const (
+	introductionReachWeight        = 0.35 // Decayed strength of the connections from the source to the intermediary
+	introductionVerificationWeight = 0.15 // Verified connections to the intermediary and a verified intermediary
+	introductionTrustWeight        = 0.2  // The intermediary's trust score
+	introductionClosenessWeight    = 0.3  // Decayed strength of the rest of the path to the target
+)
+
+// maxIntroductionHop is how far along a path intermediaries are worth approaching: the
+// families the source knows and the families they know
+const maxIntroductionHop = 2
+
+type IntroductionService struct {
+	connectionService *ConnectionService
+	familyRepo        repository.FamilyStore
+	personRepo        repository.PersonStore
+	metrics           *metrics.Collector
+}
+
+func NewIntroductionService(
+	connectionService *ConnectionService,
+	familyRepo repository.FamilyStore,
+	personRepo repository.PersonStore,
+	metrics *metrics.Collector,
+) *IntroductionService {
+	return &IntroductionService{
+		connectionService: connectionService,
+		familyRepo:        familyRepo,
+		personRepo:        personRepo,
+		metrics:           metrics,
+	}
+}
+
+// IntroductionCandidate is a family worth approaching for an introduction to the target
+type IntroductionCandidate struct {
+	Family             *models.Family         `json:"family"`
+	Contacts           []*models.Person       `json:"contacts"` // Members of the family, eldest first
+	Hop                int                    `json:"hop"`      // 1 for a family the source is connected to, 2 for one of theirs
+	HopsToTarget       int                    `json:"hops_to_target"`
+	Score              float64                `json:"score"`
+	ReachStrength      float64                `json:"reach_strength"` // Decayed strength of the connections from the source
+	Closeness          float64                `json:"closeness"`      // Decayed strength of the rest of the path to the target
+	Verified           bool                   `json:"verified"`       // True if the connections from the source are all verified
+	PathCount          int                    `json:"path_count"`     // Evaluated paths passing through the family
+	Path               *models.ConnectionPath `json:"path"`           // Best evaluated path through the family
+	PathClassification string                 `json:"path_classification"`
+	Reasons            []string               `json:"reasons"`
+}
+
+// IntroductionPlan ranks the families a source family could approach to reach a person
+type IntroductionPlan struct {
+	SourceFamilyID string                   `json:"source_family_id"`
+	TargetPerson   *models.Person           `json:"target_person"`
+	TargetFamilyID string                   `json:"target_family_id"`
+	PathsEvaluated int                      `json:"paths_evaluated"`
+	Candidates     []*IntroductionCandidate `json:"candidates"`
+	CalculatedAt   time.Time                `json:"calculated_at"`
+}
+
+// RecommendIntroductions answers whom a family should approach first to be introduced to a
+// person. It evaluates up to maxPaths shortest paths to the person's family and scores the
+// first and second families along each by the strength of the connections reaching them,
+// verification, trust score and how strongly they are connected onward to the target. A
+// family on several paths is scored by its best one. When the families are directly
+// connected, the target's own family is a candidate too.
+func (s *IntroductionService) RecommendIntroductions(ctx context.Context, sourceFamilyID, targetPersonID string, maxDepth, maxPaths, limit int) (*IntroductionPlan, error) {
+	start := time.Now()
+	defer s.metrics.RecordDuration("introduction_service_recommend", start)
+
+	if limit <= 0 || limit > 20 {
+		limit = 5 // Default limit
+	}
+
+	target, err := s.personRepo.GetPersonByID(ctx, targetPersonID)
+	if err != nil {
+		s.metrics.IncrementCounter("introduction_service_recommend_errors")
+		return nil, fmt.Errorf("person not found: %w", err)
+	}
+	if target.FamilyID == sourceFamilyID {
+		s.metrics.IncrementCounter("introduction_service_recommend_errors")
+		return nil, fmt.Errorf("the person belongs to the source family")
+	}
+
+	source, err := s.familyRepo.GetFamilyByID(ctx, sourceFamilyID)
+	if err != nil {
+		s.metrics.IncrementCounter("introduction_service_recommend_errors")
+		return nil, fmt.Errorf("family not found: %w", err)
+	}
+
+	paths, err := s.connectionService.FindMultipleConnectionPaths(ctx, sourceFamilyID, target.FamilyID, maxDepth, maxPaths, algorithms.RankByHops, nil)
+	if err != nil {
+		s.metrics.IncrementCounter("introduction_service_recommend_errors")
+		return nil, fmt.Errorf("failed to find paths to the person's family: %w", err)
+	}
+
+	plan := &IntroductionPlan{
+		SourceFamilyID: sourceFamilyID,
+		TargetPerson:   target,
+		TargetFamilyID: target.FamilyID,
+		PathsEvaluated: len(paths),
+		Candidates:     []*IntroductionCandidate{},
+		CalculatedAt:   time.Now(),
+	}
+	if len(paths) == 0 {
+		s.metrics.RecordValue("introduction_service_candidates", 0)
+		return plan, nil
+	}
+
+	// Load every family along the paths in one round trip
+	var familyIDs []string
+	seen := map[string]bool{sourceFamilyID: true}
+	for _, path := range paths {
+		for _, familyID := range path.Path {
+			if !seen[familyID] {
+				seen[familyID] = true
+				familyIDs = append(familyIDs, familyID)
+			}
+		}
+	}
+	families, err := s.familyRepo.GetFamiliesByIDs(ctx, familyIDs)
+	if err != nil {
+		s.metrics.IncrementCounter("introduction_service_recommend_errors")
+		return nil, fmt.Errorf("failed to get families along the paths: %w", err)
+	}
+	familiesByID := make(map[string]*models.Family, len(families)+1)
+	familiesByID[sourceFamilyID] = source
+	for _, family := range families {
+		familiesByID[family.ID] = family
+	}
+
+	// The paths carry their connections at decayed strength, so analysing them needs no queries
+	candidates := make(map[string]*IntroductionCandidate)
+	for _, path := range paths {
+		analysis, err := s.connectionService.AnalyzeConnectionStrength(ctx, path)
+		if err != nil {
+			s.metrics.IncrementCounter("introduction_service_recommend_errors")
+			return nil, fmt.Errorf("failed to analyze path: %w", err)
+		}
+
+		for hop := 1; hop <= maxIntroductionHop && hop < len(path.Path); hop++ {
+			familyID := path.Path[hop]
+			family, ok := familiesByID[familyID]
+			if !ok || family.ActiveStatus != "ACTIVE" {
+				continue
+			}
+			if familyID == target.FamilyID && hop > 1 {
+				continue // Reached through an intermediary already on the path
+			}
+
+			candidate := scoreIntroduction(path, hop, family)
+			candidate.PathClassification = analysis.PathClassification
+			candidate.Reasons = introductionReasons(candidate, path, analysis, familiesByID, target.FamilyID)
+
+			existing, ok := candidates[familyID]
+			if !ok {
+				candidate.PathCount = 1
+				candidates[familyID] = candidate
+				continue
+			}
+			existing.PathCount++
+			if candidate.Score > existing.Score {
+				candidate.PathCount = existing.PathCount
+				candidates[familyID] = candidate
+			}
+		}
+	}
+
+	for _, candidate := range candidates {
+		if candidate.PathCount > 1 {
+			candidate.Reasons = append(candidate.Reasons, fmt.Sprintf("On %d of the %d paths found", candidate.PathCount, len(paths)))
+		}
+		plan.Candidates = append(plan.Candidates, candidate)
+	}
+	sort.Slice(plan.Candidates, func(i, j int) bool {
+		if plan.Candidates[i].Score != plan.Candidates[j].Score {
+			return plan.Candidates[i].Score > plan.Candidates[j].Score
+		}
+		return plan.Candidates[i].Family.ID < plan.Candidates[j].Family.ID
+	})
+	if len(plan.Candidates) > limit {
+		plan.Candidates = plan.Candidates[:limit]
+	}
+
+	// Name the people to approach in each recommended family
+	for _, candidate := range plan.Candidates {
+		members, err := s.personRepo.GetPersonsByFamilyID(ctx, candidate.Family.ID)
+		if err != nil {
+			s.metrics.IncrementCounter("introduction_service_recommend_errors")
+			return nil, fmt.Errorf("failed to get members of %s: %w", candidate.Family.ID, err)
+		}
+
+		contacts := make([]*models.Person, 0, len(members))
+		for _, member := range members {
+			if member.ID != target.ID {
+				contacts = append(contacts, member)
+			}
+		}
+		sort.SliceStable(contacts, func(i, j int) bool {
+			return contacts[i].Age > contacts[j].Age
+		})
+		candidate.Contacts = contacts
+	}
+
+	s.metrics.IncrementCounter("introduction_service_recommend_success")
+	s.metrics.RecordValue("introduction_service_candidates", float64(len(plan.Candidates)))
+
+	return plan, nil
+}
+
+// scoreIntroduction scores the family hop connections along a path as an intermediary
+func scoreIntroduction(path *models.ConnectionPath, hop int, family *models.Family) *IntroductionCandidate {
+	candidate := &IntroductionCandidate{
+		Family:        family,
+		Hop:           hop,
+		HopsToTarget:  len(path.Path) - 1 - hop,
+		ReachStrength: 1.0,
+		Closeness:     1.0,
+		Verified:      true,
+		Path:          path,
+	}
+
+	verifiedEdges := 0
+	for i, edge := range path.Edges {
+		if i < hop {
+			candidate.ReachStrength *= edge.DecayedStrength
+			if edge.Verified {
+				verifiedEdges++
+			} else {
+				candidate.Verified = false
+			}
+		} else {
+			candidate.Closeness *= edge.DecayedStrength
+		}
+	}
+
+	verification := float64(verifiedEdges) / float64(hop)
+	if family.Verification.Status == "VERIFIED" {
+		verification = (verification + 1) / 2
+	} else {
+		verification /= 2
+	}
+
+	candidate.Score = introductionReachWeight*candidate.ReachStrength +
+		introductionVerificationWeight*verification +
+		introductionTrustWeight*family.TrustScore/10 +
+		introductionClosenessWeight*candidate.Closeness
+
+	return candidate
+}
+
+// introductionReasons explains a candidate's score in plain sentences
+func introductionReasons(candidate *IntroductionCandidate, path *models.ConnectionPath, analysis *ConnectionAnalysis, families map[string]*models.Family, targetFamilyID string) []string {
+	var reasons []string
+
+	switch {
+	case candidate.Family.ID == targetFamilyID:
+		reasons = append(reasons, fmt.Sprintf("Your families are directly connected (strength %.2f)", candidate.ReachStrength))
+	case candidate.Hop == 1:
+		reasons = append(reasons, fmt.Sprintf("Directly connected to your family (strength %.2f)", candidate.ReachStrength))
+	default:
+		reasons = append(reasons, fmt.Sprintf("Reachable through %s (strength %.2f)", familyName(families, path.Path[1]), candidate.ReachStrength))
+	}
+
+	if candidate.Verified {
+		reasons = append(reasons, "Every connection on the way is verified")
+	}
+	if candidate.Family.Verification.Status == "VERIFIED" {
+		reasons = append(reasons, "Verified family")
+	}
+	reasons = append(reasons, fmt.Sprintf("Trust score %.1f/10", candidate.Family.TrustScore))
+
+	switch candidate.HopsToTarget {
+	case 0:
+	case 1:
+		reasons = append(reasons, fmt.Sprintf("Directly connected to the target's family (strength %.2f)", candidate.Closeness))
+	default:
+		reasons = append(reasons, fmt.Sprintf("%d hops from the target's family (strength %.2f)", candidate.HopsToTarget, candidate.Closeness))
+	}
+
+	if len(path.Path) > 2 {
+		weakest := analysis.WeakestLinkIndex
+		reasons = append(reasons, fmt.Sprintf("%s path; weakest link %s to %s (%.2f)", analysis.PathClassification,
+			familyName(families, path.Path[weakest]), familyName(families, path.Path[weakest+1]), analysis.WeakestLink))
+	}
+
+	return reasons
+}
+
+// familyName returns a family's name, falling back to its ID
+func familyName(families map[string]*models.Family, familyID string) string {
+	if family, ok := families[familyID]; ok && family.Name != "" {
+		return family.Name
+	}
+	return familyID
+}
@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+	"families-linkedin/internal/metrics"
+	"families-linkedin/internal/models"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRecommendIntroductionsJudgesPathsAtDecayedStrength(t *testing.T) {
+	network := newTestNetwork(t, yearlyHalving)
+	network.familiesNamed("A", "B", "C")
+	network.connect("A", "B", 0.9, 0)
+	network.connect("B", "C", 0.9, 2*year) // Stored as 0.9, decayed to 0.225
+
+	target := models.NewPerson("C", "Meera", "C", "Female", time.Now().AddDate(-27, 0, 0))
+	if err := network.persons.CreatePerson(context.Background(), target); err != nil {
+		t.Fatalf("CreatePerson: %v", err)
+	}
+
+	introductions := NewIntroductionService(network.connectionService(), network.families, network.persons, metrics.NewCollector())
+	plan, err := introductions.RecommendIntroductions(context.Background(), "A", target.ID, 4, 3, 5)
+	if err != nil {
+		t.Fatalf("RecommendIntroductions: %v", err)
+	}
+	if len(plan.Candidates) != 1 || plan.Candidates[0].Family.ID != "B" {
+		t.Fatalf("candidates = %v, want only B", plan.Candidates)
+	}
+
+	candidate := plan.Candidates[0]
+	// Undecayed, both links are 0.9 and the path would be Strong
+	if candidate.PathClassification != "Weak" {
+		t.Errorf("classification = %q, want %q for an average decayed strength of 0.56", candidate.PathClassification, "Weak")
+	}
+	want := "weakest link B to C (0.22)"
+	if reasons := strings.Join(candidate.Reasons, "; "); !strings.Contains(reasons, want) {
+		t.Errorf("reasons %q do not mention %q", reasons, want)
+	}
+}
@@ -3,10 +3,14 @@ package main
 import (
 	"context"
 	"families-linkedin/internal/api"
+	"families-linkedin/internal/cache"
 	"families-linkedin/internal/config"
 	"families-linkedin/internal/database"
+	"families-linkedin/internal/matching"
 	"families-linkedin/internal/metrics"
+	"families-linkedin/internal/models"
 	"families-linkedin/internal/repository"
+	"families-linkedin/internal/repository/memory"
 	"families-linkedin/internal/service"
 	"fmt"
 	"log"
@@ -29,6 +33,7 @@ func main() {
 	fmt.Println("Configuration loaded successfully")
 	fmt.Println("Environment:", cfg.Environment)
 	fmt.Println("Server address:", cfg.Server.Address)
+	fmt.Println("Storage backend:", cfg.Storage.Backend)
 	fmt.Println("Neo4j connection:", cfg.Neo4j.URI)
 	fmt.Println("Neo4j username:", cfg.Neo4j.Username)
 	fmt.Println("Neo4j password:", cfg.Neo4j.Password)
@@ -41,26 +46,110 @@ func main() {
 	metricsCollector := metrics.NewCollector()
 	metrics.RegisterMetrics(metricsCollector)
 
-	// Connect to Neo4j
-	neo4jDriver, err := database.NewNeo4jConnection(cfg.Neo4j)
-	if err != nil {
-		log.Fatal("Failed to connect to Neo4j:", err)
+	// Initialize repositories
+	var (
+		familyRepo     repository.FamilyStore
+		personRepo     repository.PersonStore
+		connectionRepo repository.ConnectionStore
+	)
+
+	switch cfg.Storage.Backend {
+	case "memory":
+		log.Println("Using in-memory graph backend")
+		store := memory.NewStore()
+		familyRepo = memory.NewFamilyRepository(store)
+		personRepo = memory.NewPersonRepository(store)
+		connectionRepo = memory.NewConnectionRepository(store)
+	case "neo4j":
+		// Connect to Neo4j
+		neo4jDriver, err := database.NewNeo4jConnection(cfg.Neo4j)
+		if err != nil {
+			log.Fatal("Failed to connect to Neo4j:", err)
+		}
+		defer neo4jDriver.Close(context.Background())
+
+		// Verify database connection
+		if err := database.VerifyConnection(neo4jDriver); err != nil {
+			log.Fatal("Failed to verify Neo4j connection:", err)
+		}
+
+		familyRepo = repository.NewFamilyRepository(neo4jDriver)
+		personRepo = repository.NewPersonRepository(neo4jDriver)
+		connectionRepo = repository.NewConnectionRepository(neo4jDriver)
+	default:
+		log.Fatalf("Unknown storage backend: %s", cfg.Storage.Backend)
 	}
-	defer neo4jDriver.Close(context.Background())
 
-	// Verify database connection
-	if err := database.VerifyConnection(neo4jDriver); err != nil {
-		log.Fatal("Failed to verify Neo4j connection:", err)
+	// Initialize the cache shared by path searches and family lookups
+	var cacheBackend cache.Backend
+	if cfg.Performance.CacheEnabled {
+		switch cfg.Performance.CacheBackend {
+		case "memory":
+			cacheBackend = cache.NewMemoryBackend(cfg.Performance.CacheMaxEntries, metricsCollector)
+		case "redis":
+			log.Println("Using Redis cache backend at", cfg.Redis.Address)
+			redisBackend, err := cache.NewRedisBackend(context.Background(), cache.RedisOptions{
+				Address:   cfg.Redis.Address,
+				Password:  cfg.Redis.Password,
+				DB:        cfg.Redis.DB,
+				PoolSize:  cfg.Redis.PoolSize,
+				KeyPrefix: cfg.Redis.KeyPrefix,
+			}, metricsCollector)
+			if err != nil {
+				log.Fatal("Failed to connect to Redis:", err)
+			}
+			defer redisBackend.Close()
+			cacheBackend = redisBackend
+		default:
+			log.Fatalf("Unknown cache backend: %s", cfg.Performance.CacheBackend)
+		}
+
+		familyRepo = repository.NewCachedFamilyRepository(familyRepo, cacheBackend, cfg.Performance.CacheTTL, metricsCollector)
 	}
 
-	// Initialize repositories
-	familyRepo := repository.NewFamilyRepository(neo4jDriver)
-	personRepo := repository.NewPersonRepository(neo4jDriver)
-	connectionRepo := repository.NewConnectionRepository(neo4jDriver)
+	// Discount connection strength by the time since each connection was last confirmed
+	if !models.IsValidDecayFunction(cfg.Decay.Function) {
+		log.Fatalf("Unknown strength decay function: %s", cfg.Decay.Function)
+	}
+	strengthDecay := &models.StrengthDecay{
+		Function:     cfg.Decay.Function,
+		HalfLife:     cfg.Decay.HalfLife,
+		StepInterval: cfg.Decay.StepInterval,
+		StepFactor:   cfg.Decay.StepFactor,
+		MinFactor:    cfg.Decay.MinFactor,
+	}
+
+	// Consanguinity and exogamy rules applied to marriage matches
+	matchRules := matching.DefaultRules()
+	if cfg.Matching.RulesFile != "" {
+		if matchRules, err = matching.LoadRules(cfg.Matching.RulesFile); err != nil {
+			log.Fatal("Failed to load matching rules:", err)
+		}
+	}
 
 	// Initialize services
-	familyService := service.NewFamilyService(familyRepo, personRepo, connectionRepo, metricsCollector)
-	connectionService := service.NewConnectionService(connectionRepo, familyRepo, metricsCollector)
+	familyService := service.NewFamilyService(familyRepo, personRepo, connectionRepo, strengthDecay, matchRules, metricsCollector)
+	connectionService := service.NewConnectionService(connectionRepo, familyRepo, cfg.Performance, strengthDecay, cacheBackend, metricsCollector)
+	introductionService := service.NewIntroductionService(connectionService, familyRepo, personRepo, metricsCollector)
+
+	// Apply connection changes made by other replicas to the path cache until shutdown
+	cacheCtx, stopCache := context.WithCancel(context.Background())
+	defer stopCache()
+	if err := connectionService.StartCacheInvalidation(cacheCtx); err != nil {
+		log.Fatal("Failed to subscribe to cache invalidations:", err)
+	}
+
+	// Load the in-memory graph snapshot, if enabled, and keep it refreshed until shutdown
+	snapshotCtx, stopSnapshot := context.WithCancel(context.Background())
+	defer stopSnapshot()
+	if err := connectionService.StartGraphSnapshot(snapshotCtx); err != nil {
+		log.Fatal("Failed to load graph snapshot:", err)
+	}
+
+	// Recompute graph centrality in the background until shutdown
+	analyticsCtx, stopAnalytics := context.WithCancel(context.Background())
+	defer stopAnalytics()
+	connectionService.StartAnalytics(analyticsCtx)
 
 	// Setup Gin router
 	if cfg.Environment == "production" {
@@ -81,7 +170,7 @@ func main() {
 	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
 
 	// Initialize API handlers
-	api.SetupRoutes(router, familyService, connectionService)
+	api.SetupRoutes(router, familyService, connectionService, introductionService)
 
 	// Start HTTP server
 	server := &http.Server{